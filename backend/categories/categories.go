@@ -0,0 +1,142 @@
+// Package categories assigns dashboard categories to event types. Events are
+// categorized once, when they are stored, from an ordered rule table; stats and
+// filters read the stored category instead of re-parsing event types.
+package categories
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Taxonomy is the category tree and the rules that place event types in it
+type Taxonomy struct {
+	Categories []Category `json:"categories"`
+	Rules      []Rule     `json:"rules"`
+	Fallback   Assignment `json:"fallback"` // Used when no rule matches
+}
+
+// Category is a top-level dashboard category such as "deployments"
+type Category struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description,omitempty"`
+	Subcategories []Subcategory `json:"subcategories"`
+}
+
+// Subcategory groups related event types within a category
+type Subcategory struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Assignment is the category and subcategory given to an event type
+type Assignment struct {
+	Category    string `json:"category"`
+	Subcategory string `json:"subcategory"`
+}
+
+// Rule assigns a category to the event types it matches. Match is either an exact
+// event type ("github.ci") or a prefix ending in '*' ("vercel.*", "github.issue*").
+// Rules are evaluated in order and the first match wins.
+type Rule struct {
+	Match       string `json:"match"`
+	Category    string `json:"category"`
+	Subcategory string `json:"subcategory"`
+}
+
+// matches reports whether the rule applies to eventType
+func (r Rule) matches(eventType string) bool {
+	if prefix, ok := strings.CutSuffix(r.Match, "*"); ok {
+		return strings.HasPrefix(eventType, prefix)
+	}
+	return eventType == r.Match
+}
+
+// Categorizer assigns categories from a validated taxonomy
+type Categorizer struct {
+	taxonomy Taxonomy
+}
+
+// New creates a categorizer, validating the taxonomy first
+func New(taxonomy Taxonomy) (*Categorizer, error) {
+	if err := taxonomy.Validate(); err != nil {
+		return nil, err
+	}
+	return &Categorizer{taxonomy: taxonomy}, nil
+}
+
+// Default returns a categorizer for the built-in taxonomy
+func Default() *Categorizer {
+	return &Categorizer{taxonomy: DefaultTaxonomy()}
+}
+
+// Categorize returns the category and subcategory of an event type
+func (c *Categorizer) Categorize(eventType string) (category, subcategory string) {
+	for _, rule := range c.taxonomy.Rules {
+		if rule.matches(eventType) {
+			return rule.Category, rule.Subcategory
+		}
+	}
+	return c.taxonomy.Fallback.Category, c.taxonomy.Fallback.Subcategory
+}
+
+// Taxonomy returns the categories and rules the categorizer uses
+func (c *Categorizer) Taxonomy() Taxonomy {
+	return c.taxonomy
+}
+
+// Validate checks that every rule and the fallback point at a declared category
+// and subcategory
+func (t *Taxonomy) Validate() error {
+	if len(t.Categories) == 0 {
+		return fmt.Errorf("at least one category is required")
+	}
+
+	subcategories := make(map[string]map[string]bool, len(t.Categories))
+	for i, category := range t.Categories {
+		if category.ID == "" {
+			return fmt.Errorf("category %d: id is required", i)
+		}
+		if subcategories[category.ID] != nil {
+			return fmt.Errorf("category %q: duplicate id", category.ID)
+		}
+		subcategories[category.ID] = make(map[string]bool, len(category.Subcategories))
+		for j, subcategory := range category.Subcategories {
+			if subcategory.ID == "" {
+				return fmt.Errorf("category %q: subcategory %d: id is required", category.ID, j)
+			}
+			if subcategories[category.ID][subcategory.ID] {
+				return fmt.Errorf("category %q: duplicate subcategory %q", category.ID, subcategory.ID)
+			}
+			subcategories[category.ID][subcategory.ID] = true
+		}
+	}
+
+	checkAssignment := func(category, subcategory string) error {
+		declared, ok := subcategories[category]
+		if !ok {
+			return fmt.Errorf("unknown category %q", category)
+		}
+		if !declared[subcategory] {
+			return fmt.Errorf("category %q has no subcategory %q", category, subcategory)
+		}
+		return nil
+	}
+
+	for i, rule := range t.Rules {
+		if rule.Match == "" || rule.Match == "*" {
+			return fmt.Errorf("rule %d: match must be an event type or prefix", i)
+		}
+		if strings.Contains(strings.TrimSuffix(rule.Match, "*"), "*") {
+			return fmt.Errorf("rule %q: '*' is only allowed at the end of match", rule.Match)
+		}
+		if err := checkAssignment(rule.Category, rule.Subcategory); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Match, err)
+		}
+	}
+
+	if err := checkAssignment(t.Fallback.Category, t.Fallback.Subcategory); err != nil {
+		return fmt.Errorf("fallback: %w", err)
+	}
+	return nil
+}
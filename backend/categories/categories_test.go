@@ -0,0 +1,151 @@
+package categories
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefault_Categorize(t *testing.T) {
+	tests := []struct {
+		eventType   string
+		category    string
+		subcategory string
+	}{
+		{"github.push", "development", "commits"},
+		{"gitlab.push", "development", "commits"},
+		{"github.pr", "development", "pull_requests"},
+		{"gitlab.mr", "development", "pull_requests"},
+		{"github.review_comment", "development", "reviews"},
+		{"github.comment", "development", "reviews"},
+		{"github.discussion_comment", "development", "discussions"},
+		{"gitlab.release", "development", "releases"},
+		{"github.tag", "development", "refs"},
+		{"github.community", "development", "community"},
+		{"vercel.deploy", "deployments", "deploys"},
+		{"github.deploy", "deployments", "deploys"},
+		{"gitlab.ci", "deployments", "ci"},
+		{"github.ci_job", "deployments", "ci"},
+		{"artifact.image", "deployments", "artifacts"},
+		{"github.issue", "issues", "tickets"},
+		{"issues.linear_comment", "issues", "tickets"},
+		{"error.build", "issues", "errors"},
+		{"security.audit", "security", "audit"},
+		{"security.dependabot", "security", "alerts"},
+		{"monitoring.check", "infrastructure", "monitoring"},
+		{"incident.resolved", "infrastructure", "incidents"},
+		{"github.workflow", "development", "other"},
+		{"custom.type", "development", "other"},
+		{"", "development", "other"},
+	}
+
+	categorizer := Default()
+	for _, tt := range tests {
+		category, subcategory := categorizer.Categorize(tt.eventType)
+		if category != tt.category || subcategory != tt.subcategory {
+			t.Errorf("Categorize(%q) = %s/%s, want %s/%s", tt.eventType, category, subcategory, tt.category, tt.subcategory)
+		}
+	}
+}
+
+func TestDefaultTaxonomy_Valid(t *testing.T) {
+	taxonomy := DefaultTaxonomy()
+	if err := taxonomy.Validate(); err != nil {
+		t.Fatalf("default taxonomy is invalid: %v", err)
+	}
+}
+
+func TestNew_FirstMatchWins(t *testing.T) {
+	categorizer, err := New(Taxonomy{
+		Categories: []Category{
+			{ID: "ops", Subcategories: []Subcategory{{ID: "paging"}, {ID: "other"}}},
+		},
+		Rules: []Rule{
+			{Match: "acme.page", Category: "ops", Subcategory: "paging"},
+			{Match: "acme.*", Category: "ops", Subcategory: "other"},
+		},
+		Fallback: Assignment{Category: "ops", Subcategory: "other"},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, sub := categorizer.Categorize("acme.page"); sub != "paging" {
+		t.Errorf("acme.page subcategory = %s, want paging", sub)
+	}
+	if _, sub := categorizer.Categorize("acme.pager"); sub != "other" {
+		t.Errorf("acme.pager subcategory = %s, want other (exact rules do not match prefixes)", sub)
+	}
+}
+
+func TestTaxonomy_ValidateErrors(t *testing.T) {
+	base := func() Taxonomy {
+		return Taxonomy{
+			Categories: []Category{{ID: "ops", Subcategories: []Subcategory{{ID: "other"}}}},
+			Fallback:   Assignment{Category: "ops", Subcategory: "other"},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Taxonomy)
+		want   string
+	}{
+		{"no categories", func(tx *Taxonomy) { tx.Categories = nil }, "at least one category"},
+		{"duplicate category", func(tx *Taxonomy) { tx.Categories = append(tx.Categories, Category{ID: "ops"}) }, "duplicate id"},
+		{"unknown rule category", func(tx *Taxonomy) {
+			tx.Rules = []Rule{{Match: "acme.*", Category: "dev", Subcategory: "other"}}
+		}, `unknown category "dev"`},
+		{"undeclared subcategory", func(tx *Taxonomy) {
+			tx.Rules = []Rule{{Match: "acme.*", Category: "ops", Subcategory: "paging"}}
+		}, `no subcategory "paging"`},
+		{"inner wildcard", func(tx *Taxonomy) {
+			tx.Rules = []Rule{{Match: "acme.*.deploy", Category: "ops", Subcategory: "other"}}
+		}, "only allowed at the end"},
+		{"match everything", func(tx *Taxonomy) {
+			tx.Rules = []Rule{{Match: "*", Category: "ops", Subcategory: "other"}}
+		}, "match must be"},
+		{"missing fallback", func(tx *Taxonomy) { tx.Fallback = Assignment{} }, "fallback"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxonomy := base()
+			tt.modify(&taxonomy)
+			err := taxonomy.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadTaxonomy(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "categories.json")
+	content := `{
+		"categories": [{"id": "ops", "name": "Ops", "subcategories": [{"id": "other", "name": "Other"}]}],
+		"rules": [{"match": "acme.*", "category": "ops", "subcategory": "other"}],
+		"fallback": {"category": "ops", "subcategory": "other"}
+	}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	taxonomy, err := LoadTaxonomy(path)
+	if err != nil {
+		t.Fatalf("LoadTaxonomy() error = %v", err)
+	}
+	if len(taxonomy.Rules) != 1 || taxonomy.Rules[0].Match != "acme.*" {
+		t.Errorf("rules = %+v", taxonomy.Rules)
+	}
+
+	unknownField := filepath.Join(dir, "unknown.json")
+	if err := os.WriteFile(unknownField, []byte(`{"categories": [], "colour": "red"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTaxonomy(unknownField); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
@@ -0,0 +1,29 @@
+package categories
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// LoadTaxonomy reads and validates a category rules file. The file replaces the
+// built-in taxonomy entirely.
+func LoadTaxonomy(path string) (*Taxonomy, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from trusted configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read category rules file: %w", err)
+	}
+
+	var taxonomy Taxonomy
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&taxonomy); err != nil {
+		return nil, fmt.Errorf("failed to parse category rules file %s: %w", path, err)
+	}
+
+	if err := taxonomy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid category rules file %s: %w", path, err)
+	}
+	return &taxonomy, nil
+}
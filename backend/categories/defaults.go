@@ -0,0 +1,99 @@
+package categories
+
+// DefaultTaxonomy returns the built-in taxonomy. Stored events are recategorized
+// with the loaded taxonomy at startup (EventRepository.RecategorizeEvents), so
+// changing a rule here also updates existing events.
+func DefaultTaxonomy() Taxonomy {
+	return Taxonomy{
+		Categories: []Category{
+			{
+				ID:          "development",
+				Name:        "Development",
+				Description: "Code commits, pushes, and repository changes",
+				Subcategories: []Subcategory{
+					{ID: "commits", Name: "Commits"},
+					{ID: "pull_requests", Name: "Pull requests"},
+					{ID: "reviews", Name: "Reviews & comments"},
+					{ID: "discussions", Name: "Discussions"},
+					{ID: "releases", Name: "Releases"},
+					{ID: "refs", Name: "Branches & tags"},
+					{ID: "community", Name: "Community"},
+					{ID: "other", Name: "Other"},
+				},
+			},
+			{
+				ID:          "deployments",
+				Name:        "Deployments",
+				Description: "Application deployments, builds and CI runs",
+				Subcategories: []Subcategory{
+					{ID: "deploys", Name: "Deploys"},
+					{ID: "ci", Name: "CI runs"},
+					{ID: "artifacts", Name: "Artifacts"},
+				},
+			},
+			{
+				ID:          "infrastructure",
+				Name:        "Infrastructure",
+				Description: "Server and system monitoring",
+				Subcategories: []Subcategory{
+					{ID: "monitoring", Name: "Monitoring"},
+					{ID: "incidents", Name: "Incidents"},
+				},
+			},
+			{
+				ID:          "issues",
+				Name:        "Issues & Bugs",
+				Description: "Error notifications and system issues",
+				Subcategories: []Subcategory{
+					{ID: "tickets", Name: "Issues"},
+					{ID: "errors", Name: "Errors"},
+				},
+			},
+			{
+				ID:          "security",
+				Name:        "Security",
+				Description: "Security alerts and vulnerability reports",
+				Subcategories: []Subcategory{
+					{ID: "alerts", Name: "Alerts"},
+					{ID: "audit", Name: "Audit"},
+				},
+			},
+		},
+		Rules: []Rule{
+			{Match: "github.push*", Category: "development", Subcategory: "commits"},
+			{Match: "gitlab.push", Category: "development", Subcategory: "commits"},
+			{Match: "github.pr*", Category: "development", Subcategory: "pull_requests"},
+			{Match: "gitlab.mr", Category: "development", Subcategory: "pull_requests"},
+			{Match: "github.review*", Category: "development", Subcategory: "reviews"},
+			{Match: "github.comment", Category: "development", Subcategory: "reviews"},
+			{Match: "github.discussion*", Category: "development", Subcategory: "discussions"},
+			{Match: "github.release*", Category: "development", Subcategory: "releases"},
+			{Match: "gitlab.release", Category: "development", Subcategory: "releases"},
+			{Match: "github.branch", Category: "development", Subcategory: "refs"},
+			{Match: "github.tag", Category: "development", Subcategory: "refs"},
+			{Match: "gitlab.tag", Category: "development", Subcategory: "refs"},
+			{Match: "github.community", Category: "development", Subcategory: "community"},
+
+			{Match: "vercel.*", Category: "deployments", Subcategory: "deploys"},
+			{Match: "railway.*", Category: "deployments", Subcategory: "deploys"},
+			{Match: "netlify.*", Category: "deployments", Subcategory: "deploys"},
+			{Match: "render.*", Category: "deployments", Subcategory: "deploys"},
+			{Match: "github.deploy", Category: "deployments", Subcategory: "deploys"},
+			{Match: "github.ci", Category: "deployments", Subcategory: "ci"},
+			{Match: "github.ci_job", Category: "deployments", Subcategory: "ci"},
+			{Match: "gitlab.ci", Category: "deployments", Subcategory: "ci"},
+			{Match: "artifact.*", Category: "deployments", Subcategory: "artifacts"},
+
+			{Match: "github.issue*", Category: "issues", Subcategory: "tickets"},
+			{Match: "issues.*", Category: "issues", Subcategory: "tickets"},
+			{Match: "error.*", Category: "issues", Subcategory: "errors"},
+
+			{Match: "security.audit", Category: "security", Subcategory: "audit"},
+			{Match: "security.*", Category: "security", Subcategory: "alerts"},
+
+			{Match: "monitoring.*", Category: "infrastructure", Subcategory: "monitoring"},
+			{Match: "incident.*", Category: "infrastructure", Subcategory: "incidents"},
+		},
+		Fallback: Assignment{Category: "development", Subcategory: "other"},
+	}
+}
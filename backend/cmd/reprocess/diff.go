@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// change describes one differing field between the stored and the reprocessed event
+type change struct {
+	field string
+	old   interface{}
+	new   interface{}
+	kind  byte // '+' added, '-' removed, '~' modified
+}
+
+// matchTransformed finds the re-transformed counterpart of a stored event among the
+// events its payload now produces. Transformer-assigned identities match exactly;
+// identities derived from the delivery ID carry the event's position as a ":N" suffix.
+func matchTransformed(stored models.DashboardEvent, transformed []models.DashboardEvent) (models.DashboardEvent, bool) {
+	for _, event := range transformed {
+		if event.ExternalID != "" && event.ExternalID == stored.ExternalID {
+			return event, true
+		}
+	}
+
+	if len(transformed) == 1 && transformed[0].ExternalID == "" {
+		return transformed[0], true
+	}
+
+	if i := strings.LastIndex(stored.ExternalID, ":"); i >= 0 {
+		index, err := strconv.Atoi(stored.ExternalID[i+1:])
+		if err == nil && index >= 0 && index < len(transformed) && transformed[index].ExternalID == "" {
+			return transformed[index], true
+		}
+	}
+
+	return models.DashboardEvent{}, false
+}
+
+// diffEvent compares event type, category, subcategory, title and metadata. Metadata
+// is compared through a JSON round trip so that e.g. an int from a transformer equals
+// the float64 read back from JSONB.
+func diffEvent(stored, transformed models.DashboardEvent) []change {
+	var changes []change
+
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"event_type", stored.EventType, transformed.EventType},
+		{"category", stored.Category, transformed.Category},
+		{"subcategory", stored.Subcategory, transformed.Subcategory},
+		{"title", stored.Title, transformed.Title},
+	}
+	for _, f := range fields {
+		if f.old != f.new {
+			changes = append(changes, change{field: f.name, old: f.old, new: f.new, kind: '~'})
+		}
+	}
+
+	oldMeta := normalize(stored.Metadata)
+	newMeta := normalize(transformed.Metadata)
+
+	keys := make([]string, 0, len(oldMeta)+len(newMeta))
+	seen := make(map[string]bool)
+	for _, m := range []map[string]interface{}{oldMeta, newMeta} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		oldVal, hadOld := oldMeta[k]
+		newVal, hasNew := newMeta[k]
+		field := "metadata." + k
+
+		switch {
+		case !hadOld:
+			changes = append(changes, change{field: field, new: newVal, kind: '+'})
+		case !hasNew:
+			changes = append(changes, change{field: field, old: oldVal, kind: '-'})
+		case encode(oldVal) != encode(newVal):
+			changes = append(changes, change{field: field, old: oldVal, new: newVal, kind: '~'})
+		}
+	}
+
+	return changes
+}
+
+// normalize round-trips metadata through JSON to match what the database returns
+func normalize(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	data, err := json.Marshal(m)
+	if err != nil {
+		return out
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return make(map[string]interface{})
+	}
+	return out
+}
+
+// encode renders a value as compact JSON for comparison and display
+func encode(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// printDiff writes a human-readable diff for one event
+func printDiff(archived models.ArchivedEvent, changes []change) {
+	fmt.Printf("~ %s %s (%s)\n", archived.Event.ID, archived.Event.EventType, archived.Event.CreatedAt.Format(time.RFC3339))
+	for _, c := range changes {
+		switch c.kind {
+		case '+':
+			fmt.Printf("    + %s: %s\n", c.field, encode(c.new))
+		case '-':
+			fmt.Printf("    - %s: %s\n", c.field, encode(c.old))
+		default:
+			fmt.Printf("    ~ %s: %s -> %s\n", c.field, encode(c.old), encode(c.new))
+		}
+	}
+}
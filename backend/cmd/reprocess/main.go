@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"heimdall-backend/categories"
+	"heimdall-backend/database"
+	"heimdall-backend/models"
+	"heimdall-backend/transformers"
+
+	_ "github.com/lib/pq"
+)
+
+// stats counts what happened to each archived event
+type stats struct {
+	scanned   int
+	unchanged int
+	changed   int
+	unmatched int
+	failed    int
+}
+
+func main() {
+	// Parse command line flags
+	var (
+		databaseURL string
+		eventType   string
+		sinceStr    string
+		untilStr    string
+		sourcesFile string
+		rulesFile   string
+		dryRun      bool
+	)
+
+	flag.StringVar(&databaseURL, "database", "", "Database URL (or set DATABASE_URL env var)")
+	flag.StringVar(&eventType, "type", "", "Only reprocess payloads of this registry event type (e.g. github.push)")
+	flag.StringVar(&sinceStr, "since", "", "Only reprocess events created on or after this date (YYYY-MM-DD or RFC3339)")
+	flag.StringVar(&untilStr, "until", "", "Only reprocess events created before this date (YYYY-MM-DD or RFC3339)")
+	flag.StringVar(&sourcesFile, "sources", os.Getenv("CUSTOM_SOURCES_FILE"), "Custom sources mapping config (defaults to CUSTOM_SOURCES_FILE)")
+	flag.StringVar(&rulesFile, "categories", os.Getenv("CATEGORY_RULES_FILE"), "Category rules file (defaults to CATEGORY_RULES_FILE, otherwise the built-in rules)")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print a diff of the changes without updating any events")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	// Get database URL from flag or environment
+	if databaseURL == "" {
+		databaseURL = os.Getenv("DATABASE_URL")
+	}
+	if databaseURL == "" {
+		log.Fatal("Database URL is required. Set DATABASE_URL environment variable or use -database flag")
+	}
+
+	filter := models.ArchiveFilter{EventType: eventType}
+	var err error
+	if filter.Since, err = parseDate(sinceStr); err != nil {
+		log.Fatalf("Invalid -since: %v", err)
+	}
+	if filter.Until, err = parseDate(untilStr); err != nil {
+		log.Fatalf("Invalid -until: %v", err)
+	}
+
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Stop cleanly on Ctrl+C; events updated so far stay updated
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	repo := database.NewEventRepository(db)
+	registry := transformers.NewRegistry()
+
+	// Reprocessed events are categorized with the same rules as the service
+	categorizer := categories.Default()
+	if rulesFile != "" {
+		taxonomy, err := categories.LoadTaxonomy(rulesFile)
+		if err != nil {
+			log.Fatalf("Failed to load category rules: %v", err)
+		}
+		if categorizer, err = categories.New(*taxonomy); err != nil {
+			log.Fatalf("Failed to configure category rules: %v", err)
+		}
+	}
+	repo.SetCategorizer(categorizer)
+
+	// Custom sources are registered the same way the service does at startup
+	if sourcesFile != "" {
+		mappingConfig, err := transformers.LoadMappingConfig(sourcesFile)
+		if err != nil {
+			log.Fatalf("Failed to load custom sources: %v", err)
+		}
+		if err := registry.RegisterMappings(mappingConfig); err != nil {
+			log.Fatalf("Failed to register custom sources: %v", err)
+		}
+	}
+
+	// Events of one delivery arrive consecutively, so each payload is transformed once
+	var (
+		s                stats
+		lastPayloadID    string
+		lastTransformed  []models.DashboardEvent
+		lastTransformErr error
+	)
+	err = repo.StreamArchivedEvents(ctx, filter, func(archived models.ArchivedEvent) error {
+		s.scanned++
+
+		if archived.PayloadID != lastPayloadID {
+			lastPayloadID = archived.PayloadID
+			lastTransformed, lastTransformErr = registry.Transform(archived.Payload.EventType, archived.Payload.Data, archived.Payload.ReceivedAt)
+		}
+		if lastTransformErr != nil {
+			s.failed++
+			log.Printf("Event %s: transform failed: %v", archived.Event.ID, lastTransformErr)
+			return nil
+		}
+
+		transformed, ok := matchTransformed(archived.Event, lastTransformed)
+		if !ok {
+			// e.g. a push stored as one row before transformers emitted one event per commit
+			s.unmatched++
+			log.Printf("Event %s: payload now produces %d events, none matching this row", archived.Event.ID, len(lastTransformed))
+			return nil
+		}
+		transformed.Category, transformed.Subcategory = categorizer.Categorize(transformed.EventType)
+
+		changes := diffEvent(archived.Event, transformed)
+		if len(changes) == 0 {
+			s.unchanged++
+			return nil
+		}
+		s.changed++
+
+		if dryRun {
+			printDiff(archived, changes)
+			return nil
+		}
+
+		updateCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := repo.UpdateEventContent(updateCtx, archived.Event.ID, transformed); err != nil {
+			return fmt.Errorf("event %s: %w", archived.Event.ID, err)
+		}
+		return nil
+	})
+
+	mode := "updated"
+	if dryRun {
+		mode = "would update"
+	}
+	log.Printf("Scanned %d events: %s %d, unchanged %d, unmatched %d, failed %d",
+		s.scanned, mode, s.changed, s.unchanged, s.unmatched, s.failed)
+
+	if err != nil {
+		log.Printf("Reprocessing stopped: %v", err)
+		os.Exit(1)
+	}
+}
+
+// parseDate accepts YYYY-MM-DD or RFC3339; an empty string yields the zero time
+func parseDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func printUsage() {
+	fmt.Println("Usage: reprocess [flags]")
+	fmt.Println()
+	fmt.Println("Re-runs archived webhook payloads through the current transformers and")
+	fmt.Println("updates the event type, title, metadata and category of the events")
+	fmt.Println("they produced.")
+	fmt.Println()
+	fmt.Println("Flags:")
+	flag.PrintDefaults()
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config holds application configuration
@@ -14,6 +15,40 @@ type Config struct {
 	RateLimitRPS   float64 // Requests per second for rate limiting
 	RateLimitBurst int     // Burst size for rate limiting
 	PrettyLogs     bool    // Use pretty console logs (for development)
+
+	// Provider webhook secrets. A provider's route is only mounted when its secret is
+	// set, unless AllowUnsignedWebhooks is true.
+	GitHubWebhookSecret    string // HMAC secret for X-Hub-Signature-256
+	VercelWebhookSecret    string // HMAC secret for x-vercel-signature
+	RailwayWebhookToken    string // Shared token expected in the ?token= query parameter
+	SentryClientSecret     string // Integration client secret for Sentry-Hook-Signature
+	GitLabWebhookToken     string // Secret token expected in X-Gitlab-Token
+	NetlifyWebhookSecret   string // JWS secret for X-Webhook-Signature
+	RenderWebhookSecret    string // Signing secret ("whsec_...") for webhook-signature
+	LinearWebhookSecret    string // HMAC secret for Linear-Signature
+	JiraWebhookSecret      string // HMAC secret for X-Hub-Signature
+	PagerDutyWebhookSecret string // Signing secret for X-PagerDuty-Signature
+	DockerHubWebhookToken  string // Shared token expected in the ?token= query parameter
+	AllowUnsignedWebhooks  bool   // Mount providers without a secret and accept their deliveries unverified (local development)
+
+	// QStash signing keys for Upstash-Signature verification (skipped when both are empty)
+	QStashCurrentSigningKey string
+	QStashNextSigningKey    string
+
+	AdminToken string // Bearer token for /api/admin routes (routes are disabled when empty)
+
+	CustomSourcesFile string // JSON mapping config for /api/webhook/custom/{name} sources
+	MonitorsFile      string // JSON list of URLs probed by the uptime monitor
+	CategoryRulesFile string // JSON category taxonomy replacing the built-in rules
+
+	// Asynchronous ingestion (webhooks are stored synchronously when IngestAsync is false).
+	// Zero values fall back to the ingest package defaults.
+	IngestAsync         bool
+	IngestQueueSize     int
+	IngestWorkers       int
+	IngestBatchSize     int
+	IngestFlushInterval time.Duration
+	IngestSpoolDir      string // Accepted events are spooled here until stored; required when IngestAsync is true
 }
 
 // Load reads configuration from environment variables
@@ -35,18 +70,7 @@ func Load() (*Config, error) {
 		cfg.Port = "8080"
 	}
 
-	// Parse rate limit settings from environment
-	if rps := os.Getenv("RATE_LIMIT_RPS"); rps != "" {
-		if val, err := strconv.ParseFloat(rps, 64); err == nil && val > 0 {
-			cfg.RateLimitRPS = val
-		}
-	}
-
-	if burst := os.Getenv("RATE_LIMIT_BURST"); burst != "" {
-		if val, err := strconv.Atoi(burst); err == nil && val > 0 {
-			cfg.RateLimitBurst = val
-		}
-	}
+	loadOptional(cfg)
 
 	return cfg, nil
 }
@@ -70,6 +94,13 @@ func LoadWithDefaults() *Config {
 		cfg.Port = "8080"
 	}
 
+	loadOptional(cfg)
+
+	return cfg
+}
+
+// loadOptional reads settings that share the same parsing rules in every environment
+func loadOptional(cfg *Config) {
 	// Parse rate limit settings from environment
 	if rps := os.Getenv("RATE_LIMIT_RPS"); rps != "" {
 		if val, err := strconv.ParseFloat(rps, 64); err == nil && val > 0 {
@@ -83,5 +114,47 @@ func LoadWithDefaults() *Config {
 		}
 	}
 
-	return cfg
+	// GitHub secret falls back to the frontend's WEBHOOK_SECRET so both paths share one value
+	cfg.GitHubWebhookSecret = os.Getenv("GITHUB_WEBHOOK_SECRET")
+	if cfg.GitHubWebhookSecret == "" {
+		cfg.GitHubWebhookSecret = os.Getenv("WEBHOOK_SECRET")
+	}
+	cfg.VercelWebhookSecret = os.Getenv("VERCEL_WEBHOOK_SECRET")
+	cfg.RailwayWebhookToken = os.Getenv("RAILWAY_WEBHOOK_TOKEN")
+	cfg.SentryClientSecret = os.Getenv("SENTRY_CLIENT_SECRET")
+	cfg.GitLabWebhookToken = os.Getenv("GITLAB_WEBHOOK_TOKEN")
+	cfg.NetlifyWebhookSecret = os.Getenv("NETLIFY_WEBHOOK_SECRET")
+	cfg.RenderWebhookSecret = os.Getenv("RENDER_WEBHOOK_SECRET")
+	cfg.LinearWebhookSecret = os.Getenv("LINEAR_WEBHOOK_SECRET")
+	cfg.JiraWebhookSecret = os.Getenv("JIRA_WEBHOOK_SECRET")
+	cfg.PagerDutyWebhookSecret = os.Getenv("PAGERDUTY_WEBHOOK_SECRET")
+	cfg.DockerHubWebhookToken = os.Getenv("DOCKERHUB_WEBHOOK_TOKEN")
+	cfg.AllowUnsignedWebhooks = os.Getenv("ALLOW_UNSIGNED_WEBHOOKS") == "true"
+
+	cfg.QStashCurrentSigningKey = os.Getenv("QSTASH_CURRENT_SIGNING_KEY")
+	cfg.QStashNextSigningKey = os.Getenv("QSTASH_NEXT_SIGNING_KEY")
+
+	cfg.AdminToken = os.Getenv("ADMIN_TOKEN")
+	cfg.CustomSourcesFile = os.Getenv("CUSTOM_SOURCES_FILE")
+	cfg.MonitorsFile = os.Getenv("MONITORS_FILE")
+	cfg.CategoryRulesFile = os.Getenv("CATEGORY_RULES_FILE")
+
+	cfg.IngestAsync = os.Getenv("INGEST_ASYNC") == "true"
+	cfg.IngestQueueSize = positiveInt("INGEST_QUEUE_SIZE")
+	cfg.IngestWorkers = positiveInt("INGEST_WORKERS")
+	cfg.IngestBatchSize = positiveInt("INGEST_BATCH_SIZE")
+	if interval := os.Getenv("INGEST_FLUSH_INTERVAL"); interval != "" {
+		if val, err := time.ParseDuration(interval); err == nil && val > 0 {
+			cfg.IngestFlushInterval = val
+		}
+	}
+	cfg.IngestSpoolDir = os.Getenv("INGEST_SPOOL_DIR")
+}
+
+// positiveInt parses an integer environment variable, returning 0 when unset or invalid
+func positiveInt(name string) int {
+	if val, err := strconv.Atoi(os.Getenv(name)); err == nil && val > 0 {
+		return val
+	}
+	return 0
 }
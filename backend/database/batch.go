@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+
+	"github.com/lib/pq"
+)
+
+// InsertEventBatch stores events in a single transaction using COPY into temporary
+// tables followed by one INSERT ... SELECT per table. Every event must already have
+// an ID. Rows that conflict with a stored event (same ID, or same Source and
+// ExternalID) are skipped, which makes retrying a partially committed batch safe.
+// It returns the number of events actually inserted.
+func (r *EventRepository) InsertEventBatch(events []models.DashboardEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	r.categorize(events)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return WithRetry(ctx, DefaultRetryConfig, func() (int, error) {
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return 0, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		//nolint:errcheck // Rollback after Commit is a no-op
+		defer tx.Rollback()
+
+		inserted, err := insertEventBatchTx(ctx, tx, events)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return 0, fmt.Errorf("failed to commit event batch: %w", err)
+		}
+		return inserted, nil
+	})
+}
+
+func insertEventBatchTx(ctx context.Context, tx *sql.Tx, events []models.DashboardEvent) (int, error) {
+	stagingQueries := []string{
+		`CREATE TEMP TABLE ingest_payloads (
+			id VARCHAR(255), event_type VARCHAR(100), payload JSONB, received_at TIMESTAMP WITH TIME ZONE
+		) ON COMMIT DROP`,
+		`CREATE TEMP TABLE ingest_events (
+			id VARCHAR(255), event_type VARCHAR(100), title VARCHAR(500), metadata JSONB,
+			created_at TIMESTAMP WITH TIME ZONE, category VARCHAR(50), subcategory VARCHAR(50),
+			source VARCHAR(50), external_id VARCHAR(255), payload_id VARCHAR(255)
+		) ON COMMIT DROP`,
+	}
+	for _, q := range stagingQueries {
+		if _, err := tx.ExecContext(ctx, q); err != nil {
+			return 0, fmt.Errorf("failed to create staging table: %w", err)
+		}
+	}
+
+	// A payload is keyed by the ID of the first event that references it, so events
+	// produced by the same delivery share one archived payload
+	payloadIDs := make(map[*models.RawPayload]string)
+	payloadRows := make([][]interface{}, 0, len(events))
+	eventRows := make([][]interface{}, 0, len(events))
+	for i := range events {
+		event := &events[i]
+		if event.ID == "" {
+			return 0, fmt.Errorf("event %d in batch has no ID", i)
+		}
+
+		metadataJSON, err := json.Marshal(event.Metadata)
+		if err != nil {
+			return 0, fmt.Errorf("failed to marshal metadata for event %s: %w", event.ID, err)
+		}
+
+		var payloadID interface{}
+		if event.Payload != nil {
+			id, archived := payloadIDs[event.Payload]
+			if !archived {
+				id = event.ID
+				payloadIDs[event.Payload] = id
+				payloadRows = append(payloadRows, []interface{}{
+					id, event.Payload.EventType, string(event.Payload.Data), event.Payload.ReceivedAt,
+				})
+			}
+			payloadID = id
+		}
+
+		eventRows = append(eventRows, []interface{}{
+			event.ID, event.EventType, event.Title, string(metadataJSON), event.CreatedAt,
+			event.Category, event.Subcategory, nullIfEmpty(event.Source), nullIfEmpty(event.ExternalID), payloadID,
+		})
+	}
+
+	if err := copyRows(ctx, tx, "ingest_payloads", []string{"id", "event_type", "payload", "received_at"}, payloadRows); err != nil {
+		return 0, err
+	}
+	if err := copyRows(ctx, tx, "ingest_events", []string{
+		"id", "event_type", "title", "metadata", "created_at", "category", "subcategory",
+		"source", "external_id", "payload_id",
+	}, eventRows); err != nil {
+		return 0, err
+	}
+
+	// Payloads first so the events' foreign keys resolve
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO event_payloads (id, event_type, payload, received_at)
+		SELECT id, event_type, payload, received_at FROM ingest_payloads
+		ON CONFLICT DO NOTHING
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert payload batch: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO events (id, event_type, title, metadata, created_at, category, subcategory, source, external_id, payload_id)
+		SELECT id, event_type, title, metadata, created_at, category, subcategory, source, external_id, payload_id
+		FROM ingest_events
+		ON CONFLICT DO NOTHING
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert event batch: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read inserted count: %w", err)
+	}
+
+	// Duplicates were skipped; drop the payloads that were archived for them
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM event_payloads p
+		USING ingest_payloads s
+		WHERE p.id = s.id
+			AND NOT EXISTS (SELECT 1 FROM events e WHERE e.payload_id = p.id)
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove duplicate payloads: %w", err)
+	}
+
+	return int(inserted), nil
+}
+
+// copyRows streams rows into table with COPY FROM STDIN
+func copyRows(ctx context.Context, tx *sql.Tx, table string, columns []string, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return fmt.Errorf("failed to start copy into %s: %w", table, err)
+	}
+	defer stmt.Close()
+
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf("failed to copy row into %s: %w", table, err)
+		}
+	}
+
+	// An Exec without arguments flushes the buffered COPY data
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return fmt.Errorf("failed to finish copy into %s: %w", table, err)
+	}
+	return nil
+}
+
+// nullIfEmpty maps "" to NULL so unset identities never collide in the unique index
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
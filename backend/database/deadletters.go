@@ -0,0 +1,264 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNotFound is returned when a requested row does not exist
+var ErrNotFound = errors.New("not found")
+
+// DeadLetterRepository handles database operations for failed webhook deliveries
+type DeadLetterRepository struct {
+	db *sql.DB
+}
+
+// NewDeadLetterRepository creates a new dead-letter repository
+func NewDeadLetterRepository(db *sql.DB) *DeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+// InsertDeadLetter stores a failed delivery and sets its ID and attempt count.
+// A redelivery with the same source and delivery ID updates the existing entry.
+func (r *DeadLetterRepository) InsertDeadLetter(dl *models.DeadLetter) error {
+	headersJSON, err := json.Marshal(dl.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal headers: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO dead_letters (source, delivery_id, event_type, payload, headers, error, received_at)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7)
+		ON CONFLICT (source, delivery_id) DO UPDATE SET
+			payload = EXCLUDED.payload,
+			headers = EXCLUDED.headers,
+			error = EXCLUDED.error,
+			attempts = dead_letters.attempts + 1,
+			last_attempt_at = NOW()
+		RETURNING id, attempts
+	`
+
+	return WithRetryNoResult(ctx, DefaultRetryConfig, func() error {
+		err := r.db.QueryRowContext(ctx, query,
+			dl.Source, dl.DeliveryID, dl.EventType, dl.Payload, headersJSON, dl.Error, dl.ReceivedAt,
+		).Scan(&dl.ID, &dl.Attempts)
+		if err != nil {
+			return fmt.Errorf("failed to insert dead letter: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListDeadLetters retrieves dead letters (without payloads) with pagination and filtering
+func (r *DeadLetterRepository) ListDeadLetters(filter models.DeadLetterFilter) ([]models.DeadLetter, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.Source != "" {
+		conditions = append(conditions, fmt.Sprintf("source = $%d", argIndex))
+		args = append(args, filter.Source)
+		argIndex++
+	}
+
+	if filter.EventType != "" {
+		conditions = append(conditions, fmt.Sprintf("event_type = $%d", argIndex))
+		args = append(args, filter.EventType)
+		argIndex++
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Note: whereClause is safely constructed from validated conditions with parameterized args
+	countQuery := "SELECT COUNT(*) FROM dead_letters " + whereClause // #nosec G201
+	countArgs := make([]interface{}, len(args))
+	copy(countArgs, args)
+
+	total, err := WithRetry(ctx, DefaultRetryConfig, func() (int, error) {
+		var count int
+		if err := r.db.QueryRowContext(ctx, countQuery, countArgs...).Scan(&count); err != nil {
+			return 0, err
+		}
+		return count, nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	// Note: whereClause is safely constructed from validated conditions with parameterized args
+	query := fmt.Sprintf(`
+		SELECT id, source, COALESCE(delivery_id, ''), event_type, headers, error,
+			attempts, received_at, created_at, last_attempt_at
+		FROM dead_letters
+		%s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d
+	`, whereClause, argIndex, argIndex+1) // #nosec G201
+
+	args = append(args, filter.Limit, filter.Offset)
+
+	deadLetters, err := WithRetry(ctx, DefaultRetryConfig, func() ([]models.DeadLetter, error) {
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		results := make([]models.DeadLetter, 0, filter.Limit)
+		for rows.Next() {
+			dl, err := scanDeadLetter(rows, false)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, dl)
+		}
+
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query dead letters: %w", err)
+	}
+
+	return deadLetters, total, nil
+}
+
+// GetDeadLetter retrieves a single dead letter including its payload
+func (r *DeadLetterRepository) GetDeadLetter(id string) (models.DeadLetter, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT id, source, COALESCE(delivery_id, ''), event_type, headers, error,
+			attempts, received_at, created_at, last_attempt_at, payload
+		FROM dead_letters
+		WHERE id = $1
+	`
+
+	return WithRetry(ctx, DefaultRetryConfig, func() (models.DeadLetter, error) {
+		dl, err := scanDeadLetter(r.db.QueryRowContext(ctx, query, id), true)
+		if errors.Is(err, sql.ErrNoRows) {
+			return dl, ErrNotFound
+		}
+		return dl, err
+	})
+}
+
+// RecordReplayFailure bumps the attempt count of a dead letter after a failed replay
+func (r *DeadLetterRepository) RecordReplayFailure(id string, replayErr string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE dead_letters
+		SET error = $2, attempts = attempts + 1, last_attempt_at = NOW()
+		WHERE id = $1
+	`
+
+	return WithRetryNoResult(ctx, DefaultRetryConfig, func() error {
+		if _, err := r.db.ExecContext(ctx, query, id, replayErr); err != nil {
+			return fmt.Errorf("failed to record replay failure: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteDeadLetter removes a single dead letter
+func (r *DeadLetterRepository) DeleteDeadLetter(id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return WithRetryNoResult(ctx, DefaultRetryConfig, func() error {
+		result, err := r.db.ExecContext(ctx, "DELETE FROM dead_letters WHERE id = $1", id)
+		if err != nil {
+			return fmt.Errorf("failed to delete dead letter: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil && n == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// PurgeDeadLetters deletes dead letters created before the given time (all of them when zero)
+func (r *DeadLetterRepository) PurgeDeadLetters(before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if before.IsZero() {
+		before = time.Now().UTC()
+	}
+
+	return WithRetry(ctx, DefaultRetryConfig, func() (int64, error) {
+		result, err := r.db.ExecContext(ctx, "DELETE FROM dead_letters WHERE created_at < $1", before)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge dead letters: %w", err)
+		}
+		return result.RowsAffected()
+	})
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanDeadLetter reads a dead letter row, optionally followed by its payload column
+func scanDeadLetter(row rowScanner, withPayload bool) (models.DeadLetter, error) {
+	var dl models.DeadLetter
+	var headersBytes []byte
+
+	dest := []interface{}{
+		&dl.ID, &dl.Source, &dl.DeliveryID, &dl.EventType, &headersBytes, &dl.Error,
+		&dl.Attempts, &dl.ReceivedAt, &dl.CreatedAt, &dl.LastAttemptAt,
+	}
+	if withPayload {
+		dest = append(dest, &dl.Payload)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return dl, err
+		}
+		return dl, fmt.Errorf("failed to scan dead letter row: %w", err)
+	}
+
+	if headersBytes != nil {
+		if err := json.Unmarshal(headersBytes, &dl.Headers); err != nil {
+			log.Warn().
+				Str("dead_letter_id", dl.ID).
+				Err(err).
+				Msg("failed to unmarshal dead letter headers")
+		}
+	}
+
+	return dl, nil
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// GetDeployments returns the deploys that finished at or after since, oldest first.
+// A deploy's outcome is its first SUCCESS, FAILED or CRASHED state; later states of
+// the same deployment ID (a crash after going live, a removal) are not counted.
+// CommittedAt is the earliest push of the deployed commit or merge that created it.
+func (r *EventRepository) GetDeployments(since time.Time) ([]models.Deployment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		WITH outcomes AS (
+			SELECT DISTINCT ON (event_type, COALESCE(metadata->>'deployment_id', id))
+				event_type,
+				COALESCE(NULLIF(metadata->>'project', ''), NULLIF(metadata->>'project_name', ''), 'unknown') AS project,
+				COALESCE(NULLIF(metadata->>'environment', ''), 'production') AS environment,
+				COALESCE(metadata->>'deployment_id', id) AS deployment_id,
+				CASE WHEN metadata->>'status' = 'SUCCESS' THEN 'SUCCESS' ELSE 'FAILED' END AS status,
+				COALESCE(metadata->>'commit_sha', '') AS commit_sha,
+				created_at
+			FROM events
+			WHERE event_type IN ('vercel.deploy', 'railway.deploy', 'netlify.deploy', 'render.deploy', 'github.deploy')
+				AND metadata->>'status' IN ('SUCCESS', 'FAILED', 'CRASHED')
+			ORDER BY event_type, COALESCE(metadata->>'deployment_id', id), created_at ASC
+		)
+		SELECT
+			o.event_type, o.project, o.environment, o.deployment_id, o.status, o.commit_sha, o.created_at,
+			(
+				SELECT MIN(c.created_at)
+				FROM events c
+				WHERE o.commit_sha <> ''
+					AND ((c.metadata->>'commit_sha' = o.commit_sha AND c.event_type IN ('github.push', 'gitlab.push'))
+						OR (c.metadata->>'merge_commit_sha' = o.commit_sha AND c.event_type IN ('github.pr', 'gitlab.mr')))
+			) AS committed_at
+		FROM outcomes o
+		WHERE o.created_at >= $1
+		ORDER BY o.created_at ASC
+	`
+
+	deployments, err := WithRetry(ctx, DefaultRetryConfig, func() ([]models.Deployment, error) {
+		rows, err := r.db.QueryContext(ctx, query, since)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var results []models.Deployment
+		for rows.Next() {
+			var deployment models.Deployment
+			var committedAt sql.NullTime
+			if err := rows.Scan(
+				&deployment.EventType, &deployment.Project, &deployment.Environment, &deployment.DeploymentID,
+				&deployment.Status, &deployment.CommitSHA, &deployment.FinishedAt, &committedAt,
+			); err != nil {
+				return nil, fmt.Errorf("failed to scan deployment: %w", err)
+			}
+			if committedAt.Valid {
+				deployment.CommittedAt = &committedAt.Time
+			}
+			results = append(results, deployment)
+		}
+		return results, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deployments: %w", err)
+	}
+	return deployments, nil
+}
+
+// GetDeploymentRuns pairs the lifecycle states of each deploy that finished at or
+// after since, oldest first. A run starts with the first state reported for its
+// deployment ID (usually BUILDING) and ends with the first SUCCESS, FAILED,
+// CRASHED or CANCELED state. CRASHED is reported as FAILED. The duration is the
+// provider's own when it sends a numeric one, otherwise start to finish; a deploy
+// seen only in its final state has none.
+func (r *EventRepository) GetDeploymentRuns(since time.Time) ([]models.DeploymentRun, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		WITH states AS (
+			SELECT
+				event_type,
+				COALESCE(metadata->>'deployment_id', id) AS deployment_id,
+				metadata,
+				created_at,
+				MIN(created_at) OVER (PARTITION BY event_type, COALESCE(metadata->>'deployment_id', id)) AS started_at
+			FROM events
+			WHERE event_type IN ('vercel.deploy', 'railway.deploy', 'netlify.deploy', 'render.deploy', 'github.deploy')
+		),
+		runs AS (
+			SELECT DISTINCT ON (event_type, deployment_id)
+				event_type,
+				COALESCE(NULLIF(metadata->>'project', ''), NULLIF(metadata->>'project_name', ''), 'unknown') AS project,
+				COALESCE(NULLIF(metadata->>'environment', ''), 'production') AS environment,
+				deployment_id,
+				CASE metadata->>'status' WHEN 'CRASHED' THEN 'FAILED' ELSE metadata->>'status' END AS status,
+				COALESCE(metadata->>'commit_sha', '') AS commit_sha,
+				COALESCE(NULLIF(metadata->>'deployment_url', ''), metadata->>'url', '') AS url,
+				started_at,
+				created_at AS finished_at,
+				CASE
+					WHEN jsonb_typeof(metadata->'duration_seconds') = 'number'
+						THEN (metadata->>'duration_seconds')::numeric::bigint
+					WHEN started_at < created_at THEN EXTRACT(EPOCH FROM created_at - started_at)::bigint
+				END AS duration_seconds
+			FROM states
+			WHERE metadata->>'status' IN ('SUCCESS', 'FAILED', 'CRASHED', 'CANCELED')
+			ORDER BY event_type, deployment_id, created_at ASC
+		)
+		SELECT event_type, project, environment, deployment_id, status, commit_sha, url,
+			started_at, finished_at, duration_seconds
+		FROM runs
+		WHERE finished_at >= $1
+		ORDER BY finished_at ASC
+	`
+
+	runs, err := WithRetry(ctx, DefaultRetryConfig, func() ([]models.DeploymentRun, error) {
+		rows, err := r.db.QueryContext(ctx, query, since)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var results []models.DeploymentRun
+		for rows.Next() {
+			var run models.DeploymentRun
+			var duration sql.NullInt64
+			if err := rows.Scan(
+				&run.EventType, &run.Project, &run.Environment, &run.DeploymentID, &run.Status,
+				&run.CommitSHA, &run.URL, &run.StartedAt, &run.FinishedAt, &duration,
+			); err != nil {
+				return nil, fmt.Errorf("failed to scan deployment run: %w", err)
+			}
+			if duration.Valid {
+				seconds := max(duration.Int64, 0)
+				run.DurationSeconds = &seconds
+			}
+			results = append(results, run)
+		}
+		return results, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query deployment runs: %w", err)
+	}
+	return runs, nil
+}
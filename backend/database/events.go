@@ -4,23 +4,47 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"heimdall-backend/categories"
 	"heimdall-backend/models"
 
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
 
+// statsEventFilter leaves stars and forks (github.community) out of the activity
+// stats, since they are other people's activity, and CI jobs (github.ci_job),
+// since their workflow run is counted already
+const statsEventFilter = "event_type NOT IN ('github.community', 'github.ci_job')"
+
 // EventRepository handles database operations for events
 type EventRepository struct {
-	db *sql.DB
+	db          *sql.DB
+	categorizer *categories.Categorizer
 }
 
-// NewEventRepository creates a new event repository
+// NewEventRepository creates a new event repository that categorizes events with
+// the built-in taxonomy
 func NewEventRepository(db *sql.DB) *EventRepository {
-	return &EventRepository{db: db}
+	return &EventRepository{db: db, categorizer: categories.Default()}
+}
+
+// SetCategorizer replaces the categorizer applied to inserted events
+func (r *EventRepository) SetCategorizer(categorizer *categories.Categorizer) {
+	r.categorizer = categorizer
+}
+
+// categorize assigns a category to every event that does not have one yet
+func (r *EventRepository) categorize(events []models.DashboardEvent) {
+	for i := range events {
+		if events[i].Category == "" {
+			events[i].Category, events[i].Subcategory = r.categorizer.Categorize(events[i].EventType)
+		}
+	}
 }
 
 // GetRecentEvents retrieves the most recent events from the database
@@ -50,6 +74,12 @@ func (r *EventRepository) GetEventsWithFilters(filter models.EventsFilter) ([]mo
 		argIndex++
 	}
 
+	if filter.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("category = $%d", argIndex))
+		args = append(args, filter.Category)
+		argIndex++
+	}
+
 	if !filter.Since.IsZero() {
 		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", argIndex))
 		args = append(args, filter.Since)
@@ -85,7 +115,8 @@ func (r *EventRepository) GetEventsWithFilters(filter models.EventsFilter) ([]mo
 	// Get events with pagination and retry
 	// Note: whereClause is safely constructed from validated conditions with parameterized args
 	query := fmt.Sprintf(`
-		SELECT id, event_type, title, metadata, created_at
+		SELECT id, event_type, title, metadata, created_at, category, subcategory,
+			COALESCE(source, ''), COALESCE(external_id, '')
 		FROM events
 		%s
 		ORDER BY created_at DESC
@@ -107,7 +138,8 @@ func (r *EventRepository) GetEventsWithFilters(filter models.EventsFilter) ([]mo
 			var event models.DashboardEvent
 			var metadataBytes []byte
 
-			err := rows.Scan(&event.ID, &event.EventType, &event.Title, &metadataBytes, &event.CreatedAt)
+			err := rows.Scan(&event.ID, &event.EventType, &event.Title, &metadataBytes, &event.CreatedAt,
+				&event.Category, &event.Subcategory, &event.Source, &event.ExternalID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to scan event row: %w", err)
 			}
@@ -166,26 +198,22 @@ func (r *EventRepository) getStatsInternal(ctx context.Context) (models.EventSta
 				COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '24 hours') as last_24h,
 				COUNT(*) FILTER (WHERE created_at >= NOW() - INTERVAL '7 days') as last_week
 			FROM events
+			WHERE ` + statsEventFilter + `
 		),
 		service_counts AS (
 			SELECT
 				SPLIT_PART(event_type, '.', 1) as service,
 				COUNT(*) as count
 			FROM events
+			WHERE ` + statsEventFilter + `
 			GROUP BY 1
 		),
 		category_counts AS (
 			SELECT
-				CASE
-					WHEN event_type LIKE 'github.push%' OR event_type LIKE 'github.pr%' OR event_type LIKE 'github.release%' THEN 'development'
-					WHEN event_type LIKE 'vercel.%' OR event_type LIKE 'railway.%' THEN 'deployments'
-					WHEN event_type LIKE 'github.issue%' OR event_type LIKE 'error.%' THEN 'issues'
-					WHEN event_type LIKE 'security.%' THEN 'security'
-					WHEN event_type LIKE 'monitoring.%' THEN 'infrastructure'
-					ELSE 'development'
-				END as category,
+				category,
 				COUNT(*) as count
 			FROM events
+			WHERE ` + statsEventFilter + `
 			GROUP BY 1
 		)
 		SELECT
@@ -243,7 +271,7 @@ func (r *EventRepository) getStatsInternal(ctx context.Context) (models.EventSta
 			TO_CHAR(DATE(created_at), 'YYYY-MM-DD') as date,
 			COUNT(*) as count
 		FROM events
-		WHERE created_at >= NOW() - INTERVAL '30 days'
+		WHERE created_at >= NOW() - INTERVAL '30 days' AND ` + statsEventFilter + `
 		GROUP BY DATE(created_at)
 		ORDER BY DATE(created_at) ASC
 	`
@@ -271,7 +299,7 @@ func (r *EventRepository) getStatsInternal(ctx context.Context) (models.EventSta
 	return stats, nil
 }
 
-// GetYearlyDailyStats retrieves daily counts for the past 365 days
+// GetYearlyDailyStats retrieves daily counts for the past 365 days, excluding github.community events
 func (r *EventRepository) GetYearlyDailyStats() ([]models.DailyCount, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -282,7 +310,7 @@ func (r *EventRepository) GetYearlyDailyStats() ([]models.DailyCount, error) {
 				TO_CHAR(DATE(created_at), 'YYYY-MM-DD') as date,
 				COUNT(*) as count
 			FROM events
-			WHERE created_at >= NOW() - INTERVAL '365 days'
+			WHERE created_at >= NOW() - INTERVAL '365 days' AND ` + statsEventFilter + `
 			GROUP BY DATE(created_at)
 			ORDER BY DATE(created_at) ASC
 		`
@@ -315,10 +343,12 @@ func (r *EventRepository) CalculateStreak() (models.StreakInfo, error) {
 	defer cancel()
 
 	return WithRetry(ctx, DefaultRetryConfig, func() (models.StreakInfo, error) {
-		// Get all distinct dates with events, ordered descending
+		// Get all distinct dates with events, ordered descending. Stars and forks
+		// (github.community) are other people's activity and do not extend a streak.
 		query := `
 			SELECT DISTINCT DATE(created_at) as event_date
 			FROM events
+			WHERE ` + statsEventFilter + `
 			ORDER BY event_date DESC
 		`
 		rows, err := r.db.QueryContext(ctx, query)
@@ -408,7 +438,7 @@ func (r *EventRepository) CalculateStreak() (models.StreakInfo, error) {
 	})
 }
 
-// GetMonthlyStats retrieves aggregate statistics for a specific month
+// GetMonthlyStats retrieves aggregate statistics for a specific month, excluding github.community events
 func (r *EventRepository) GetMonthlyStats(year, month int) (models.MonthlyStats, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -432,7 +462,7 @@ func (r *EventRepository) GetMonthlyStats(year, month int) (models.MonthlyStats,
 				TO_CHAR(DATE(created_at), 'YYYY-MM-DD') as date,
 				COUNT(*) as count
 			FROM events
-			WHERE created_at >= $1 AND created_at < $2
+			WHERE created_at >= $1 AND created_at < $2 AND ` + statsEventFilter + `
 			GROUP BY DATE(created_at)
 			ORDER BY DATE(created_at) ASC
 		`
@@ -476,7 +506,7 @@ func (r *EventRepository) GetMonthlyStats(year, month int) (models.MonthlyStats,
 				SPLIT_PART(event_type, '.', 1) as service,
 				COUNT(*) as count
 			FROM events
-			WHERE created_at >= $1 AND created_at < $2
+			WHERE created_at >= $1 AND created_at < $2 AND ` + statsEventFilter + `
 			GROUP BY 1
 			ORDER BY count DESC
 			LIMIT 5
@@ -503,17 +533,10 @@ func (r *EventRepository) GetMonthlyStats(year, month int) (models.MonthlyStats,
 		// Get category breakdown
 		categoryQuery := `
 			SELECT
-				CASE
-					WHEN event_type LIKE 'github.push%' OR event_type LIKE 'github.pr%' OR event_type LIKE 'github.release%' THEN 'development'
-					WHEN event_type LIKE 'vercel.%' OR event_type LIKE 'railway.%' THEN 'deployments'
-					WHEN event_type LIKE 'github.issue%' OR event_type LIKE 'error.%' THEN 'issues'
-					WHEN event_type LIKE 'security.%' THEN 'security'
-					WHEN event_type LIKE 'monitoring.%' THEN 'infrastructure'
-					ELSE 'development'
-				END as category,
+				category,
 				COUNT(*) as count
 			FROM events
-			WHERE created_at >= $1 AND created_at < $2
+			WHERE created_at >= $1 AND created_at < $2 AND ` + statsEventFilter + `
 			GROUP BY 1
 		`
 		catRows, err := r.db.QueryContext(ctx, categoryQuery, monthStart, monthEnd)
@@ -540,27 +563,264 @@ func (r *EventRepository) GetMonthlyStats(year, month int) (models.MonthlyStats,
 	})
 }
 
-// InsertEvent inserts a new event into the database
-func (r *EventRepository) InsertEvent(event *models.DashboardEvent) error {
-	metadataJSON, err := json.Marshal(event.Metadata)
+// InsertEvent inserts a single event; see InsertEvents
+func (r *EventRepository) InsertEvent(event *models.DashboardEvent) (bool, error) {
+	events := []models.DashboardEvent{*event}
+	created, err := r.InsertEvents(events)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metadata: %w", err)
+		return false, err
+	}
+	*event = events[0]
+	return created[0], nil
+}
+
+// InsertEvents inserts events produced by one delivery in a single transaction and
+// sets each event's ID. Events with a Source and ExternalID that were already stored
+// are not inserted again; their created flag is false and their ID is the existing
+// row's. Payloads are archived once per distinct *RawPayload, and only kept when at
+// least one event referencing them was inserted.
+func (r *EventRepository) InsertEvents(events []models.DashboardEvent) ([]bool, error) {
+	r.categorize(events)
+
+	metadata := make([][]byte, len(events))
+	for i := range events {
+		metadataJSON, err := json.Marshal(events[i].Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		metadata[i] = metadataJSON
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	query := `
-		INSERT INTO events (event_type, title, metadata, created_at)
+	payloadQuery := `
+		INSERT INTO event_payloads (id, event_type, payload, received_at)
 		VALUES ($1, $2, $3, $4)
 	`
+	query := `
+		INSERT INTO events (event_type, title, metadata, created_at, category, subcategory, source, external_id, payload_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), $9)
+		ON CONFLICT (source, external_id) DO NOTHING
+		RETURNING id
+	`
+	existingQuery := `
+		SELECT id FROM events WHERE source = $1 AND external_id = $2
+	`
 
 	// Use the event's CreatedAt timestamp (set by transformer from webhook timestamp)
-	return WithRetryNoResult(ctx, DefaultRetryConfig, func() error {
-		_, err := r.db.ExecContext(ctx, query, event.EventType, event.Title, metadataJSON, event.CreatedAt)
+	return WithRetry(ctx, DefaultRetryConfig, func() ([]bool, error) {
+		tx, err := r.db.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		//nolint:errcheck // Rollback after Commit is a no-op
+		defer tx.Rollback()
+
+		payloadIDs := make(map[*models.RawPayload]string)
+		created := make([]bool, len(events))
+		anyCreated := false
+
+		for i := range events {
+			event := &events[i]
+
+			var payloadID sql.NullString
+			if event.Payload != nil {
+				id, archived := payloadIDs[event.Payload]
+				if !archived {
+					id = uuid.New().String()
+					_, err := tx.ExecContext(ctx, payloadQuery,
+						id, event.Payload.EventType, []byte(event.Payload.Data), event.Payload.ReceivedAt,
+					)
+					if err != nil {
+						return nil, fmt.Errorf("failed to archive payload: %w", err)
+					}
+					payloadIDs[event.Payload] = id
+				}
+				payloadID = sql.NullString{String: id, Valid: true}
+			}
+
+			err := tx.QueryRowContext(ctx, query,
+				event.EventType, event.Title, metadata[i], event.CreatedAt, event.Category, event.Subcategory,
+				event.Source, event.ExternalID, payloadID,
+			).Scan(&event.ID)
+			if errors.Is(err, sql.ErrNoRows) {
+				// Conflict: this event was already stored by an earlier delivery
+				if err := tx.QueryRowContext(ctx, existingQuery, event.Source, event.ExternalID).Scan(&event.ID); err != nil {
+					return nil, fmt.Errorf("failed to look up duplicate event: %w", err)
+				}
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to insert event: %w", err)
+			}
+			created[i] = true
+			anyCreated = true
+		}
+
+		// Nothing new: the deferred rollback discards the payloads archived above
+		if !anyCreated {
+			return created, nil
+		}
+
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit events: %w", err)
+		}
+		return created, nil
+	})
+}
+
+// StreamArchivedEvents calls fn for every event with an archived payload matching filter,
+// oldest payload first, with events sharing a payload delivered consecutively.
+// Iteration stops at the first error returned by fn.
+func (r *EventRepository) StreamArchivedEvents(ctx context.Context, filter models.ArchiveFilter, fn func(models.ArchivedEvent) error) error {
+	var conditions []string
+	var args []interface{}
+	argIndex := 1
+
+	if filter.EventType != "" {
+		conditions = append(conditions, fmt.Sprintf("p.event_type = $%d", argIndex))
+		args = append(args, filter.EventType)
+		argIndex++
+	}
+
+	if !filter.Since.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("e.created_at >= $%d", argIndex))
+		args = append(args, filter.Since)
+		argIndex++
+	}
+
+	if !filter.Until.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("e.created_at < $%d", argIndex))
+		args = append(args, filter.Until)
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Note: whereClause is safely constructed from validated conditions with parameterized args
+	query := fmt.Sprintf(`
+		SELECT e.id, e.event_type, e.title, e.metadata, e.created_at,
+			COALESCE(e.source, ''), COALESCE(e.external_id, ''), e.category, e.subcategory,
+			p.id, p.event_type, p.payload, p.received_at
+		FROM events e
+		JOIN event_payloads p ON p.id = e.payload_id
+		%s
+		ORDER BY p.received_at ASC, p.id, e.created_at ASC
+	`, whereClause) // #nosec G201
+
+	// Not retried: a partially consumed stream cannot be restarted transparently
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query archived events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var archived models.ArchivedEvent
+		var metadataBytes, payloadBytes []byte
+
+		err := rows.Scan(
+			&archived.Event.ID, &archived.Event.EventType, &archived.Event.Title, &metadataBytes, &archived.Event.CreatedAt,
+			&archived.Event.Source, &archived.Event.ExternalID, &archived.Event.Category, &archived.Event.Subcategory,
+			&archived.PayloadID, &archived.Payload.EventType, &payloadBytes, &archived.Payload.ReceivedAt,
+		)
 		if err != nil {
-			return fmt.Errorf("failed to insert event: %w", err)
+			return fmt.Errorf("failed to scan archived event row: %w", err)
+		}
+
+		if metadataBytes != nil {
+			if err := json.Unmarshal(metadataBytes, &archived.Event.Metadata); err != nil {
+				return fmt.Errorf("failed to unmarshal metadata for event %s: %w", archived.Event.ID, err)
+			}
+		}
+		archived.Payload.Data = payloadBytes
+
+		if err := fn(archived); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// UpdateEventContent replaces the event type, title, metadata and category of a
+// stored event with those of event; an event without a category is categorized first
+func (r *EventRepository) UpdateEventContent(ctx context.Context, id string, event models.DashboardEvent) error {
+	metadataJSON, err := json.Marshal(event.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	events := []models.DashboardEvent{event}
+	r.categorize(events)
+	event = events[0]
+
+	query := `
+		UPDATE events SET event_type = $2, title = $3, metadata = $4, category = $5, subcategory = $6
+		WHERE id = $1
+	`
+
+	return WithRetryNoResult(ctx, DefaultRetryConfig, func() error {
+		if _, err := r.db.ExecContext(ctx, query, id, event.EventType, event.Title, metadataJSON, event.Category, event.Subcategory); err != nil {
+			return fmt.Errorf("failed to update event: %w", err)
 		}
 		return nil
 	})
 }
+
+// RecategorizeEvents applies the current categorizer to stored events whose category
+// or subcategory differs from it, returning the number of updated events. Rules are
+// evaluated once per distinct event type, so this stays cheap when nothing changed.
+func (r *EventRepository) RecategorizeEvents(ctx context.Context) (int64, error) {
+	var eventTypes []string
+	err := WithRetryNoResult(ctx, DefaultRetryConfig, func() error {
+		rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT event_type FROM events`)
+		if err != nil {
+			return fmt.Errorf("failed to query event types: %w", err)
+		}
+		defer rows.Close()
+
+		eventTypes = eventTypes[:0]
+		for rows.Next() {
+			var eventType string
+			if err := rows.Scan(&eventType); err != nil {
+				return fmt.Errorf("failed to scan event type: %w", err)
+			}
+			eventTypes = append(eventTypes, eventType)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		UPDATE events SET category = $2, subcategory = $3
+		WHERE event_type = $1 AND (category <> $2 OR subcategory <> $3)
+	`
+
+	var updated int64
+	for _, eventType := range eventTypes {
+		category, subcategory := r.categorizer.Categorize(eventType)
+		err := WithRetryNoResult(ctx, DefaultRetryConfig, func() error {
+			result, err := r.db.ExecContext(ctx, query, eventType, category, subcategory)
+			if err != nil {
+				return fmt.Errorf("failed to recategorize %s events: %w", eventType, err)
+			}
+			count, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to recategorize %s events: %w", eventType, err)
+			}
+			updated += count
+			return nil
+		})
+		if err != nil {
+			return updated, err
+		}
+	}
+
+	return updated, nil
+}
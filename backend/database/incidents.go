@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// GetIncidentEvents returns every incident.* event of the incidents triggered at
+// or after since, including acknowledgements and resolutions recorded later.
+// Events are ordered oldest first.
+func (r *EventRepository) GetIncidentEvents(since time.Time) ([]models.IncidentEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT
+			metadata->>'incident_id',
+			SPLIT_PART(event_type, '.', 2),
+			CASE WHEN metadata->>'number' ~ '^[0-9]{1,9}$' THEN (metadata->>'number')::int ELSE 0 END,
+			COALESCE(metadata->>'incident_title', ''),
+			COALESCE(metadata->>'service', ''),
+			COALESCE(metadata->>'urgency', ''),
+			COALESCE(metadata->>'assignee', ''),
+			COALESCE(metadata->>'url', ''),
+			created_at
+		FROM events
+		WHERE event_type LIKE 'incident.%'
+			AND metadata->>'incident_id' IN (
+				SELECT metadata->>'incident_id'
+				FROM events
+				WHERE event_type = 'incident.triggered' AND created_at >= $1
+			)
+		ORDER BY created_at ASC
+	`
+
+	events, err := WithRetry(ctx, DefaultRetryConfig, func() ([]models.IncidentEvent, error) {
+		rows, err := r.db.QueryContext(ctx, query, since)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var results []models.IncidentEvent
+		for rows.Next() {
+			var event models.IncidentEvent
+			if err := rows.Scan(
+				&event.IncidentID, &event.Action, &event.Number, &event.Title,
+				&event.Service, &event.Urgency, &event.Assignee, &event.URL, &event.CreatedAt,
+			); err != nil {
+				return nil, fmt.Errorf("failed to scan incident event: %w", err)
+			}
+			results = append(results, event)
+		}
+		return results, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident events: %w", err)
+	}
+	return events, nil
+}
@@ -1,10 +1,14 @@
 package database
 
-import "heimdall-backend/models"
+import (
+	"time"
+
+	"heimdall-backend/models"
+)
 
 // EventStore defines the interface for event storage operations
 type EventStore interface {
-	InsertEvent(event *models.DashboardEvent) error
+	InsertEvents(events []models.DashboardEvent) ([]bool, error)
 	GetRecentEvents(limit int) ([]models.DashboardEvent, error)
 	GetEventsWithFilters(filter models.EventsFilter) ([]models.DashboardEvent, int, error)
 	GetStats() (models.EventStats, error)
@@ -13,5 +17,76 @@ type EventStore interface {
 	GetMonthlyStats(year int, month int) (models.MonthlyStats, error)
 }
 
+// BatchEventStore defines bulk event storage used by asynchronous ingestion
+type BatchEventStore interface {
+	InsertEventBatch(events []models.DashboardEvent) (int, error)
+}
+
+// MonitorStore defines the event operations used by the uptime monitor
+type MonitorStore interface {
+	InsertEvents(events []models.DashboardEvent) ([]bool, error)
+	GetMonitorChecks(since time.Time) ([]models.MonitorCheck, error)
+}
+
+// IncidentStore defines the event operations used by the incident view
+type IncidentStore interface {
+	GetIncidentEvents(since time.Time) ([]models.IncidentEvent, error)
+}
+
+// PipelineStore defines the event operations used to trace a commit to production
+type PipelineStore interface {
+	GetCommitEvents(shaPrefix string) ([]models.CommitEvent, error)
+	GetBranchRelease(repo, branch string, after time.Time) (*models.CommitEvent, error)
+}
+
+// DORAStore defines the event operations used by the DORA metrics
+type DORAStore interface {
+	GetDeployments(since time.Time) ([]models.Deployment, error)
+}
+
+// DeploymentStatsStore defines the event operations used by the deploy analytics
+type DeploymentStatsStore interface {
+	GetDeploymentRuns(since time.Time) ([]models.DeploymentRun, error)
+}
+
+// PullRequestStore defines the event operations used by the PR cycle-time analytics
+type PullRequestStore interface {
+	GetPullRequestEvents(since, until time.Time) ([]models.PullRequestEvent, error)
+}
+
+// DeadLetterStore defines the interface for failed webhook delivery storage
+type DeadLetterStore interface {
+	InsertDeadLetter(dl *models.DeadLetter) error
+	ListDeadLetters(filter models.DeadLetterFilter) ([]models.DeadLetter, int, error)
+	GetDeadLetter(id string) (models.DeadLetter, error)
+	RecordReplayFailure(id string, replayErr string) error
+	DeleteDeadLetter(id string) error
+	PurgeDeadLetters(before time.Time) (int64, error)
+}
+
 // Ensure EventRepository implements EventStore
 var _ EventStore = (*EventRepository)(nil)
+
+// Ensure EventRepository implements BatchEventStore
+var _ BatchEventStore = (*EventRepository)(nil)
+
+// Ensure EventRepository implements MonitorStore
+var _ MonitorStore = (*EventRepository)(nil)
+
+// Ensure EventRepository implements IncidentStore
+var _ IncidentStore = (*EventRepository)(nil)
+
+// Ensure EventRepository implements PipelineStore
+var _ PipelineStore = (*EventRepository)(nil)
+
+// Ensure EventRepository implements DORAStore
+var _ DORAStore = (*EventRepository)(nil)
+
+// Ensure EventRepository implements DeploymentStatsStore
+var _ DeploymentStatsStore = (*EventRepository)(nil)
+
+// Ensure EventRepository implements PullRequestStore
+var _ PullRequestStore = (*EventRepository)(nil)
+
+// Ensure DeadLetterRepository implements DeadLetterStore
+var _ DeadLetterStore = (*DeadLetterRepository)(nil)
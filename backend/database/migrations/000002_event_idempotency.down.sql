@@ -0,0 +1,5 @@
+-- Rollback idempotent ingestion
+
+DROP INDEX IF EXISTS idx_events_source_external_id;
+ALTER TABLE events DROP COLUMN IF EXISTS external_id;
+ALTER TABLE events DROP COLUMN IF EXISTS source;
@@ -0,0 +1,10 @@
+-- Idempotent ingestion
+-- Stores the provider delivery identity so redelivered webhooks are not inserted twice.
+-- external_id is namespaced by source: a GitHub delivery ID ("github"), a QStash
+-- message ID ("qstash") or a deployment ID plus state ("vercel", "railway").
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS source VARCHAR(50);
+ALTER TABLE events ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+-- NULLs never conflict, so events without a delivery identity are unaffected
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_source_external_id ON events (source, external_id);
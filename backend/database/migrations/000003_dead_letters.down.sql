@@ -0,0 +1,5 @@
+-- Rollback dead-letter store
+
+DROP INDEX IF EXISTS idx_dead_letters_created_at;
+DROP INDEX IF EXISTS idx_dead_letters_source_delivery;
+DROP TABLE IF EXISTS dead_letters;
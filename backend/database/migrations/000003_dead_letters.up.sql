@@ -0,0 +1,21 @@
+-- Dead-letter store for webhook deliveries that failed transformation
+-- Payloads are kept verbatim so they can be replayed after a transformer fix
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id VARCHAR(255) PRIMARY KEY DEFAULT uuid_generate_v4()::text,
+    source VARCHAR(50) NOT NULL,
+    delivery_id VARCHAR(255),
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    headers JSONB,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Redeliveries of the same delivery bump attempts instead of adding rows
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dead_letters_source_delivery ON dead_letters (source, delivery_id);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letters_created_at ON dead_letters (created_at DESC);
@@ -0,0 +1,6 @@
+-- Rollback raw payload archive
+
+DROP INDEX IF EXISTS idx_event_payloads_type_received;
+DROP INDEX IF EXISTS idx_events_payload_id;
+ALTER TABLE events DROP COLUMN IF EXISTS payload_id;
+DROP TABLE IF EXISTS event_payloads;
@@ -0,0 +1,17 @@
+-- Raw payload archive
+-- Keeps the original transformer input for each event so transformers can be
+-- re-run over history (see cmd/reprocess) when they start extracting new fields.
+
+CREATE TABLE IF NOT EXISTS event_payloads (
+    id VARCHAR(255) PRIMARY KEY DEFAULT uuid_generate_v4()::text,
+    event_type VARCHAR(100) NOT NULL, -- Registry event type the payload was transformed with
+    payload JSONB NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS payload_id VARCHAR(255)
+    REFERENCES event_payloads (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_payload_id ON events (payload_id);
+CREATE INDEX IF NOT EXISTS idx_event_payloads_type_received ON event_payloads (event_type, received_at);
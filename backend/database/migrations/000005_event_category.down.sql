@@ -0,0 +1,5 @@
+-- Rollback stored event categories
+
+DROP INDEX IF EXISTS idx_events_category_created;
+ALTER TABLE events DROP COLUMN IF EXISTS subcategory;
+ALTER TABLE events DROP COLUMN IF EXISTS category;
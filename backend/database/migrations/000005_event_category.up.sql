@@ -0,0 +1,10 @@
+-- Stored event categories
+-- Events are categorized when they are stored (see the categories package), so
+-- stats group by an indexed column instead of matching event_type patterns.
+-- Existing rows keep the defaults until the server starts and recategorizes them
+-- with the loaded taxonomy (EventRepository.RecategorizeEvents).
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS category VARCHAR(50) NOT NULL DEFAULT 'development';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS subcategory VARCHAR(50) NOT NULL DEFAULT 'other';
+
+CREATE INDEX IF NOT EXISTS idx_events_category_created ON events (category, created_at DESC);
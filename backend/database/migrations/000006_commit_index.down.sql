@@ -0,0 +1,4 @@
+-- Rollback commit lookups
+
+DROP INDEX IF EXISTS idx_events_merge_commit_sha;
+DROP INDEX IF EXISTS idx_events_commit_sha;
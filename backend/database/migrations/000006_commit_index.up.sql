@@ -0,0 +1,7 @@
+-- Commit lookups
+-- /api/pipelines/{sha} finds every event built from a commit (pushes, CI runs,
+-- deploys, releases) and the PR that merged it by SHA prefix. text_pattern_ops
+-- lets LIKE 'abc1234%' use the index.
+
+CREATE INDEX IF NOT EXISTS idx_events_commit_sha ON events ((metadata->>'commit_sha') text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_events_merge_commit_sha ON events ((metadata->>'merge_commit_sha') text_pattern_ops);
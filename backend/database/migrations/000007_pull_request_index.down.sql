@@ -0,0 +1,3 @@
+-- Rollback pull request lookups
+
+DROP INDEX IF EXISTS idx_events_pull_request;
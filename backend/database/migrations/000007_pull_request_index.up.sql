@@ -0,0 +1,6 @@
+-- Pull request lookups
+-- /api/pull-requests/stats gathers the lifecycle, review and comment events of
+-- each PR by repo and number.
+
+CREATE INDEX IF NOT EXISTS idx_events_pull_request ON events ((metadata->>'repo'), (metadata->>'number'), created_at)
+    WHERE event_type IN ('github.pr', 'github.review', 'github.review_comment', 'github.comment');
@@ -0,0 +1,2 @@
+-- Rollback duplicate Vercel successes
+-- The removed rows were duplicates and are not restored.
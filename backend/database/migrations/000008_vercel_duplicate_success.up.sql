@@ -0,0 +1,14 @@
+-- Duplicate Vercel successes
+-- Vercel sends both deployment.succeeded and deployment.ready for a successful
+-- deployment, and both used to be stored. New deliveries are deduplicated on the
+-- deployment ID plus status; this removes the extra deployment.ready rows.
+
+DELETE FROM events ready
+WHERE ready.event_type = 'vercel.deploy'
+    AND ready.metadata->>'event_type' = 'deployment.ready'
+    AND EXISTS (
+        SELECT 1 FROM events succeeded
+        WHERE succeeded.event_type = 'vercel.deploy'
+            AND succeeded.metadata->>'event_type' = 'deployment.succeeded'
+            AND succeeded.metadata->>'deployment_id' = ready.metadata->>'deployment_id'
+    );
@@ -0,0 +1,5 @@
+-- Rollback CI job events
+-- Removed Actions check suites are not restored.
+
+UPDATE events SET event_type = 'github.ci'
+WHERE event_type = 'github.ci_job';
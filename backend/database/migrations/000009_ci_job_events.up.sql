@@ -0,0 +1,12 @@
+-- Count each GitHub Actions run once
+-- An Actions run used to be stored as a workflow_run, a check_suite and one
+-- event per job, all github.ci. Jobs become github.ci_job, which the stats leave
+-- out, and the Actions check suites are removed since the workflow_run covers them.
+
+UPDATE events SET event_type = 'github.ci_job'
+WHERE event_type = 'github.ci' AND metadata->>'kind' = 'workflow_job';
+
+DELETE FROM events
+WHERE event_type = 'github.ci'
+    AND metadata->>'kind' = 'check_suite'
+    AND metadata->>'workflow' = 'GitHub Actions';
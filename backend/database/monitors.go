@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// GetMonitorChecks returns the monitoring.check state changes recorded at or after
+// since, preceded by each monitor's last change before since so callers know the
+// state every monitor was in at the start of the window. Checks are ordered oldest first.
+func (r *EventRepository) GetMonitorChecks(since time.Time) ([]models.MonitorCheck, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT monitor, state, created_at FROM (
+			(
+				SELECT DISTINCT ON (metadata->>'monitor')
+					metadata->>'monitor' AS monitor, metadata->>'state' AS state, created_at
+				FROM events
+				WHERE event_type = 'monitoring.check' AND created_at < $1
+				ORDER BY metadata->>'monitor', created_at DESC
+			)
+			UNION ALL
+			(
+				SELECT metadata->>'monitor', metadata->>'state', created_at
+				FROM events
+				WHERE event_type = 'monitoring.check' AND created_at >= $1
+			)
+		) checks
+		WHERE monitor IS NOT NULL AND state IS NOT NULL
+		ORDER BY created_at ASC
+	`
+
+	checks, err := WithRetry(ctx, DefaultRetryConfig, func() ([]models.MonitorCheck, error) {
+		rows, err := r.db.QueryContext(ctx, query, since)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var results []models.MonitorCheck
+		for rows.Next() {
+			var check models.MonitorCheck
+			if err := rows.Scan(&check.Monitor, &check.State, &check.CreatedAt); err != nil {
+				return nil, fmt.Errorf("failed to scan monitor check: %w", err)
+			}
+			results = append(results, check)
+		}
+		return results, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query monitor checks: %w", err)
+	}
+	return checks, nil
+}
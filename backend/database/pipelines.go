@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// commitEventColumns selects the fields of a models.CommitEvent; see scanCommitEvent.
+// Metadata values are only cast when they have the expected JSON type, so one
+// malformed event cannot fail the whole query.
+const commitEventColumns = `
+	id,
+	event_type,
+	title,
+	COALESCE(metadata->>'action', ''),
+	COALESCE(metadata->>'kind', ''),
+	CASE WHEN jsonb_typeof(metadata->'merged') = 'boolean'
+		THEN (metadata->>'merged')::boolean ELSE false END,
+	COALESCE(metadata->>'status', ''),
+	COALESCE(metadata->>'environment', ''),
+	COALESCE(metadata->>'deployment_id', ''),
+	COALESCE(metadata->>'repo', ''),
+	COALESCE(metadata->>'branch', metadata->>'base_branch', ''),
+	COALESCE(metadata->>'deployment_url', metadata->>'run_url', metadata->>'pr_url',
+		metadata->>'release_url', metadata->>'commit_url', ''),
+	COALESCE(metadata->>'commit_sha', ''),
+	COALESCE(metadata->>'merge_commit_sha', ''),
+	CASE WHEN jsonb_typeof(metadata->'duration_seconds') = 'number'
+		THEN (metadata->>'duration_seconds')::numeric::bigint ELSE 0 END,
+	created_at
+`
+
+// maxCommitEvents caps the events loaded for one commit
+const maxCommitEvents = 500
+
+// GetCommitEvents returns the events whose commit_sha or merge_commit_sha starts
+// with shaPrefix (lowercase hex), oldest first
+func (r *EventRepository) GetCommitEvents(shaPrefix string) ([]models.CommitEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Both keys have a text_pattern_ops index, so the prefix match is an index scan
+	query := `
+		SELECT ` + commitEventColumns + `
+		FROM events
+		WHERE metadata->>'commit_sha' LIKE $1 OR metadata->>'merge_commit_sha' LIKE $1
+		ORDER BY created_at ASC
+		LIMIT $2
+	`
+
+	events, err := WithRetry(ctx, DefaultRetryConfig, func() ([]models.CommitEvent, error) {
+		rows, err := r.db.QueryContext(ctx, query, shaPrefix+"%", maxCommitEvents)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var results []models.CommitEvent
+		for rows.Next() {
+			event, err := scanCommitEvent(rows)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, event)
+		}
+		return results, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commit events: %w", err)
+	}
+	return events, nil
+}
+
+// GetBranchRelease returns the first GitHub release of repo published from branch
+// at or after after, or nil when there is none yet. GitHub releases name the branch
+// they were cut from rather than a commit, so this is how a commit's release is
+// found once it has landed on the branch.
+func (r *EventRepository) GetBranchRelease(repo, branch string, after time.Time) (*models.CommitEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		SELECT ` + commitEventColumns + `
+		FROM events
+		WHERE event_type = 'github.release'
+			AND metadata->>'action' = 'published'
+			AND metadata->>'repo' = $1
+			AND metadata->>'target_commitish' = $2
+			AND created_at >= $3
+		ORDER BY created_at ASC
+		LIMIT 1
+	`
+
+	release, err := WithRetry(ctx, DefaultRetryConfig, func() (*models.CommitEvent, error) {
+		rows, err := r.db.QueryContext(ctx, query, repo, branch, after)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		if !rows.Next() {
+			return nil, rows.Err()
+		}
+		event, err := scanCommitEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		return &event, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query branch release: %w", err)
+	}
+	return release, nil
+}
+
+// scanCommitEvent reads a row selected with commitEventColumns
+func scanCommitEvent(rows *sql.Rows) (models.CommitEvent, error) {
+	var event models.CommitEvent
+	if err := rows.Scan(
+		&event.ID, &event.EventType, &event.Title, &event.Action, &event.Kind, &event.Merged,
+		&event.Status, &event.Environment, &event.DeploymentID, &event.Repo, &event.Branch,
+		&event.URL, &event.CommitSHA, &event.MergeCommitSHA, &event.DurationSeconds, &event.CreatedAt,
+	); err != nil {
+		return event, fmt.Errorf("failed to scan commit event: %w", err)
+	}
+	return event, nil
+}
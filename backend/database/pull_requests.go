@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// GetPullRequestEvents returns the lifecycle and review events, before until, of
+// every GitHub PR that had a PR event at or after since or was still open at
+// until, oldest first. Comments count only when they are on a PR. Metadata values
+// are only cast when they have the expected JSON type, and PRs without a numeric
+// number are skipped, so one malformed event cannot fail the whole query.
+func (r *EventRepository) GetPullRequestEvents(since, until time.Time) ([]models.PullRequestEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	query := `
+		WITH latest AS (
+			SELECT DISTINCT ON (metadata->>'repo', metadata->>'number')
+				metadata->>'repo' AS repo,
+				metadata->>'number' AS number,
+				metadata->>'action' AS action,
+				created_at
+			FROM events
+			WHERE event_type = 'github.pr' AND created_at < $2
+				AND metadata->>'number' ~ '^[0-9]{1,9}$'
+			ORDER BY metadata->>'repo', metadata->>'number', created_at DESC
+		)
+		SELECT
+			e.event_type,
+			COALESCE(e.metadata->>'action', ''),
+			l.repo,
+			l.number::int,
+			COALESCE(e.metadata->>'pr_author', e.metadata->>'issue_author', e.metadata->>'author', ''),
+			COALESCE(e.metadata->>'reviewer', e.metadata->>'author', ''),
+			CASE WHEN jsonb_typeof(e.metadata->'merged') = 'boolean'
+				THEN (e.metadata->>'merged')::boolean ELSE false END,
+			COALESCE(e.metadata->>'pr_url', ''),
+			e.created_at
+		FROM events e
+		JOIN latest l ON l.repo = e.metadata->>'repo' AND l.number = e.metadata->>'number'
+		WHERE (l.created_at >= $1 OR l.action <> 'closed')
+			AND e.created_at < $2
+			AND (e.event_type IN ('github.pr', 'github.review', 'github.review_comment')
+				OR (e.event_type = 'github.comment' AND e.metadata->'is_pull_request' = 'true'::jsonb))
+		ORDER BY e.created_at ASC
+	`
+
+	events, err := WithRetry(ctx, DefaultRetryConfig, func() ([]models.PullRequestEvent, error) {
+		rows, err := r.db.QueryContext(ctx, query, since, until)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var results []models.PullRequestEvent
+		for rows.Next() {
+			var event models.PullRequestEvent
+			if err := rows.Scan(
+				&event.EventType, &event.Action, &event.Repo, &event.Number, &event.Author,
+				&event.Actor, &event.Merged, &event.URL, &event.CreatedAt,
+			); err != nil {
+				return nil, fmt.Errorf("failed to scan pull request event: %w", err)
+			}
+			results = append(results, event)
+		}
+		return results, rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pull request events: %w", err)
+	}
+	return events, nil
+}
@@ -75,6 +75,12 @@ func isRetryableError(err error) bool {
 	return false
 }
 
+// IsTransientError reports whether err is a temporary database failure that a
+// later retry of the same operation could succeed at
+func IsTransientError(err error) bool {
+	return isRetryableError(err)
+}
+
 // contains checks if s contains substr (case-insensitive would be better but this is simpler)
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && searchString(s, substr)
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"heimdall-backend/categories"
+	"heimdall-backend/logger"
+)
+
+// CategoriesHandler serves the category taxonomy and the rules used to assign it
+type CategoriesHandler struct {
+	categorizer *categories.Categorizer
+}
+
+// NewCategoriesHandler creates a new categories handler
+func NewCategoriesHandler(categorizer *categories.Categorizer) *CategoriesHandler {
+	return &CategoriesHandler{categorizer: categorizer}
+}
+
+// ServeHTTP handles GET /api/categories. The response has the same shape as a
+// CATEGORY_RULES_FILE, so it can be saved and edited to customize the rules.
+func (h *CategoriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	// The taxonomy only changes on restart
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, log, http.StatusOK, h.categorizer.Taxonomy())
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"heimdall-backend/categories"
+)
+
+func TestCategoriesHandler(t *testing.T) {
+	handler := NewCategoriesHandler(categories.Default())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var taxonomy categories.Taxonomy
+	if err := json.NewDecoder(rec.Body).Decode(&taxonomy); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(taxonomy.Categories) != 5 {
+		t.Errorf("expected 5 categories, got %d", len(taxonomy.Categories))
+	}
+	if taxonomy.Fallback.Category != "development" {
+		t.Errorf("expected fallback development, got %q", taxonomy.Fallback.Category)
+	}
+
+	// The served taxonomy is a valid rules file
+	if err := taxonomy.Validate(); err != nil {
+		t.Errorf("served taxonomy does not validate: %v", err)
+	}
+}
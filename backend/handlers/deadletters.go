@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+
+	"github.com/gorilla/mux"
+)
+
+// DeadLetterHandler serves the admin API for inspecting, replaying and purging
+// webhook deliveries that could not be ingested
+type DeadLetterHandler struct {
+	store    database.DeadLetterStore
+	ingester *Ingester
+}
+
+// NewDeadLetterHandler creates a new dead-letter handler
+func NewDeadLetterHandler(store database.DeadLetterStore, ingester *Ingester) *DeadLetterHandler {
+	return &DeadLetterHandler{
+		store:    store,
+		ingester: ingester,
+	}
+}
+
+// DeadLettersResponse wraps dead letters with pagination metadata
+type DeadLettersResponse struct {
+	DeadLetters []models.DeadLetter `json:"dead_letters"`
+	Pagination  PaginationMeta      `json:"pagination"`
+}
+
+// ReplayResponse reports the outcome of replaying a dead letter
+type ReplayResponse struct {
+	DeadLetterID string   `json:"dead_letter_id"`
+	EventID      string   `json:"event_id,omitempty"`
+	EventIDs     []string `json:"event_ids,omitempty"`
+	Duplicate    bool     `json:"duplicate"`
+	Error        string   `json:"error,omitempty"`
+}
+
+// List handles GET /api/admin/dead-letters
+func (h *DeadLetterHandler) List(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	filter := models.DeadLetterFilter{Limit: 50}
+	query := r.URL.Query()
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		if limit > 500 {
+			limit = 500 // max limit
+		}
+		filter.Limit = limit
+	}
+	if offset, err := strconv.Atoi(query.Get("offset")); err == nil && offset >= 0 {
+		filter.Offset = offset
+	}
+	filter.Source = query.Get("source")
+	filter.EventType = query.Get("type")
+
+	deadLetters, total, err := h.store.ListDeadLetters(filter)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list dead letters")
+		http.Error(w, "Failed to list dead letters", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, log, http.StatusOK, DeadLettersResponse{
+		DeadLetters: deadLetters,
+		Pagination: PaginationMeta{
+			Limit:   filter.Limit,
+			Offset:  filter.Offset,
+			Total:   total,
+			HasMore: filter.Offset+len(deadLetters) < total,
+		},
+	})
+}
+
+// Get handles GET /api/admin/dead-letters/{id}
+func (h *DeadLetterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	dl, err := h.store.GetDeadLetter(mux.Vars(r)["id"])
+	if err != nil {
+		writeLookupError(w, log, err)
+		return
+	}
+
+	writeJSON(w, log, http.StatusOK, dl)
+}
+
+// Replay handles POST /api/admin/dead-letters/{id}/replay.
+// The stored payload is run through the current transformer registry; on success
+// the dead letter is removed, otherwise its attempt count and error are updated.
+func (h *DeadLetterHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	dl, err := h.store.GetDeadLetter(mux.Vars(r)["id"])
+	if err != nil {
+		writeLookupError(w, log, err)
+		return
+	}
+
+	headers := make(http.Header, len(dl.Headers))
+	for name, value := range dl.Headers {
+		headers.Set(name, value)
+	}
+
+	events, created, ingestErr := h.ingester.process(ingestRequest{
+		Headers:    headers,
+		Source:     dl.Source,
+		DeliveryID: dl.DeliveryID,
+		EventType:  dl.EventType,
+		EventData:  json.RawMessage(dl.Payload),
+		Timestamp:  dl.ReceivedAt,
+	})
+	if ingestErr != nil {
+		log.Warn().
+			Err(ingestErr).
+			Str("dead_letter_id", dl.ID).
+			Bool("permanent", ingestErr.permanent).
+			Msg("dead letter replay failed")
+		if ingestErr.permanent {
+			if err := h.store.RecordReplayFailure(dl.ID, ingestErr.Error()); err != nil {
+				log.Error().Err(err).Str("dead_letter_id", dl.ID).Msg("failed to record replay failure")
+			}
+		}
+		writeJSON(w, log, ingestErr.status, ReplayResponse{
+			DeadLetterID: dl.ID,
+			Error:        ingestErr.Error(),
+		})
+		return
+	}
+
+	if err := h.store.DeleteDeadLetter(dl.ID); err != nil && !errors.Is(err, database.ErrNotFound) {
+		// The event is stored; a leftover dead letter replays as a duplicate next time
+		log.Error().Err(err).Str("dead_letter_id", dl.ID).Msg("failed to delete replayed dead letter")
+	}
+
+	result := newIngestResponse(events, created)
+	log.Info().
+		Str("dead_letter_id", dl.ID).
+		Strs("event_ids", result.IDs).
+		Bool("duplicate", result.Duplicate).
+		Msg("dead letter replayed")
+
+	writeJSON(w, log, http.StatusOK, ReplayResponse{
+		DeadLetterID: dl.ID,
+		EventID:      result.ID,
+		EventIDs:     result.IDs,
+		Duplicate:    result.Duplicate,
+	})
+}
+
+// Delete handles DELETE /api/admin/dead-letters/{id}
+func (h *DeadLetterHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	if err := h.store.DeleteDeadLetter(mux.Vars(r)["id"]); err != nil {
+		writeLookupError(w, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Purge handles DELETE /api/admin/dead-letters.
+// An optional ?before= (YYYY-MM-DD or RFC3339) limits the purge to older entries.
+func (h *DeadLetterHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	var before time.Time
+	if beforeStr := r.URL.Query().Get("before"); beforeStr != "" {
+		var err error
+		if before, err = time.Parse("2006-01-02", beforeStr); err != nil {
+			if before, err = time.Parse(time.RFC3339, beforeStr); err != nil {
+				http.Error(w, "Invalid before: expected YYYY-MM-DD or RFC3339", http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	purged, err := h.store.PurgeDeadLetters(before)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to purge dead letters")
+		http.Error(w, "Failed to purge dead letters", http.StatusInternalServerError)
+		return
+	}
+
+	log.Info().Int64("purged", purged).Msg("dead letters purged")
+	writeJSON(w, log, http.StatusOK, map[string]int64{"purged": purged})
+}
+
+// writeLookupError maps repository lookup errors to HTTP responses
+func writeLookupError(w http.ResponseWriter, log *logger.Logger, err error) {
+	if errors.Is(err, database.ErrNotFound) {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	log.Error().Err(err).Msg("failed to look up dead letter")
+	http.Error(w, "Failed to look up dead letter", http.StatusInternalServerError)
+}
+
+// writeJSON encodes v as the response body with the given status
+func writeJSON(w http.ResponseWriter, log *logger.Logger, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Error().Err(err).Msg("failed to encode response")
+	}
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+const (
+	defaultDeploymentStatsDays = 30
+	maxDeploymentStatsDays     = 365
+
+	// slowestDeploymentsLimit caps the slowest runs listed per group
+	slowestDeploymentsLimit = 5
+)
+
+// DeploymentStatsResponse lists the deploy stats of each project and environment
+type DeploymentStatsResponse struct {
+	Days   int                           `json:"days"`
+	Groups []models.DeploymentStatsGroup `json:"groups"`
+}
+
+// DeploymentStatsHandler serves build durations, success rates, failure streaks
+// and the slowest deploys, derived from paired deploy lifecycle events
+type DeploymentStatsHandler struct {
+	repo database.DeploymentStatsStore
+}
+
+// NewDeploymentStatsHandler creates a new deployment stats handler
+func NewDeploymentStatsHandler(repo database.DeploymentStatsStore) *DeploymentStatsHandler {
+	return &DeploymentStatsHandler{repo: repo}
+}
+
+// ServeHTTP handles GET /api/deployments/stats?days=N&project=P&environment=E
+func (h *DeploymentStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	query := r.URL.Query()
+
+	days := defaultDeploymentStatsDays
+	if daysStr := query.Get("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			days = min(d, maxDeploymentStatsDays)
+		}
+	}
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -days)
+	runs, err := h.repo.GetDeploymentRuns(since)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load deployment runs")
+		http.Error(w, "Failed to load deployment stats", http.StatusInternalServerError)
+		return
+	}
+
+	project, environment := query.Get("project"), query.Get("environment")
+	filtered := runs[:0]
+	for _, run := range runs {
+		if (project == "" || run.Project == project) && (environment == "" || run.Environment == environment) {
+			filtered = append(filtered, run)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=60")
+	writeJSON(w, log, http.StatusOK, DeploymentStatsResponse{
+		Days:   days,
+		Groups: computeDeploymentStats(filtered, since, now),
+	})
+}
+
+// computeDeploymentStats groups runs, oldest first, by project and environment
+// and summarizes them for the whole window and for every week in it, each week
+// compared with the one before
+func computeDeploymentStats(runs []models.DeploymentRun, since, now time.Time) []models.DeploymentStatsGroup {
+	type group struct {
+		result models.DeploymentStatsGroup
+		runs   []models.DeploymentRun
+		weeks  map[string][]models.DeploymentRun
+	}
+	groups := make(map[string]*group)
+	var keys []string
+
+	for _, run := range runs {
+		key := run.Project + "\x00" + run.Environment
+		g, ok := groups[key]
+		if !ok {
+			g = &group{
+				result: models.DeploymentStatsGroup{Project: run.Project, Environment: run.Environment},
+				weeks:  make(map[string][]models.DeploymentRun),
+			}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.runs = append(g.runs, run)
+		week := weekStart(run.FinishedAt).Format("2006-01-02")
+		g.weeks[week] = append(g.weeks[week], run)
+	}
+
+	var weekStarts []string
+	for week := weekStart(since); !week.After(now); week = week.AddDate(0, 0, 7) {
+		weekStarts = append(weekStarts, week.Format("2006-01-02"))
+	}
+
+	sort.Strings(keys)
+	result := make([]models.DeploymentStatsGroup, 0, len(keys))
+	for _, key := range keys {
+		g := groups[key]
+		stats := g.result
+		stats.Summary = summarizeDeploymentRuns(g.runs)
+		stats.LongestFailureStreak, stats.CurrentFailureStreak, stats.CurrentStreakStartedAt = failureStreaks(g.runs)
+		stats.Slowest = slowestDeploymentRuns(g.runs, slowestDeploymentsLimit)
+
+		stats.Weeks = make([]models.DeploymentWeek, 0, len(weekStarts))
+		for i, week := range weekStarts {
+			current := models.DeploymentWeek{WeekStart: week, DeploymentStats: summarizeDeploymentRuns(g.weeks[week])}
+			if i > 0 {
+				current.Trend = deploymentTrend(stats.Weeks[i-1].DeploymentStats, current.DeploymentStats)
+			}
+			stats.Weeks = append(stats.Weeks, current)
+		}
+		result = append(result, stats)
+	}
+	return result
+}
+
+// summarizeDeploymentRuns counts outcomes and takes duration percentiles
+func summarizeDeploymentRuns(runs []models.DeploymentRun) models.DeploymentStats {
+	stats := models.DeploymentStats{Runs: len(runs)}
+	var durations []int64
+
+	for _, run := range runs {
+		switch run.Status {
+		case "SUCCESS":
+			stats.Succeeded++
+		case "FAILED":
+			stats.Failed++
+		case "CANCELED":
+			stats.Canceled++
+		}
+		if run.DurationSeconds != nil {
+			durations = append(durations, *run.DurationSeconds)
+		}
+	}
+
+	if finished := stats.Succeeded + stats.Failed; finished > 0 {
+		rate := float64(stats.Succeeded) / float64(finished)
+		stats.SuccessRate = &rate
+	}
+	if len(durations) > 0 {
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		stats.DurationSampleCount = len(durations)
+		stats.DurationP50Seconds = percentileSeconds(durations, 50)
+		stats.DurationP90Seconds = percentileSeconds(durations, 90)
+		stats.DurationP95Seconds = percentileSeconds(durations, 95)
+		stats.DurationMaxSeconds = &durations[len(durations)-1]
+	}
+	return stats
+}
+
+// percentileSeconds returns the nearest-rank p-th percentile of sorted, which must not be empty
+func percentileSeconds(sorted []int64, p float64) *int64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	value := sorted[max(rank-1, 0)]
+	return &value
+}
+
+// failureStreaks returns the most consecutive failed runs and the failures since
+// the last success, with when that current streak began. Canceled runs neither
+// extend nor break a streak.
+func failureStreaks(runs []models.DeploymentRun) (longest, current int, startedAt *time.Time) {
+	for _, run := range runs {
+		switch run.Status {
+		case "FAILED":
+			if current == 0 {
+				at := run.FinishedAt
+				startedAt = &at
+			}
+			current++
+			longest = max(longest, current)
+		case "SUCCESS":
+			current, startedAt = 0, nil
+		}
+	}
+	return longest, current, startedAt
+}
+
+// slowestDeploymentRuns returns up to limit runs with a known duration, slowest first
+func slowestDeploymentRuns(runs []models.DeploymentRun, limit int) []models.DeploymentRun {
+	slowest := make([]models.DeploymentRun, 0, limit)
+	for _, run := range runs {
+		if run.DurationSeconds != nil {
+			slowest = append(slowest, run)
+		}
+	}
+	sort.SliceStable(slowest, func(i, j int) bool {
+		return *slowest[i].DurationSeconds > *slowest[j].DurationSeconds
+	})
+	if len(slowest) > limit {
+		slowest = slowest[:limit]
+	}
+	return slowest
+}
+
+// deploymentTrend compares a week with the previous one. Rate and duration
+// changes are left out when either week has nothing to compare.
+func deploymentTrend(previous, current models.DeploymentStats) *models.DeploymentTrend {
+	trend := &models.DeploymentTrend{RunsChange: current.Runs - previous.Runs}
+	if previous.SuccessRate != nil && current.SuccessRate != nil {
+		change := *current.SuccessRate - *previous.SuccessRate
+		trend.SuccessRateChange = &change
+	}
+	if previous.DurationP50Seconds != nil && current.DurationP50Seconds != nil {
+		change := *current.DurationP50Seconds - *previous.DurationP50Seconds
+		trend.DurationP50ChangeSeconds = &change
+	}
+	return trend
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+type mockDeploymentStatsStore struct {
+	runs  []models.DeploymentRun
+	since time.Time
+}
+
+func (m *mockDeploymentStatsStore) GetDeploymentRuns(since time.Time) ([]models.DeploymentRun, error) {
+	m.since = since
+	return m.runs, nil
+}
+
+func TestComputeDeploymentStats(t *testing.T) {
+	// Monday 2024-03-04 through Sunday 2024-03-17: two weeks
+	since := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	now := since.AddDate(0, 0, 14).Add(-time.Second)
+	run := func(id, status string, day int, duration int64) models.DeploymentRun {
+		r := models.DeploymentRun{Project: "web", Environment: "production", DeploymentID: id, Status: status,
+			FinishedAt: since.AddDate(0, 0, day)}
+		if duration > 0 {
+			r.DurationSeconds = &duration
+		}
+		return r
+	}
+
+	runs := []models.DeploymentRun{
+		run("d1", "SUCCESS", 0, 60),
+		run("d2", "FAILED", 1, 30),
+		run("d3", "CANCELED", 2, 0),
+		run("d4", "FAILED", 3, 40),
+		run("d5", "SUCCESS", 4, 100),
+		run("d6", "SUCCESS", 7, 120),
+		run("d7", "SUCCESS", 8, 200),
+		run("d8", "FAILED", 9, 300),
+	}
+
+	groups := computeDeploymentStats(runs, since, now)
+	if len(groups) != 1 {
+		t.Fatalf("expected one group, got %d", len(groups))
+	}
+	group := groups[0]
+
+	summary := group.Summary
+	if summary.Runs != 8 || summary.Succeeded != 4 || summary.Failed != 3 || summary.Canceled != 1 {
+		t.Errorf("unexpected counts: %+v", summary)
+	}
+	if summary.SuccessRate == nil || *summary.SuccessRate != 4.0/7 {
+		t.Errorf("expected success rate 4/7, got %v", summary.SuccessRate)
+	}
+	// Durations 30 40 60 100 120 200 300
+	if summary.DurationSampleCount != 7 || *summary.DurationP50Seconds != 100 ||
+		*summary.DurationP90Seconds != 300 || *summary.DurationMaxSeconds != 300 {
+		t.Errorf("unexpected durations: %+v", summary)
+	}
+
+	// The cancellation between d2 and d4 does not break the streak
+	if group.LongestFailureStreak != 2 || group.CurrentFailureStreak != 1 {
+		t.Errorf("unexpected streaks: longest %d current %d", group.LongestFailureStreak, group.CurrentFailureStreak)
+	}
+	if group.CurrentStreakStartedAt == nil || !group.CurrentStreakStartedAt.Equal(since.AddDate(0, 0, 9)) {
+		t.Errorf("unexpected current streak start: %v", group.CurrentStreakStartedAt)
+	}
+
+	if len(group.Slowest) != slowestDeploymentsLimit || group.Slowest[0].DeploymentID != "d8" || group.Slowest[4].DeploymentID != "d1" {
+		t.Errorf("unexpected slowest runs: %+v", group.Slowest)
+	}
+
+	if len(group.Weeks) != 2 {
+		t.Fatalf("expected two weeks, got %d", len(group.Weeks))
+	}
+	first, second := group.Weeks[0], group.Weeks[1]
+	if first.Trend != nil {
+		t.Errorf("expected no trend for the first week")
+	}
+	if first.Runs != 5 || *first.SuccessRate != 0.5 || *first.DurationP50Seconds != 40 {
+		t.Errorf("unexpected first week: %+v", first.DeploymentStats)
+	}
+	if second.Trend == nil || second.Trend.RunsChange != -2 {
+		t.Fatalf("unexpected second week trend: %+v", second.Trend)
+	}
+	if rate := *second.Trend.SuccessRateChange; rate < 0.166 || rate > 0.167 {
+		t.Errorf("expected success rate change of 1/6, got %f", rate)
+	}
+	if *second.Trend.DurationP50ChangeSeconds != 160 {
+		t.Errorf("expected p50 change of 160s, got %d", *second.Trend.DurationP50ChangeSeconds)
+	}
+}
+
+func TestPercentileSeconds(t *testing.T) {
+	sorted := []int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+	tests := []struct {
+		p    float64
+		want int64
+	}{
+		{50, 50},
+		{90, 90},
+		{95, 100},
+		{1, 10},
+	}
+	for _, tt := range tests {
+		if got := *percentileSeconds(sorted, tt.p); got != tt.want {
+			t.Errorf("percentileSeconds(p%.0f) = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+	if got := *percentileSeconds([]int64{7}, 95); got != 7 {
+		t.Errorf("percentileSeconds of one value = %d, want 7", got)
+	}
+}
+
+func TestDeploymentStatsHandler(t *testing.T) {
+	finished := time.Now().UTC().Add(-time.Hour)
+	store := &mockDeploymentStatsStore{runs: []models.DeploymentRun{
+		{Project: "web", Environment: "production", Status: "SUCCESS", FinishedAt: finished},
+		{Project: "api", Environment: "production", Status: "FAILED", FinishedAt: finished},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/deployments/stats?days=abc&project=api", http.NoBody)
+	rec := httptest.NewRecorder()
+	NewDeploymentStatsHandler(store).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var response DeploymentStatsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Days != defaultDeploymentStatsDays {
+		t.Errorf("expected default days %d, got %d", defaultDeploymentStatsDays, response.Days)
+	}
+	if len(response.Groups) != 1 || response.Groups[0].Project != "api" || response.Groups[0].CurrentFailureStreak != 1 {
+		t.Errorf("expected the api group only, got %+v", response.Groups)
+	}
+}
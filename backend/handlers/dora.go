@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+const (
+	defaultDORADays = 90
+	maxDORADays     = 365
+)
+
+// DORAResponse lists the DORA metrics of each project and environment
+type DORAResponse struct {
+	Days   int                `json:"days"`
+	Groups []models.DORAGroup `json:"groups"`
+}
+
+// DORAHandler serves deployment frequency, lead time for changes, change
+// failure rate and time to restore, derived from deploy, push and merge events
+type DORAHandler struct {
+	repo database.DORAStore
+}
+
+// NewDORAHandler creates a new DORA metrics handler
+func NewDORAHandler(repo database.DORAStore) *DORAHandler {
+	return &DORAHandler{repo: repo}
+}
+
+// ServeHTTP handles GET /api/metrics/dora?days=N&project=P&environment=E
+func (h *DORAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	query := r.URL.Query()
+
+	days := defaultDORADays
+	if daysStr := query.Get("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			days = min(d, maxDORADays)
+		}
+	}
+
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -days)
+	deployments, err := h.repo.GetDeployments(since)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load deployments")
+		http.Error(w, "Failed to load DORA metrics", http.StatusInternalServerError)
+		return
+	}
+
+	project, environment := query.Get("project"), query.Get("environment")
+	filtered := deployments[:0]
+	for _, deployment := range deployments {
+		if (project == "" || deployment.Project == project) && (environment == "" || deployment.Environment == environment) {
+			filtered = append(filtered, deployment)
+		}
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=60")
+	writeJSON(w, log, http.StatusOK, DORAResponse{
+		Days:   days,
+		Groups: computeDORA(filtered, since, now),
+	})
+}
+
+// doraTally accumulates the deploys of one group or week
+type doraTally struct {
+	deployments  int
+	failed       int
+	restores     int
+	leadTimes    []int64
+	restoreTimes []int64
+}
+
+// metrics turns the tally into DORA metrics over a period of the given weeks
+func (t *doraTally) metrics(weeks float64) models.DORAMetrics {
+	metrics := models.DORAMetrics{
+		Deployments:          t.deployments,
+		FailedDeployments:    t.failed,
+		Restores:             t.restores,
+		LeadTimeSeconds:      medianSeconds(t.leadTimes),
+		TimeToRestoreSeconds: medianSeconds(t.restoreTimes),
+	}
+	if weeks > 0 {
+		metrics.DeploysPerWeek = float64(t.deployments) / weeks
+	}
+	if total := t.deployments + t.failed; total > 0 {
+		rate := float64(t.failed) / float64(total)
+		metrics.ChangeFailureRate = &rate
+	}
+	return metrics
+}
+
+// computeDORA groups deploys, oldest first, by project and environment and
+// derives their metrics for the whole window and for every week in it. A failed
+// deploy is restored by the next successful deploy of the same group; the restore
+// counts towards the week the failure happened in.
+func computeDORA(deployments []models.Deployment, since, now time.Time) []models.DORAGroup {
+	type group struct {
+		project, environment string
+		total                doraTally
+		weeks                map[string]*doraTally
+		failedAt             *time.Time
+		failedWeek           string
+	}
+	groups := make(map[string]*group)
+	var keys []string
+
+	for _, deployment := range deployments {
+		key := deployment.Project + "\x00" + deployment.Environment
+		g, ok := groups[key]
+		if !ok {
+			g = &group{project: deployment.Project, environment: deployment.Environment, weeks: make(map[string]*doraTally)}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		week := weekStart(deployment.FinishedAt).Format("2006-01-02")
+		tally, ok := g.weeks[week]
+		if !ok {
+			tally = &doraTally{}
+			g.weeks[week] = tally
+		}
+
+		if deployment.Status != "SUCCESS" {
+			g.total.failed++
+			tally.failed++
+			if g.failedAt == nil {
+				at := deployment.FinishedAt
+				g.failedAt, g.failedWeek = &at, week
+			}
+			continue
+		}
+
+		g.total.deployments++
+		tally.deployments++
+		if deployment.CommittedAt != nil {
+			leadTime := *secondsBetween(*deployment.CommittedAt, deployment.FinishedAt)
+			g.total.leadTimes = append(g.total.leadTimes, leadTime)
+			tally.leadTimes = append(tally.leadTimes, leadTime)
+		}
+		if g.failedAt != nil {
+			restoreTime := *secondsBetween(*g.failedAt, deployment.FinishedAt)
+			g.total.restores++
+			g.total.restoreTimes = append(g.total.restoreTimes, restoreTime)
+			g.weeks[g.failedWeek].restores++
+			g.weeks[g.failedWeek].restoreTimes = append(g.weeks[g.failedWeek].restoreTimes, restoreTime)
+			g.failedAt = nil
+		}
+	}
+
+	var weekStarts []string
+	for week := weekStart(since); !week.After(now); week = week.AddDate(0, 0, 7) {
+		weekStarts = append(weekStarts, week.Format("2006-01-02"))
+	}
+	windowWeeks := now.Sub(since).Hours() / (24 * 7)
+
+	sort.Strings(keys)
+	result := make([]models.DORAGroup, 0, len(keys))
+	for _, key := range keys {
+		g := groups[key]
+		dora := models.DORAGroup{
+			Project:     g.project,
+			Environment: g.environment,
+			Summary:     g.total.metrics(windowWeeks),
+			Weeks:       make([]models.DORAWeek, 0, len(weekStarts)),
+		}
+		for _, week := range weekStarts {
+			tally, ok := g.weeks[week]
+			if !ok {
+				tally = &doraTally{}
+			}
+			dora.Weeks = append(dora.Weeks, models.DORAWeek{WeekStart: week, DORAMetrics: tally.metrics(1)})
+		}
+		result = append(result, dora)
+	}
+	return result
+}
+
+// weekStart returns the Monday 00:00 UTC that starts the week of t
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// medianSeconds returns the median of values, or nil when there are none
+func medianSeconds(values []int64) *int64 {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]int64(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+	return &median
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+type mockDORAStore struct {
+	deployments []models.Deployment
+	since       time.Time
+}
+
+func (m *mockDORAStore) GetDeployments(since time.Time) ([]models.Deployment, error) {
+	m.since = since
+	return m.deployments, nil
+}
+
+func TestComputeDORA(t *testing.T) {
+	// Monday 2024-03-04 through Sunday 2024-03-17: two weeks
+	since := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	now := since.AddDate(0, 0, 14).Add(-time.Second)
+	at := func(day, hour int) time.Time { return since.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+	committed := func(day, hour int) *time.Time { t := at(day, hour); return &t }
+
+	deployments := []models.Deployment{
+		{Project: "web", Environment: "production", Status: "SUCCESS", FinishedAt: at(0, 10), CommittedAt: committed(0, 9)},
+		{Project: "web", Environment: "production", Status: "FAILED", FinishedAt: at(6, 20)},
+		{Project: "web", Environment: "production", Status: "FAILED", FinishedAt: at(6, 21)},
+		// Restores the failure of the first week
+		{Project: "web", Environment: "production", Status: "SUCCESS", FinishedAt: at(7, 2), CommittedAt: committed(7, 0)},
+		{Project: "web", Environment: "production", Status: "SUCCESS", FinishedAt: at(8, 0)},
+		{Project: "api", Environment: "production", Status: "SUCCESS", FinishedAt: at(1, 0), CommittedAt: committed(2, 0)},
+	}
+
+	groups := computeDORA(deployments, since, now)
+	if len(groups) != 2 || groups[0].Project != "api" || groups[1].Project != "web" {
+		t.Fatalf("expected api and web groups in order, got %+v", groups)
+	}
+
+	api := groups[0].Summary
+	if api.LeadTimeSeconds == nil || *api.LeadTimeSeconds != 0 {
+		t.Errorf("expected a commit recorded after its deploy to clamp to 0, got %v", api.LeadTimeSeconds)
+	}
+
+	web := groups[1]
+	if web.Summary.Deployments != 3 || web.Summary.FailedDeployments != 2 {
+		t.Errorf("unexpected counts: %+v", web.Summary)
+	}
+	if web.Summary.DeploysPerWeek < 1.49 || web.Summary.DeploysPerWeek > 1.51 {
+		t.Errorf("expected 1.5 deploys per week, got %f", web.Summary.DeploysPerWeek)
+	}
+	if web.Summary.ChangeFailureRate == nil || *web.Summary.ChangeFailureRate != 0.4 {
+		t.Errorf("expected change failure rate 0.4, got %v", web.Summary.ChangeFailureRate)
+	}
+	if web.Summary.LeadTimeSeconds == nil || *web.Summary.LeadTimeSeconds != 5400 {
+		t.Errorf("expected median lead time 5400s, got %v", web.Summary.LeadTimeSeconds)
+	}
+	if web.Summary.Restores != 1 || web.Summary.TimeToRestoreSeconds == nil || *web.Summary.TimeToRestoreSeconds != 6*3600 {
+		t.Errorf("expected one 6h restore, got %d %v", web.Summary.Restores, web.Summary.TimeToRestoreSeconds)
+	}
+
+	if len(web.Weeks) != 2 || web.Weeks[0].WeekStart != "2024-03-04" || web.Weeks[1].WeekStart != "2024-03-11" {
+		t.Fatalf("unexpected weeks: %+v", web.Weeks)
+	}
+	first, second := web.Weeks[0], web.Weeks[1]
+	if first.Deployments != 1 || first.FailedDeployments != 2 || first.Restores != 1 {
+		t.Errorf("unexpected first week: %+v", first.DORAMetrics)
+	}
+	if second.Deployments != 2 || second.FailedDeployments != 0 || second.Restores != 0 || second.DeploysPerWeek != 2 {
+		t.Errorf("unexpected second week: %+v", second.DORAMetrics)
+	}
+	if second.ChangeFailureRate == nil || *second.ChangeFailureRate != 0 {
+		t.Errorf("expected change failure rate 0 in the second week, got %v", second.ChangeFailureRate)
+	}
+
+	if len(groups[0].Weeks) != 2 || groups[0].Weeks[1].ChangeFailureRate != nil {
+		t.Errorf("expected an empty second week for api, got %+v", groups[0].Weeks)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), "2024-03-04"},
+		{time.Date(2024, 3, 10, 23, 59, 0, 0, time.UTC), "2024-03-04"},
+		{time.Date(2024, 3, 11, 1, 0, 0, 0, time.FixedZone("CET", 3600)), "2024-03-11"},
+		{time.Date(2024, 3, 11, 0, 30, 0, 0, time.FixedZone("CET", 3600)), "2024-03-04"},
+	}
+	for _, tt := range tests {
+		if got := weekStart(tt.at).Format("2006-01-02"); got != tt.want {
+			t.Errorf("weekStart(%v) = %s, want %s", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestDORAHandler_Filters(t *testing.T) {
+	finished := time.Now().UTC().Add(-time.Hour)
+	store := &mockDORAStore{deployments: []models.Deployment{
+		{Project: "web", Environment: "production", Status: "SUCCESS", FinishedAt: finished},
+		{Project: "web", Environment: "preview", Status: "SUCCESS", FinishedAt: finished},
+		{Project: "api", Environment: "production", Status: "FAILED", FinishedAt: finished},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/metrics/dora?days=1000&environment=production", http.NoBody)
+	rec := httptest.NewRecorder()
+	NewDORAHandler(store).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var response DORAResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Days != maxDORADays {
+		t.Errorf("expected days to be capped at %d, got %d", maxDORADays, response.Days)
+	}
+	if age := time.Since(store.since); age < 364*24*time.Hour || age > 366*24*time.Hour {
+		t.Errorf("expected a one-year window, got since %v", store.since)
+	}
+	if len(response.Groups) != 2 || response.Groups[0].Project != "api" || response.Groups[1].Environment != "production" {
+		t.Errorf("expected production groups only, got %+v", response.Groups)
+	}
+}
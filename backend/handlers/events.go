@@ -85,6 +85,7 @@ func (h *EventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Int("limit", filter.Limit).
 		Int("offset", filter.Offset).
 		Str("event_type", filter.EventType).
+		Str("category", filter.Category).
 		Msg("retrieving events")
 
 	events, total, err := h.repo.GetEventsWithFilters(filter)
@@ -154,6 +155,10 @@ func parseEventsFilter(r *http.Request) models.EventsFilter {
 		filter.EventType = eventType
 	}
 
+	if category := r.URL.Query().Get("category"); category != "" {
+		filter.Category = category
+	}
+
 	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
 		if since, err := time.Parse("2006-01-02", sinceStr); err == nil {
 			filter.Since = since
@@ -225,8 +225,12 @@ type mockStoreWithTotal struct {
 	total  int
 }
 
-func (m *mockStoreWithTotal) InsertEvent(_ *models.DashboardEvent) error {
-	return nil
+func (m *mockStoreWithTotal) InsertEvents(events []models.DashboardEvent) ([]bool, error) {
+	created := make([]bool, len(events))
+	for i := range created {
+		created[i] = true
+	}
+	return created, nil
 }
 
 func (m *mockStoreWithTotal) GetRecentEvents(_ int) ([]models.DashboardEvent, error) {
@@ -261,3 +265,15 @@ func (m *mockStoreWithTotal) GetMonthlyStats(year, month int) (models.MonthlySta
 		CategoryBreakdown: make(map[string]int),
 	}, nil
 }
+
+func TestParseEventsFilter_Category(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/api/events?category=deployments&type=vercel.deploy", http.NoBody)
+
+	filter := parseEventsFilter(req)
+	if filter.Category != "deployments" {
+		t.Errorf("expected category deployments, got %q", filter.Category)
+	}
+	if filter.EventType != "vercel.deploy" {
+		t.Errorf("expected type vercel.deploy, got %q", filter.EventType)
+	}
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+const (
+	defaultIncidentDays = 30
+	maxIncidentDays     = 365
+)
+
+// IncidentsResponse lists incidents with their lifecycle timings
+type IncidentsResponse struct {
+	Days      int                    `json:"days"`
+	Summary   models.IncidentSummary `json:"summary"`
+	Incidents []models.Incident      `json:"incidents"`
+}
+
+// IncidentsHandler serves incidents paired from their incident.* events
+type IncidentsHandler struct {
+	repo database.IncidentStore
+}
+
+// NewIncidentsHandler creates a new incidents handler
+func NewIncidentsHandler(repo database.IncidentStore) *IncidentsHandler {
+	return &IncidentsHandler{repo: repo}
+}
+
+// ServeHTTP handles GET /api/incidents?days=N
+func (h *IncidentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	days := defaultIncidentDays
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 {
+			days = min(d, maxIncidentDays)
+		}
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	events, err := h.repo.GetIncidentEvents(since)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load incident events")
+		http.Error(w, "Failed to load incidents", http.StatusInternalServerError)
+		return
+	}
+
+	incidents := pairIncidents(events)
+	w.Header().Set("Cache-Control", "private, max-age=30")
+	writeJSON(w, log, http.StatusOK, IncidentsResponse{
+		Days:      days,
+		Summary:   summarizeIncidents(incidents),
+		Incidents: incidents,
+	})
+}
+
+// openIncident tracks an incident while its events are folded
+type openIncident struct {
+	incident    *models.Incident
+	openSince   time.Time // Start of the current open interval
+	openSeconds int64     // Time spent open in earlier, resolved intervals
+}
+
+// pairIncidents folds the lifecycle events of each incident, oldest first, into
+// one Incident. The first acknowledgement after the trigger gives the time to
+// acknowledge. A reopen starts a new open interval, and the time to resolve is
+// the time spent open across all intervals, so the gaps between a resolution and
+// a reopen are not counted. Events of an incident without a trigger are dropped.
+// Incidents are returned newest first.
+func pairIncidents(events []models.IncidentEvent) []models.Incident {
+	byID := make(map[string]*openIncident)
+	order := make([]string, 0)
+
+	for _, event := range events {
+		state, ok := byID[event.IncidentID]
+		if !ok {
+			if event.Action != "triggered" {
+				continue
+			}
+			state = &openIncident{
+				incident: &models.Incident{
+					ID:          event.IncidentID,
+					Number:      event.Number,
+					Title:       event.Title,
+					Service:     event.Service,
+					Urgency:     event.Urgency,
+					URL:         event.URL,
+					Status:      "triggered",
+					TriggeredAt: event.CreatedAt,
+				},
+				openSince: event.CreatedAt,
+			}
+			byID[event.IncidentID] = state
+			order = append(order, event.IncidentID)
+		}
+		incident := state.incident
+		if event.Assignee != "" {
+			incident.Assignee = event.Assignee
+		}
+
+		switch event.Action {
+		case "acknowledged":
+			if incident.ResolvedAt == nil {
+				if incident.AcknowledgedAt == nil {
+					at := event.CreatedAt
+					incident.AcknowledgedAt = &at
+					incident.TTASeconds = secondsBetween(incident.TriggeredAt, at)
+				}
+				incident.Status = "acknowledged"
+			}
+		case "escalated":
+			incident.Escalations++
+		case "resolved":
+			if incident.ResolvedAt == nil {
+				at := event.CreatedAt
+				state.openSeconds += *secondsBetween(state.openSince, at)
+				ttr := state.openSeconds
+				incident.ResolvedAt = &at
+				incident.TTRSeconds = &ttr
+				incident.Status = "resolved"
+			}
+		case "reopened":
+			if incident.ResolvedAt != nil {
+				state.openSince = event.CreatedAt
+				incident.ResolvedAt = nil
+				incident.TTRSeconds = nil
+				incident.Status = "triggered"
+			}
+		}
+	}
+
+	incidents := make([]models.Incident, 0, len(order))
+	for _, id := range order {
+		incidents = append(incidents, *byID[id].incident)
+	}
+	sort.SliceStable(incidents, func(i, j int) bool {
+		return incidents[i].TriggeredAt.After(incidents[j].TriggeredAt)
+	})
+	return incidents
+}
+
+// summarizeIncidents counts incidents by status and averages their timings
+func summarizeIncidents(incidents []models.Incident) models.IncidentSummary {
+	summary := models.IncidentSummary{Total: len(incidents)}
+	var ttaTotal, ttrTotal, ttaCount, ttrCount int64
+
+	for _, incident := range incidents {
+		switch incident.Status {
+		case "resolved":
+			summary.Resolved++
+		case "acknowledged":
+			summary.Acknowledged++
+			summary.Open++
+		default:
+			summary.Open++
+		}
+		if incident.Urgency == "high" {
+			summary.HighUrgency++
+		}
+		if incident.TTASeconds != nil {
+			ttaTotal += *incident.TTASeconds
+			ttaCount++
+		}
+		if incident.TTRSeconds != nil {
+			ttrTotal += *incident.TTRSeconds
+			ttrCount++
+		}
+	}
+
+	if ttaCount > 0 {
+		mean := ttaTotal / ttaCount
+		summary.MeanTTA = &mean
+	}
+	if ttrCount > 0 {
+		mean := ttrTotal / ttrCount
+		summary.MeanTTR = &mean
+	}
+	return summary
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+type mockIncidentStore struct {
+	events []models.IncidentEvent
+	since  time.Time
+	err    error
+}
+
+func (m *mockIncidentStore) GetIncidentEvents(since time.Time) ([]models.IncidentEvent, error) {
+	m.since = since
+	return m.events, m.err
+}
+
+func TestPairIncidents(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	events := []models.IncidentEvent{
+		// Acknowledgement of an incident triggered before the window is dropped
+		{IncidentID: "OLD", Action: "acknowledged", CreatedAt: start},
+		{IncidentID: "A", Action: "triggered", Number: 1, Title: "Checkout down", Service: "checkout", Urgency: "high", CreatedAt: start},
+		{IncidentID: "A", Action: "escalated", CreatedAt: start.Add(5 * time.Minute)},
+		{IncidentID: "A", Action: "acknowledged", Assignee: "Ada", CreatedAt: start.Add(6 * time.Minute)},
+		{IncidentID: "B", Action: "triggered", Number: 2, Title: "Slow search", Urgency: "low", CreatedAt: start.Add(10 * time.Minute)},
+		{IncidentID: "B", Action: "acknowledged", CreatedAt: start.Add(12 * time.Minute)},
+		{IncidentID: "A", Action: "resolved", CreatedAt: start.Add(30 * time.Minute)},
+	}
+
+	incidents := pairIncidents(events)
+	if len(incidents) != 2 {
+		t.Fatalf("expected 2 incidents, got %d", len(incidents))
+	}
+
+	// Newest first
+	b, a := incidents[0], incidents[1]
+	if a.ID != "A" || b.ID != "B" {
+		t.Fatalf("unexpected order: %s, %s", incidents[0].ID, incidents[1].ID)
+	}
+	if a.Status != "resolved" || a.Escalations != 1 || a.Assignee != "Ada" {
+		t.Errorf("unexpected incident A: %+v", a)
+	}
+	if a.TTASeconds == nil || *a.TTASeconds != 360 || a.TTRSeconds == nil || *a.TTRSeconds != 1800 {
+		t.Errorf("unexpected timings for A: tta=%v ttr=%v", a.TTASeconds, a.TTRSeconds)
+	}
+	if b.Status != "acknowledged" || b.TTRSeconds != nil || b.TTASeconds == nil || *b.TTASeconds != 120 {
+		t.Errorf("unexpected incident B: %+v", b)
+	}
+
+	summary := summarizeIncidents(incidents)
+	if summary.Total != 2 || summary.Open != 1 || summary.Acknowledged != 1 || summary.Resolved != 1 || summary.HighUrgency != 1 {
+		t.Errorf("unexpected summary counts: %+v", summary)
+	}
+	if summary.MeanTTA == nil || *summary.MeanTTA != 240 || summary.MeanTTR == nil || *summary.MeanTTR != 1800 {
+		t.Errorf("unexpected summary timings: tta=%v ttr=%v", summary.MeanTTA, summary.MeanTTR)
+	}
+}
+
+func TestPairIncidents_Reopened(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	events := []models.IncidentEvent{
+		{IncidentID: "A", Action: "triggered", CreatedAt: start},
+		{IncidentID: "A", Action: "acknowledged", CreatedAt: start.Add(2 * time.Minute)},
+		{IncidentID: "A", Action: "resolved", CreatedAt: start.Add(10 * time.Minute)},
+		{IncidentID: "A", Action: "reopened", CreatedAt: start.Add(60 * time.Minute)},
+		{IncidentID: "B", Action: "triggered", CreatedAt: start},
+		{IncidentID: "B", Action: "resolved", CreatedAt: start.Add(5 * time.Minute)},
+		{IncidentID: "B", Action: "reopened", CreatedAt: start.Add(20 * time.Minute)},
+		{IncidentID: "B", Action: "acknowledged", CreatedAt: start.Add(21 * time.Minute)},
+		{IncidentID: "B", Action: "resolved", CreatedAt: start.Add(25 * time.Minute)},
+	}
+
+	byID := make(map[string]models.Incident)
+	for _, incident := range pairIncidents(events) {
+		byID[incident.ID] = incident
+	}
+
+	// Reopened and still open: no resolution, the first acknowledgement is kept
+	a := byID["A"]
+	if a.Status != "triggered" || a.ResolvedAt != nil || a.TTRSeconds != nil {
+		t.Errorf("expected A open again, got %+v", a)
+	}
+	if a.TTASeconds == nil || *a.TTASeconds != 120 {
+		t.Errorf("expected A tta 120, got %v", a.TTASeconds)
+	}
+
+	// Resolved again: open 5m, then 5m after the reopen; the 15m gap is not counted
+	b := byID["B"]
+	if b.Status != "resolved" || b.ResolvedAt == nil || !b.ResolvedAt.Equal(start.Add(25*time.Minute)) {
+		t.Errorf("expected B resolved at the second resolution, got %+v", b)
+	}
+	if b.TTRSeconds == nil || *b.TTRSeconds != 600 {
+		t.Errorf("expected B ttr 600, got %v", b.TTRSeconds)
+	}
+
+	summary := summarizeIncidents([]models.Incident{a, b})
+	if summary.Open != 1 || summary.Resolved != 1 {
+		t.Errorf("unexpected summary counts: %+v", summary)
+	}
+}
+
+func TestIncidentsHandler(t *testing.T) {
+	store := &mockIncidentStore{events: []models.IncidentEvent{
+		{IncidentID: "A", Action: "triggered", Number: 1, CreatedAt: time.Now().Add(-time.Hour)},
+	}}
+	handler := NewIncidentsHandler(store)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/incidents?days=7", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	var response IncidentsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Days != 7 || len(response.Incidents) != 1 || response.Summary.Open != 1 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	if window := time.Since(store.since); window < 7*24*time.Hour || window > 7*24*time.Hour+time.Minute {
+		t.Errorf("expected a 7 day window, got %v", window)
+	}
+}
+
+func TestIncidentsHandler_StoreError(t *testing.T) {
+	handler := NewIncidentsHandler(&mockIncidentStore{err: errors.New("db down")})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/incidents?days=abc", http.NoBody)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", rec.Code)
+	}
+}
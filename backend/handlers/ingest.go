@@ -0,0 +1,389 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/ingest"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+	"heimdall-backend/transformers"
+
+	"github.com/google/uuid"
+)
+
+// statusNonRetryable is the status QStash treats as a final failure when it is
+// paired with the Upstash-NonRetryable-Error header; any other non-2xx is retried
+const statusNonRetryable = 489
+
+// transientRetryAfter is the Retry-After hint sent when the database is unavailable
+const transientRetryAfter = "30"
+
+// redactedHeaders are never copied into the dead-letter store
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// ingestRequest is a single delivery ready to be transformed and stored
+type ingestRequest struct {
+	Headers    http.Header
+	Source     string // Namespace for DeliveryID ("github", "qstash", ...)
+	DeliveryID string // Provider delivery ID, used when the transformer sets no ExternalID
+	EventType  string
+	EventData  json.RawMessage
+	Timestamp  time.Time
+}
+
+// IngestResponse is returned for every accepted delivery. ID is the first event's ID
+// and IDs lists every event the delivery produced. Duplicate is set when none of them
+// were new. Queued deliveries are deduplicated when flushed, so Duplicate is always
+// false for them. A delivery that produces no events is acknowledged with Ignored.
+type IngestResponse struct {
+	ID        string   `json:"id,omitempty"`
+	IDs       []string `json:"ids,omitempty"`
+	Duplicate bool     `json:"duplicate"`
+	Queued    bool     `json:"queued,omitempty"`
+	Ignored   bool     `json:"ignored,omitempty"`
+}
+
+// newIngestResponse summarizes the events produced by one delivery
+func newIngestResponse(events []models.DashboardEvent, created []bool) IngestResponse {
+	resp := IngestResponse{
+		IDs:       make([]string, len(events)),
+		Duplicate: len(events) > 0,
+	}
+	for i, event := range events {
+		resp.IDs[i] = event.ID
+		if created == nil || created[i] {
+			resp.Duplicate = false
+		}
+	}
+	if len(events) > 0 {
+		resp.ID = events[0].ID
+	}
+	return resp
+}
+
+// EventQueue accepts transformed events for asynchronous storage. The events of
+// one delivery are enqueued together and stored atomically.
+type EventQueue interface {
+	Enqueue(events []models.DashboardEvent) error
+}
+
+// ingestError describes a failed ingest and how the sender should react to it
+type ingestError struct {
+	err       error
+	message   string // Client-facing message
+	status    int
+	permanent bool // Retrying the same delivery cannot succeed
+}
+
+func (e *ingestError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ingestError) Unwrap() error {
+	return e.err
+}
+
+// Ingester transforms event payloads and persists the resulting dashboard events.
+// It is shared by the QStash envelope handler, the native provider handlers and
+// dead-letter replay.
+type Ingester struct {
+	repo        database.EventStore
+	registry    *transformers.Registry
+	deadLetters database.DeadLetterStore
+	queue       EventQueue
+}
+
+// NewIngester creates an ingester. Rejected deliveries are only persisted when
+// deadLetters is non-nil.
+func NewIngester(repo database.EventStore, registry *transformers.Registry, deadLetters database.DeadLetterStore) *Ingester {
+	return &Ingester{
+		repo:        repo,
+		registry:    registry,
+		deadLetters: deadLetters,
+	}
+}
+
+// SetQueue makes webhook deliveries asynchronous: events are acknowledged once the
+// queue accepts them instead of after the INSERT. Dead-letter replay stays synchronous.
+func (in *Ingester) SetQueue(queue EventQueue) {
+	in.queue = queue
+}
+
+// process transforms and stores a delivery, reporting which events were new rows
+func (in *Ingester) process(req ingestRequest) ([]models.DashboardEvent, []bool, *ingestError) {
+	events, ingestErr := in.transform(req)
+	if ingestErr != nil {
+		return nil, nil, ingestErr
+	}
+	if len(events) == 0 {
+		return nil, nil, nil
+	}
+
+	// Insert all events of the delivery atomically
+	created, err := in.repo.InsertEvents(events)
+	if err != nil {
+		if database.IsTransientError(err) {
+			return events, nil, &ingestError{
+				err:     err,
+				message: "Database temporarily unavailable",
+				status:  http.StatusServiceUnavailable,
+			}
+		}
+		return events, nil, &ingestError{
+			err:     err,
+			message: "Failed to save event",
+			status:  http.StatusInternalServerError,
+		}
+	}
+
+	return events, created, nil
+}
+
+// enqueue transforms a delivery and hands its events to the queue. Event IDs are
+// assigned here because the rows do not exist yet when the response is written.
+func (in *Ingester) enqueue(req ingestRequest) ([]models.DashboardEvent, *ingestError) {
+	events, ingestErr := in.transform(req)
+	if ingestErr != nil {
+		return nil, ingestErr
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	for i := range events {
+		events[i].ID = uuid.New().String()
+	}
+	if err := in.queue.Enqueue(events); err != nil {
+		message := "Ingest queue unavailable"
+		if errors.Is(err, ingest.ErrQueueFull) {
+			message = "Ingest queue full"
+		}
+		return events, &ingestError{
+			err:     err,
+			message: message,
+			status:  http.StatusServiceUnavailable,
+		}
+	}
+
+	return events, nil
+}
+
+// transform runs the registry and attaches delivery identity and the raw payload
+func (in *Ingester) transform(req ingestRequest) ([]models.DashboardEvent, *ingestError) {
+	// Check if we have a transformer for this event type
+	if !in.registry.HasTransformer(req.EventType) {
+		return nil, &ingestError{
+			err:       fmt.Errorf("unknown event type: %s", req.EventType),
+			message:   "Unknown event type",
+			status:    http.StatusBadRequest,
+			permanent: true,
+		}
+	}
+
+	// Transform the event - a payload the transformer cannot read will never succeed on retry
+	events, err := in.registry.Transform(req.EventType, req.EventData, req.Timestamp)
+	if err != nil {
+		return nil, &ingestError{
+			err:       err,
+			message:   "Failed to process event",
+			status:    http.StatusUnprocessableEntity,
+			permanent: true,
+		}
+	}
+
+	// Archive the transformer input once; every event of the delivery references it
+	payload := &models.RawPayload{
+		EventType:  req.EventType,
+		Data:       req.EventData,
+		ReceivedAt: req.Timestamp,
+	}
+
+	for i := range events {
+		// Transformers that know a stable identity (e.g. deployment ID + state) set their own.
+		// Otherwise the delivery ID is used, suffixed with the position when a delivery
+		// produces several events.
+		if events[i].ExternalID == "" && req.DeliveryID != "" {
+			events[i].Source = req.Source
+			events[i].ExternalID = req.DeliveryID
+			if len(events) > 1 {
+				events[i].ExternalID = fmt.Sprintf("%s:%d", req.DeliveryID, i)
+			}
+		}
+		events[i].Payload = payload
+	}
+
+	return events, nil
+}
+
+// ingest processes the delivery and writes the HTTP response
+func (in *Ingester) ingest(w http.ResponseWriter, r *http.Request, log *logger.Logger, req ingestRequest) {
+	if in.queue != nil {
+		in.ingestAsync(w, r, log, req)
+		return
+	}
+
+	events, created, ingestErr := in.process(req)
+	if ingestErr != nil {
+		log.Error().
+			Err(ingestErr).
+			Str("event_type", req.EventType).
+			Bool("permanent", ingestErr.permanent).
+			Msg("failed to ingest event")
+		if ingestErr.permanent {
+			in.reject(w, r, log, req, ingestErr.status, ingestErr.message, ingestErr)
+			return
+		}
+		if ingestErr.status == http.StatusServiceUnavailable {
+			failTransient(w, ingestErr.message)
+			return
+		}
+		http.Error(w, ingestErr.message, ingestErr.status)
+		return
+	}
+
+	if len(events) == 0 {
+		acknowledgeIgnored(w, log, req)
+		return
+	}
+
+	for i, event := range events {
+		if created[i] {
+			log.Info().
+				Str("event_type", event.EventType).
+				Str("title", event.Title).
+				Str("event_id", event.ID).
+				Msg("processed event successfully")
+		} else {
+			log.Info().
+				Str("event_type", event.EventType).
+				Str("event_id", event.ID).
+				Str("external_id", event.ExternalID).
+				Msg("duplicate delivery acknowledged")
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	//nolint:errcheck // WriteHeader already sent, can't change response on encode failure
+	_ = json.NewEncoder(w).Encode(newIngestResponse(events, created))
+}
+
+// ingestAsync queues the delivery and responds 202 once the queue has accepted it
+func (in *Ingester) ingestAsync(w http.ResponseWriter, r *http.Request, log *logger.Logger, req ingestRequest) {
+	events, ingestErr := in.enqueue(req)
+	if ingestErr != nil {
+		log.Error().
+			Err(ingestErr).
+			Str("event_type", req.EventType).
+			Bool("permanent", ingestErr.permanent).
+			Msg("failed to queue event")
+		if ingestErr.permanent {
+			in.reject(w, r, log, req, ingestErr.status, ingestErr.message, ingestErr)
+			return
+		}
+		failTransient(w, ingestErr.message)
+		return
+	}
+
+	if len(events) == 0 {
+		acknowledgeIgnored(w, log, req)
+		return
+	}
+
+	for _, event := range events {
+		log.Info().
+			Str("event_type", event.EventType).
+			Str("title", event.Title).
+			Str("event_id", event.ID).
+			Msg("queued event")
+	}
+
+	resp := newIngestResponse(events, nil)
+	resp.Queued = true
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	//nolint:errcheck // WriteHeader already sent, can't change response on encode failure
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// acknowledgeIgnored answers a valid delivery that produced no events
+func acknowledgeIgnored(w http.ResponseWriter, log *logger.Logger, req ingestRequest) {
+	log.Info().
+		Str("event_type", req.EventType).
+		Msg("delivery produced no events")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	//nolint:errcheck // WriteHeader already sent, can't change response on encode failure
+	_ = json.NewEncoder(w).Encode(IngestResponse{Ignored: true})
+}
+
+// reject dead-letters a delivery that can never succeed and responds accordingly.
+// If the dead letter cannot be stored the delivery is failed as transient instead,
+// so the sender keeps it until it can be persisted.
+func (in *Ingester) reject(w http.ResponseWriter, r *http.Request, log *logger.Logger, req ingestRequest, status int, message string, cause error) {
+	if in.deadLetters != nil {
+		dl := newDeadLetter(req, cause)
+		if err := in.deadLetters.InsertDeadLetter(&dl); err != nil {
+			log.Error().Err(err).Msg("failed to store dead letter")
+			failTransient(w, "Failed to store rejected delivery")
+			return
+		}
+		log.Warn().
+			Str("dead_letter_id", dl.ID).
+			Int("attempts", dl.Attempts).
+			Msg("delivery moved to dead-letter store")
+	}
+
+	rejectPermanent(w, r, status, message)
+}
+
+// newDeadLetter captures a rejected delivery for later inspection and replay
+func newDeadLetter(req ingestRequest, cause error) models.DeadLetter {
+	headers := make(map[string]string, len(req.Headers))
+	for name, values := range req.Headers {
+		if len(values) > 0 && !redactedHeaders[name] {
+			headers[name] = values[0]
+		}
+	}
+
+	timestamp := req.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	return models.DeadLetter{
+		Source:     req.Source,
+		DeliveryID: req.DeliveryID,
+		EventType:  req.EventType,
+		Payload:    string(req.EventData),
+		Headers:    headers,
+		Error:      cause.Error(),
+		ReceivedAt: timestamp,
+	}
+}
+
+// rejectPermanent responds to a delivery that can never succeed. QStash deliveries
+// get the non-retryable marker so they are not redelivered; other callers get status.
+func rejectPermanent(w http.ResponseWriter, r *http.Request, status int, message string) {
+	if r.Header.Get("Upstash-Message-Id") != "" {
+		w.Header().Set("Upstash-NonRetryable-Error", "true")
+		status = statusNonRetryable
+	}
+	http.Error(w, message, status)
+}
+
+// failTransient responds to a delivery that should be retried later
+func failTransient(w http.ResponseWriter, message string) {
+	w.Header().Set("Retry-After", transientRetryAfter)
+	http.Error(w, message, http.StatusServiceUnavailable)
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http"
+
+	"heimdall-backend/ingest"
+	"heimdall-backend/logger"
+)
+
+// IngestStatsProvider reports the state of the asynchronous ingest pipeline
+type IngestStatsProvider interface {
+	Stats() ingest.Stats
+}
+
+// IngestMetricsHandler exposes queue depth and flush counters
+type IngestMetricsHandler struct {
+	pipeline IngestStatsProvider
+}
+
+// NewIngestMetricsHandler creates a new ingest metrics handler
+func NewIngestMetricsHandler(pipeline IngestStatsProvider) *IngestMetricsHandler {
+	return &IngestMetricsHandler{pipeline: pipeline}
+}
+
+// ServeHTTP handles GET /api/metrics/ingest
+func (h *IngestMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+	writeJSON(w, log, http.StatusOK, h.pipeline.Stats())
+}
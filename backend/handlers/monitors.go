@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"heimdall-backend/logger"
+	"heimdall-backend/monitor"
+)
+
+// MonitorStatusProvider reports the state and uptime of every monitored target
+type MonitorStatusProvider interface {
+	Status() ([]monitor.TargetStatus, error)
+}
+
+// MonitorsResponse wraps the monitor list
+type MonitorsResponse struct {
+	Monitors []monitor.TargetStatus `json:"monitors"`
+}
+
+// MonitorsHandler serves the uptime monitor status
+type MonitorsHandler struct {
+	monitors MonitorStatusProvider
+}
+
+// NewMonitorsHandler creates a new monitors handler
+func NewMonitorsHandler(monitors MonitorStatusProvider) *MonitorsHandler {
+	return &MonitorsHandler{monitors: monitors}
+}
+
+// ServeHTTP handles GET /api/monitors
+func (h *MonitorsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	statuses, err := h.monitors.Status()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load monitor status")
+		http.Error(w, "Failed to load monitors", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, log, http.StatusOK, MonitorsResponse{Monitors: statuses})
+}
@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+
+	"github.com/gorilla/mux"
+)
+
+// minSHAPrefix is the shortest commit SHA prefix a pipeline can be looked up by
+const minSHAPrefix = 7
+
+// pipelineStageOrder ranks the stages of a change on its way to production
+var pipelineStageOrder = map[string]int{
+	"push":     0,
+	"merge":    1,
+	"ci":       2,
+	"artifact": 3,
+	"deploy":   4,
+	"release":  5,
+}
+
+// deployEventTypes are the events that record a deploy
+var deployEventTypes = map[string]bool{
+	"vercel.deploy":  true,
+	"railway.deploy": true,
+	"netlify.deploy": true,
+	"render.deploy":  true,
+	"github.deploy":  true,
+}
+
+// finishedDeployStatuses end a deploy; later states (sleeping, removed) are not part of the pipeline
+var finishedDeployStatuses = map[string]bool{
+	"SUCCESS":  true,
+	"PROMOTED": true,
+	"FAILED":   true,
+	"CANCELED": true,
+	"CRASHED":  true,
+}
+
+// PipelinesHandler traces a commit through push, merge, CI, deploys and release
+type PipelinesHandler struct {
+	repo database.PipelineStore
+}
+
+// NewPipelinesHandler creates a new pipelines handler
+func NewPipelinesHandler(repo database.PipelineStore) *PipelinesHandler {
+	return &PipelinesHandler{repo: repo}
+}
+
+// ServeHTTP handles GET /api/pipelines/{sha}. The SHA may be abbreviated to
+// at least seven characters as long as it is unambiguous.
+func (h *PipelinesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	prefix := strings.ToLower(mux.Vars(r)["sha"])
+	if !isSHAPrefix(prefix) {
+		http.Error(w, "Invalid commit SHA: expected 7 to 40 hex characters", http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.repo.GetCommitEvents(prefix)
+	if err != nil {
+		log.Error().Err(err).Str("sha", prefix).Msg("failed to load commit events")
+		http.Error(w, "Failed to load pipeline", http.StatusInternalServerError)
+		return
+	}
+
+	shas := matchingSHAs(prefix, events)
+	if len(shas) > 1 {
+		http.Error(w, "Commit SHA is ambiguous", http.StatusConflict)
+		return
+	}
+	if len(shas) == 0 {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	sha := shas[0]
+
+	stages := groupPipelineStages(sha, events)
+	if len(stages) == 0 {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	repo, branch, landedAt := commitLanding(sha, events)
+	if !hasStage(stages, "release") && repo != "" && branch != "" {
+		release, err := h.repo.GetBranchRelease(repo, branch, landedAt)
+		if err != nil {
+			log.Error().Err(err).Str("sha", sha).Msg("failed to look up release")
+			http.Error(w, "Failed to load pipeline", http.StatusInternalServerError)
+			return
+		}
+		if release != nil {
+			stages = append(stages, newPipelineStage("release", "branch", []models.CommitEvent{*release}))
+		}
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=30")
+	writeJSON(w, log, http.StatusOK, assemblePipeline(sha, repo, branch, stages))
+}
+
+// isSHAPrefix reports whether s is a lowercase hex string of 7 to 40 characters
+func isSHAPrefix(s string) bool {
+	if len(s) < minSHAPrefix || len(s) > 40 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// matchingSHAs returns the distinct full SHAs among events that start with prefix
+func matchingSHAs(prefix string, events []models.CommitEvent) []string {
+	seen := make(map[string]bool)
+	var shas []string
+	for _, event := range events {
+		for _, sha := range []string{event.CommitSHA, event.MergeCommitSHA} {
+			sha = strings.ToLower(sha)
+			if strings.HasPrefix(sha, prefix) && !seen[sha] {
+				seen[sha] = true
+				shas = append(shas, sha)
+			}
+		}
+	}
+	sort.Strings(shas)
+	return shas
+}
+
+// pipelineStageOf returns the stage an event belongs to, or "" when it has no
+// place in a pipeline (security alerts, unmerged PR activity, ...)
+func pipelineStageOf(event models.CommitEvent) string {
+	switch {
+	case event.EventType == "github.push" || event.EventType == "gitlab.push":
+		return "push"
+	case event.EventType == "github.pr" || event.EventType == "gitlab.mr":
+		if event.Merged && event.Action == "closed" {
+			return "merge"
+		}
+	case event.EventType == "github.ci" || event.EventType == "gitlab.ci":
+		// Jobs are github.ci_job events and part of a workflow run reported on its own
+		return "ci"
+	case strings.HasPrefix(event.EventType, "artifact."):
+		return "artifact"
+	case deployEventTypes[event.EventType]:
+		return "deploy"
+	case event.EventType == "github.release" || event.EventType == "gitlab.release":
+		if event.Action == "published" || event.Action == "created" {
+			return "release"
+		}
+	}
+	return ""
+}
+
+// groupPipelineStages turns the events of one commit, oldest first, into stages.
+// The lifecycle states of one deploy (building, then ready) form a single stage
+// that ends with the first finished state.
+func groupPipelineStages(sha string, events []models.CommitEvent) []models.PipelineStage {
+	type group struct {
+		stage     string
+		matchedBy string
+		finished  bool
+		events    []models.CommitEvent
+	}
+	groups := make(map[string]*group)
+	var order []string
+
+	for _, event := range events {
+		var matchedBy string
+		switch sha {
+		case strings.ToLower(event.CommitSHA):
+			matchedBy = "commit"
+		case strings.ToLower(event.MergeCommitSHA):
+			matchedBy = "merge_commit"
+		default:
+			continue
+		}
+		stage := pipelineStageOf(event)
+		if stage == "" {
+			continue
+		}
+
+		key := event.ID
+		if stage == "deploy" && event.DeploymentID != "" {
+			key = event.EventType + ":" + event.DeploymentID
+		}
+		g, ok := groups[key]
+		if !ok {
+			g = &group{stage: stage, matchedBy: matchedBy}
+			groups[key] = g
+			order = append(order, key)
+		}
+		if g.finished {
+			continue
+		}
+		g.events = append(g.events, event)
+		g.finished = stage == "deploy" && finishedDeployStatuses[event.Status]
+	}
+
+	stages := make([]models.PipelineStage, 0, len(order))
+	for _, key := range order {
+		g := groups[key]
+		stages = append(stages, newPipelineStage(g.stage, g.matchedBy, g.events))
+	}
+	return stages
+}
+
+// newPipelineStage summarizes the events of one stage, oldest first. CI runs are
+// reported once they finish, so a reported duration moves the start back.
+func newPipelineStage(stage, matchedBy string, events []models.CommitEvent) models.PipelineStage {
+	first, last := events[0], events[len(events)-1]
+	result := models.PipelineStage{
+		Stage:      stage,
+		EventType:  last.EventType,
+		Title:      last.Title,
+		Status:     last.Status,
+		MatchedBy:  matchedBy,
+		StartedAt:  first.CreatedAt,
+		FinishedAt: last.CreatedAt,
+		EventIDs:   make([]string, 0, len(events)),
+	}
+	if started := last.CreatedAt.Add(-time.Duration(last.DurationSeconds) * time.Second); started.Before(result.StartedAt) {
+		result.StartedAt = started
+	}
+	for _, event := range events {
+		result.EventIDs = append(result.EventIDs, event.ID)
+		if event.Environment != "" {
+			result.Environment = event.Environment
+		}
+		if event.URL != "" {
+			result.URL = event.URL
+		}
+	}
+	result.DurationSeconds = *secondsBetween(result.StartedAt, result.FinishedAt)
+	return result
+}
+
+// commitLanding returns the repo and branch the commit landed on and when: the
+// base branch of the PR that merged it, otherwise the branch it was last pushed to
+func commitLanding(sha string, events []models.CommitEvent) (repo, branch string, at time.Time) {
+	merged := false
+	for _, event := range events {
+		if strings.ToLower(event.CommitSHA) != sha && strings.ToLower(event.MergeCommitSHA) != sha {
+			continue
+		}
+		switch pipelineStageOf(event) {
+		case "merge":
+			merged = true
+		case "push":
+			if merged {
+				continue
+			}
+		default:
+			continue
+		}
+		repo = event.Repo
+		branch = strings.TrimPrefix(event.Branch, "refs/heads/")
+		at = event.CreatedAt
+	}
+	return repo, branch, at
+}
+
+// hasStage reports whether any stage is of the given kind
+func hasStage(stages []models.PipelineStage, stage string) bool {
+	for _, s := range stages {
+		if s.Stage == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// assemblePipeline orders the stages and derives the time between them and the
+// lead time to the first successful production deploy. Stages keep their logical
+// order even when they ran out of it (CI before the merge, say), so the time
+// since the previous stage is left out when the stage started before the
+// previous one finished.
+func assemblePipeline(sha, repo, branch string, stages []models.PipelineStage) models.Pipeline {
+	sort.SliceStable(stages, func(i, j int) bool {
+		if pipelineStageOrder[stages[i].Stage] != pipelineStageOrder[stages[j].Stage] {
+			return pipelineStageOrder[stages[i].Stage] < pipelineStageOrder[stages[j].Stage]
+		}
+		return stages[i].StartedAt.Before(stages[j].StartedAt)
+	})
+
+	pipeline := models.Pipeline{
+		SHA:    sha,
+		Repo:   repo,
+		Branch: branch,
+		Stages: stages,
+	}
+	for i := range stages {
+		if i == 0 || stages[i].StartedAt.Before(pipeline.StartedAt) {
+			pipeline.StartedAt = stages[i].StartedAt
+		}
+		if i > 0 && !stages[i].StartedAt.Before(stages[i-1].FinishedAt) {
+			stages[i].SincePreviousSeconds = secondsBetween(stages[i-1].FinishedAt, stages[i].StartedAt)
+		}
+	}
+
+	for _, stage := range stages {
+		succeeded := stage.Status == "SUCCESS" || stage.Status == "PROMOTED"
+		if stage.Stage != "deploy" || !succeeded || !isProductionEnvironment(stage.Environment) {
+			continue
+		}
+		if pipeline.ReachedProductionAt == nil || stage.FinishedAt.Before(*pipeline.ReachedProductionAt) {
+			at := stage.FinishedAt
+			pipeline.ReachedProductionAt = &at
+		}
+	}
+	if pipeline.ReachedProductionAt != nil {
+		pipeline.LeadTimeSeconds = secondsBetween(pipeline.StartedAt, *pipeline.ReachedProductionAt)
+	}
+	return pipeline
+}
+
+// isProductionEnvironment reports whether a deploy environment is production
+func isProductionEnvironment(environment string) bool {
+	return strings.EqualFold(environment, "production") || strings.EqualFold(environment, "prod")
+}
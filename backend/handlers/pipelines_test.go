@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"heimdall-backend/models"
+
+	"github.com/gorilla/mux"
+)
+
+type mockPipelineStore struct {
+	events        []models.CommitEvent
+	release       *models.CommitEvent
+	prefix        string
+	releaseRepo   string
+	releaseBranch string
+}
+
+func (m *mockPipelineStore) GetCommitEvents(shaPrefix string) ([]models.CommitEvent, error) {
+	m.prefix = shaPrefix
+	return m.events, nil
+}
+
+func (m *mockPipelineStore) GetBranchRelease(repo, branch string, _ time.Time) (*models.CommitEvent, error) {
+	m.releaseRepo, m.releaseBranch = repo, branch
+	return m.release, nil
+}
+
+const (
+	testMergeSHA  = "9f8e7d6c5b4a39281706f5e4d3c2b1a098765432"
+	testBranchSHA = "1234567890abcdef1234567890abcdef12345678"
+)
+
+func servePipeline(t *testing.T, store *mockPipelineStore, sha string) *httptest.ResponseRecorder {
+	t.Helper()
+	router := mux.NewRouter()
+	router.Handle("/api/pipelines/{sha}", NewPipelinesHandler(store))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/pipelines/"+sha, http.NoBody)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPipelinesHandler_Chain(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := &mockPipelineStore{
+		events: []models.CommitEvent{
+			// Events of the PR branch head do not belong to the merge commit's pipeline
+			{ID: "0", EventType: "github.push", CommitSHA: testBranchSHA, CreatedAt: start.Add(-time.Hour)},
+			{ID: "1", EventType: "github.pr", Action: "closed", Merged: true, Repo: "heimdall", Branch: "main",
+				MergeCommitSHA: testMergeSHA, CreatedAt: start},
+			{ID: "2", EventType: "github.push", Repo: "heimdall", Branch: "refs/heads/main",
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(time.Second)},
+			{ID: "3", EventType: "vercel.deploy", Status: "BUILDING", Environment: "production", DeploymentID: "dpl_1",
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(30 * time.Second)},
+			{ID: "4", EventType: "github.ci_job", Kind: "workflow_job", Status: "success",
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(2 * time.Minute)},
+			{ID: "5", EventType: "github.ci", Kind: "workflow_run", Status: "success", DurationSeconds: 240,
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(5 * time.Minute)},
+			{ID: "6", EventType: "vercel.deploy", Status: "SUCCESS", Environment: "production", DeploymentID: "dpl_1",
+				URL: "https://heimdall.vercel.app", CommitSHA: testMergeSHA, CreatedAt: start.Add(3 * time.Minute)},
+			// A later state of the same deploy is not part of the pipeline
+			{ID: "7", EventType: "vercel.deploy", Status: "CANCELED", Environment: "production", DeploymentID: "dpl_1",
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(48 * time.Hour)},
+			{ID: "8", EventType: "security.code_scanning", CommitSHA: testMergeSHA, CreatedAt: start.Add(time.Minute)},
+		},
+		release: &models.CommitEvent{ID: "9", EventType: "github.release", Action: "published", CreatedAt: start.Add(24 * time.Hour)},
+	}
+
+	rec := servePipeline(t, store, "9F8E7D6")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if store.prefix != "9f8e7d6" {
+		t.Errorf("expected lowercase prefix lookup, got %q", store.prefix)
+	}
+	if store.releaseRepo != "heimdall" || store.releaseBranch != "main" {
+		t.Errorf("expected release lookup on heimdall/main, got %s/%s", store.releaseRepo, store.releaseBranch)
+	}
+
+	var pipeline models.Pipeline
+	if err := json.NewDecoder(rec.Body).Decode(&pipeline); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if pipeline.SHA != testMergeSHA || pipeline.Repo != "heimdall" || pipeline.Branch != "main" {
+		t.Errorf("unexpected pipeline identity: %s %s %s", pipeline.SHA, pipeline.Repo, pipeline.Branch)
+	}
+
+	wantStages := []string{"push", "merge", "ci", "deploy", "release"}
+	if len(pipeline.Stages) != len(wantStages) {
+		t.Fatalf("expected %d stages, got %+v", len(wantStages), pipeline.Stages)
+	}
+	for i, want := range wantStages {
+		if pipeline.Stages[i].Stage != want {
+			t.Errorf("stage %d: expected %s, got %s", i, want, pipeline.Stages[i].Stage)
+		}
+	}
+
+	merge, ci, deploy, release := pipeline.Stages[1], pipeline.Stages[2], pipeline.Stages[3], pipeline.Stages[4]
+	if merge.MatchedBy != "merge_commit" || release.MatchedBy != "branch" {
+		t.Errorf("unexpected matched_by: merge=%s release=%s", merge.MatchedBy, release.MatchedBy)
+	}
+	if !ci.StartedAt.Equal(start.Add(time.Minute)) || ci.DurationSeconds != 240 {
+		t.Errorf("expected CI to start at its reported duration, got %v (%ds)", ci.StartedAt, ci.DurationSeconds)
+	}
+	if deploy.Status != "SUCCESS" || deploy.DurationSeconds != 150 || len(deploy.EventIDs) != 2 {
+		t.Errorf("unexpected deploy stage: %+v", deploy)
+	}
+	if deploy.URL != "https://heimdall.vercel.app" {
+		t.Errorf("unexpected deploy URL: %s", deploy.URL)
+	}
+	if ci.SincePreviousSeconds == nil || *ci.SincePreviousSeconds != 60 {
+		t.Errorf("expected 60s between merge and CI, got %v", ci.SincePreviousSeconds)
+	}
+
+	if pipeline.ReachedProductionAt == nil || !pipeline.ReachedProductionAt.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("unexpected reached_production_at: %v", pipeline.ReachedProductionAt)
+	}
+	if pipeline.LeadTimeSeconds == nil || *pipeline.LeadTimeSeconds != 180 {
+		t.Errorf("expected lead time 180s, got %v", pipeline.LeadTimeSeconds)
+	}
+}
+
+func TestPipelinesHandler_CIBeforeMerge(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := &mockPipelineStore{
+		events: []models.CommitEvent{
+			{ID: "1", EventType: "github.push", Repo: "heimdall", Branch: "refs/heads/feature",
+				CommitSHA: testMergeSHA, CreatedAt: start},
+			// CI finishes on the branch before the PR is merged with the same commit
+			{ID: "2", EventType: "github.ci", Kind: "workflow_run", Status: "success", DurationSeconds: 120,
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(3 * time.Minute)},
+			{ID: "3", EventType: "github.pr", Action: "closed", Merged: true, Repo: "heimdall", Branch: "main",
+				MergeCommitSHA: testMergeSHA, CreatedAt: start.Add(5 * time.Minute)},
+			{ID: "4", EventType: "vercel.deploy", Status: "BUILDING", Environment: "production", DeploymentID: "dpl_1",
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(6 * time.Minute)},
+			{ID: "5", EventType: "vercel.deploy", Status: "SUCCESS", Environment: "production", DeploymentID: "dpl_1",
+				CommitSHA: testMergeSHA, CreatedAt: start.Add(8 * time.Minute)},
+		},
+	}
+
+	rec := servePipeline(t, store, testMergeSHA)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var pipeline models.Pipeline
+	if err := json.NewDecoder(rec.Body).Decode(&pipeline); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	wantStages := []string{"push", "merge", "ci", "deploy"}
+	if len(pipeline.Stages) != len(wantStages) {
+		t.Fatalf("expected %d stages, got %+v", len(wantStages), pipeline.Stages)
+	}
+	merge, ci, deploy := pipeline.Stages[1], pipeline.Stages[2], pipeline.Stages[3]
+	if merge.Stage != "merge" || ci.Stage != "ci" || deploy.Stage != "deploy" {
+		t.Fatalf("unexpected stage order: %+v", pipeline.Stages)
+	}
+	if merge.SincePreviousSeconds == nil || *merge.SincePreviousSeconds != 300 {
+		t.Errorf("expected 300s between push and merge, got %v", merge.SincePreviousSeconds)
+	}
+	// CI ran before the merge, so there is no wait to report
+	if ci.SincePreviousSeconds != nil {
+		t.Errorf("expected no wait for CI that ran before the merge, got %d", *ci.SincePreviousSeconds)
+	}
+	if deploy.SincePreviousSeconds == nil || *deploy.SincePreviousSeconds != 180 {
+		t.Errorf("expected 180s between CI and deploy, got %v", deploy.SincePreviousSeconds)
+	}
+}
+
+func TestPipelinesHandler_CommitRelease(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := &mockPipelineStore{
+		events: []models.CommitEvent{
+			{ID: "1", EventType: "gitlab.push", Repo: "heimdall", Branch: "main", CommitSHA: testMergeSHA, CreatedAt: start},
+			{ID: "2", EventType: "gitlab.release", Action: "created", CommitSHA: testMergeSHA, CreatedAt: start.Add(time.Hour)},
+		},
+		release: &models.CommitEvent{ID: "3", EventType: "github.release", Action: "published"},
+	}
+
+	rec := servePipeline(t, store, testMergeSHA)
+	var pipeline models.Pipeline
+	if err := json.NewDecoder(rec.Body).Decode(&pipeline); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if store.releaseRepo != "" {
+		t.Errorf("expected no branch release lookup when the release names the commit")
+	}
+	if len(pipeline.Stages) != 2 || pipeline.Stages[1].MatchedBy != "commit" {
+		t.Errorf("unexpected stages: %+v", pipeline.Stages)
+	}
+	if pipeline.LeadTimeSeconds != nil {
+		t.Errorf("expected no lead time without a production deploy, got %d", *pipeline.LeadTimeSeconds)
+	}
+}
+
+func TestPipelinesHandler_Errors(t *testing.T) {
+	ambiguous := &mockPipelineStore{events: []models.CommitEvent{
+		{ID: "1", EventType: "github.push", CommitSHA: "abcdef1000000000000000000000000000000000"},
+		{ID: "2", EventType: "github.push", CommitSHA: "abcdef1999999999999999999999999999999999"},
+	}}
+	unrelated := &mockPipelineStore{events: []models.CommitEvent{
+		{ID: "1", EventType: "security.code_scanning", CommitSHA: testMergeSHA},
+	}}
+
+	tests := []struct {
+		name   string
+		store  *mockPipelineStore
+		sha    string
+		status int
+	}{
+		{"too short", &mockPipelineStore{}, "abc12", http.StatusBadRequest},
+		{"not hex", &mockPipelineStore{}, "main-branch", http.StatusBadRequest},
+		{"unknown", &mockPipelineStore{}, "abcdef1", http.StatusNotFound},
+		{"no pipeline events", unrelated, testMergeSHA, http.StatusNotFound},
+		{"ambiguous", ambiguous, "abcdef1", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := servePipeline(t, tt.store, tt.sha)
+			if rec.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"heimdall-backend/logger"
+	"heimdall-backend/webhooks"
+)
+
+// maxWebhookBodyBytes caps provider payloads (GitHub's documented maximum is 25MB)
+const maxWebhookBodyBytes = 25 << 20
+
+// ProviderWebhookHandler accepts native payloads posted directly by a provider,
+// bypassing the Next.js edge route and QStash
+type ProviderWebhookHandler struct {
+	ingester *Ingester
+	source   webhooks.Source
+}
+
+// NewProviderWebhookHandler creates a handler for a single webhook source
+func NewProviderWebhookHandler(ingester *Ingester, source webhooks.Source) *ProviderWebhookHandler {
+	return &ProviderWebhookHandler{
+		ingester: ingester,
+		source:   source,
+	}
+}
+
+// ServeHTTP verifies the delivery, resolves its event type and ingests it
+func (h *ProviderWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context()).WithField("source", h.source.Name())
+
+	// Signatures are computed over the exact bytes, so read the raw body once
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read webhook body")
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.source.Verify(r, body); err != nil {
+		log.Warn().Err(err).Msg("webhook signature verification failed")
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	req := ingestRequest{
+		Headers:   r.Header,
+		Source:    h.source.Name(),
+		EventData: body,
+	}
+
+	delivery, err := h.source.Parse(r, body)
+	if err != nil {
+		if errors.Is(err, webhooks.ErrIgnoredEvent) {
+			log.Info().Err(err).Msg("ignoring webhook delivery")
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		log.Error().Err(err).Msg("invalid webhook payload")
+		h.ingester.reject(w, r, log, req, http.StatusBadRequest, "Invalid payload", err)
+		return
+	}
+
+	log.Info().
+		Str("event_type", delivery.EventType).
+		Msg("received webhook")
+
+	timestamp := delivery.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	req.DeliveryID = delivery.ID
+	req.EventType = delivery.EventType
+	req.Timestamp = timestamp
+	h.ingester.ingest(w, r, log, req)
+}
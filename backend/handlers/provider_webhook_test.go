@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"heimdall-backend/transformers"
+	"heimdall-backend/webhooks"
+)
+
+const testPushBody = `{
+	"ref": "refs/heads/main",
+	"repository": {"name": "test-repo"},
+	"head_commit": {"message": "Test commit", "author": {"name": "Test User"}},
+	"commits": [{"id": "abc123"}]
+}`
+
+func githubSignature(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestProviderWebhookHandler_GitHubValidSignature(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewProviderWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), &webhooks.GitHubSource{Secret: "secret"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/github", bytes.NewReader([]byte(testPushBody)))
+	req.Header.Set("X-GitHub-Event", "push")
+	req.Header.Set("X-Hub-Signature-256", githubSignature("secret", testPushBody))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+
+	if mockRepo.insertCalls != 1 {
+		t.Fatalf("expected 1 insert call, got %d", mockRepo.insertCalls)
+	}
+
+	if mockRepo.events[0].EventType != "github.push" {
+		t.Errorf("expected github.push, got %q", mockRepo.events[0].EventType)
+	}
+}
+
+func TestProviderWebhookHandler_GitHubInvalidSignature(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewProviderWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), &webhooks.GitHubSource{Secret: "secret"})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/github", bytes.NewReader([]byte(testPushBody)))
+	req.Header.Set("X-GitHub-Event", "push")
+	req.Header.Set("X-Hub-Signature-256", githubSignature("wrong", testPushBody))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+
+	if mockRepo.insertCalls != 0 {
+		t.Errorf("expected 0 insert calls, got %d", mockRepo.insertCalls)
+	}
+}
+
+func TestProviderWebhookHandler_RejectsWithoutSecret(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewProviderWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), &webhooks.GitHubSource{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/github", bytes.NewReader([]byte(testPushBody)))
+	req.Header.Set("X-GitHub-Event", "push")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", rec.Code)
+	}
+	if mockRepo.insertCalls != 0 {
+		t.Errorf("expected 0 insert calls, got %d", mockRepo.insertCalls)
+	}
+}
+
+func TestProviderWebhookHandler_GitHubPingIgnored(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewProviderWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), webhooks.Unsigned(&webhooks.GitHubSource{}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/github", bytes.NewReader([]byte(`{"zen":"Keep it simple."}`)))
+	req.Header.Set("X-GitHub-Event", "ping")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected status 202, got %d", rec.Code)
+	}
+
+	if mockRepo.insertCalls != 0 {
+		t.Errorf("expected 0 insert calls, got %d", mockRepo.insertCalls)
+	}
+}
+
+func TestProviderWebhookHandler_VercelDeploy(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewProviderWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), webhooks.Unsigned(&webhooks.VercelSource{}))
+
+	body := `{"type":"deployment.succeeded","createdAt":1705314600000,"payload":{"deployment":{"id":"dpl_1","name":"heimdall"},"target":"production"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/vercel", bytes.NewReader([]byte(body)))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", rec.Code)
+	}
+
+	if len(mockRepo.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(mockRepo.events))
+	}
+
+	if mockRepo.events[0].Title != "heimdall: SUCCESS to production" {
+		t.Errorf("unexpected title %q", mockRepo.events[0].Title)
+	}
+}
+
+func TestProviderWebhookHandler_GitHubDeliveryIDDeduplicates(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewProviderWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), webhooks.Unsigned(&webhooks.GitHubSource{}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/webhook/github", bytes.NewReader([]byte(testPushBody)))
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("delivery %d: expected status 200, got %d", i, rec.Code)
+		}
+	}
+
+	if len(mockRepo.events) != 1 {
+		t.Fatalf("expected 1 stored event, got %d", len(mockRepo.events))
+	}
+
+	if mockRepo.events[0].Source != "github" {
+		t.Errorf("expected source github, got %q", mockRepo.events[0].Source)
+	}
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+const (
+	// defaultPullRequestMonths is the number of calendar months shown without a from date
+	defaultPullRequestMonths = 6
+	maxPullRequestRangeDays  = 730
+)
+
+// PullRequestStatsResponse reports PR cycle times overall, per repo and per author
+type PullRequestStatsResponse struct {
+	From    string                    `json:"from"` // YYYY-MM-DD, inclusive
+	To      string                    `json:"to"`   // YYYY-MM-DD, inclusive
+	Summary models.PullRequestStats   `json:"summary"`
+	Months  []models.PullRequestMonth `json:"months"`
+	Repos   []models.PullRequestGroup `json:"repos"`
+	Authors []models.PullRequestGroup `json:"authors"`
+}
+
+// PullRequestStatsHandler serves time to merge, open PR age and merge ratio
+// rebuilt from GitHub pull request, review and comment events
+type PullRequestStatsHandler struct {
+	repo database.PullRequestStore
+}
+
+// NewPullRequestStatsHandler creates a new pull request stats handler
+func NewPullRequestStatsHandler(repo database.PullRequestStore) *PullRequestStatsHandler {
+	return &PullRequestStatsHandler{repo: repo}
+}
+
+// ServeHTTP handles GET /api/pull-requests/stats?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *PullRequestStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logger.FromContext(r.Context())
+
+	now := time.Now().UTC()
+	from, until, ok := parsePullRequestRange(r, now)
+	if !ok {
+		http.Error(w, "Invalid date range: from must not be after to", http.StatusBadRequest)
+		return
+	}
+
+	events, err := h.repo.GetPullRequestEvents(from, until)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to load pull request events")
+		http.Error(w, "Failed to load pull request stats", http.StatusInternalServerError)
+		return
+	}
+
+	prs := rebuildPullRequests(events)
+	response := PullRequestStatsResponse{
+		From:    from.Format("2006-01-02"),
+		To:      until.Add(-time.Nanosecond).Format("2006-01-02"),
+		Summary: summarizePullRequests(prs, from, until),
+		Months:  pullRequestMonths(prs, from, until),
+		Repos:   groupPullRequests(prs, from, until, func(pr models.PullRequest) string { return pr.Repo }),
+		Authors: groupPullRequests(prs, from, until, func(pr models.PullRequest) string { return pr.Author }),
+	}
+
+	w.Header().Set("Cache-Control", "private, max-age=60")
+	writeJSON(w, log, http.StatusOK, response)
+}
+
+// parsePullRequestRange reads the inclusive from and to dates (YYYY-MM-DD or
+// RFC 3339) and returns the half-open range [from, until). Unparseable dates
+// fall back to the defaults: to is today and from is the first day of the month
+// defaultPullRequestMonths-1 months earlier. until never lies in the future and
+// the range is capped at maxPullRequestRangeDays.
+func parsePullRequestRange(r *http.Request, now time.Time) (from, until time.Time, ok bool) {
+	until = now
+	if to, valid := parseDate(r.URL.Query().Get("to")); valid && to.AddDate(0, 0, 1).Before(now) {
+		until = to.AddDate(0, 0, 1)
+	}
+
+	last := until.Add(-time.Nanosecond)
+	from = time.Date(last.Year(), last.Month()-(defaultPullRequestMonths-1), 1, 0, 0, 0, 0, time.UTC)
+	if parsed, valid := parseDate(r.URL.Query().Get("from")); valid {
+		from = parsed
+	}
+	if !from.Before(until) {
+		return from, until, false
+	}
+	if earliest := until.AddDate(0, 0, -maxPullRequestRangeDays); from.Before(earliest) {
+		from = earliest
+	}
+	return from, until, true
+}
+
+// parseDate parses a YYYY-MM-DD date or an RFC 3339 time, truncated to its UTC day
+func parseDate(s string) (time.Time, bool) {
+	if s == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			return time.Time{}, false
+		}
+	}
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), true
+}
+
+// rebuildPullRequests folds the events of each PR, oldest first, into its
+// lifecycle. A reopen starts a new open interval that lasts until the PR is next
+// closed, so every close is counted in the period it happened. Reviews and
+// comments by the PR's author are not review activity.
+func rebuildPullRequests(events []models.PullRequestEvent) []models.PullRequest {
+	byKey := make(map[string]*models.PullRequest)
+	var order []string
+
+	for _, event := range events {
+		key := event.Repo + "#" + strconv.Itoa(event.Number)
+		pr, ok := byKey[key]
+		if !ok {
+			// A PR first seen after it was opened has been open since before events were recorded
+			pr = &models.PullRequest{Repo: event.Repo, Number: event.Number, State: "open",
+				Intervals: []models.PullRequestInterval{{}}}
+			byKey[key] = pr
+			order = append(order, key)
+		}
+		if event.Author != "" {
+			pr.Author = event.Author
+		}
+		if event.URL != "" {
+			pr.URL = event.URL
+		}
+
+		if event.EventType != "github.pr" {
+			reviewed := event.Action == "submitted" || event.Action == "created"
+			if reviewed && event.Actor != "" && event.Actor != pr.Author {
+				pr.ReviewedAt = append(pr.ReviewedAt, event.CreatedAt)
+			}
+			continue
+		}
+
+		at := event.CreatedAt
+		current := &pr.Intervals[len(pr.Intervals)-1]
+		switch event.Action {
+		case "opened":
+			if pr.OpenedAt == nil {
+				pr.OpenedAt = &at
+				pr.Intervals[0].OpenedAt = &at
+			}
+		case "reopened":
+			if current.ClosedAt != nil {
+				pr.Intervals = append(pr.Intervals, models.PullRequestInterval{OpenedAt: &at})
+				pr.State = "open"
+			}
+		case "closed":
+			if current.ClosedAt == nil {
+				current.ClosedAt, current.Merged = &at, event.Merged
+				pr.State = "closed"
+				if event.Merged {
+					pr.State = "merged"
+				}
+			}
+		}
+	}
+
+	prs := make([]models.PullRequest, 0, len(order))
+	for _, key := range order {
+		prs = append(prs, *byKey[key])
+	}
+	return prs
+}
+
+// summarizePullRequests reports what happened to prs within [start, end) and
+// which of them were still open at end. Every close of a reopened PR counts in
+// the period it happened, and the PR is open at end only when one of its open
+// intervals spans it.
+func summarizePullRequests(prs []models.PullRequest, start, end time.Time) models.PullRequestStats {
+	var stats models.PullRequestStats
+	var mergeTimes, reviewTimes, openAges []int64
+	within := func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+
+	for _, pr := range prs {
+		if pr.OpenedAt != nil && within(*pr.OpenedAt) {
+			stats.Opened++
+		}
+		openAtEnd := false
+		for _, interval := range pr.Intervals {
+			openedBefore := interval.OpenedAt == nil || interval.OpenedAt.Before(end)
+			closedAfter := interval.ClosedAt == nil || !interval.ClosedAt.Before(end)
+			if openedBefore && closedAfter {
+				openAtEnd = true
+			}
+			if interval.ClosedAt == nil || !within(*interval.ClosedAt) {
+				continue
+			}
+			if interval.Merged {
+				stats.Merged++
+				if pr.OpenedAt != nil {
+					mergeTimes = append(mergeTimes, *secondsBetween(*pr.OpenedAt, *interval.ClosedAt))
+				}
+			} else {
+				stats.Closed++
+			}
+		}
+		for i, reviewedAt := range pr.ReviewedAt {
+			if !within(reviewedAt) {
+				continue
+			}
+			stats.Reviews++
+			if i == 0 && pr.OpenedAt != nil {
+				reviewTimes = append(reviewTimes, *secondsBetween(*pr.OpenedAt, reviewedAt))
+			}
+		}
+
+		if openAtEnd {
+			stats.Open++
+			if pr.OpenedAt != nil {
+				openAges = append(openAges, *secondsBetween(*pr.OpenedAt, end))
+			}
+		}
+	}
+
+	if finished := stats.Merged + stats.Closed; finished > 0 {
+		ratio := float64(stats.Merged) / float64(finished)
+		stats.MergeRatio = &ratio
+	}
+	if len(mergeTimes) > 0 {
+		sort.Slice(mergeTimes, func(i, j int) bool { return mergeTimes[i] < mergeTimes[j] })
+		stats.TimeToMergeP50Seconds = percentileSeconds(mergeTimes, 50)
+		stats.TimeToMergeP90Seconds = percentileSeconds(mergeTimes, 90)
+	}
+	if len(reviewTimes) > 0 {
+		sort.Slice(reviewTimes, func(i, j int) bool { return reviewTimes[i] < reviewTimes[j] })
+		stats.TimeToFirstReviewP50Seconds = percentileSeconds(reviewTimes, 50)
+	}
+	if len(openAges) > 0 {
+		sort.Slice(openAges, func(i, j int) bool { return openAges[i] < openAges[j] })
+		stats.OpenAgeP50Seconds = percentileSeconds(openAges, 50)
+		stats.OldestOpenAgeSeconds = &openAges[len(openAges)-1]
+	}
+	return stats
+}
+
+// pullRequestMonths summarizes prs for each calendar month of [from, until),
+// clipping the first and last month to the range
+func pullRequestMonths(prs []models.PullRequest, from, until time.Time) []models.PullRequestMonth {
+	var months []models.PullRequestMonth
+	for month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); month.Before(until); month = month.AddDate(0, 1, 0) {
+		start, end := month, month.AddDate(0, 1, 0)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(until) {
+			end = until
+		}
+		months = append(months, models.PullRequestMonth{
+			Month:            month.Format("2006-01"),
+			PullRequestStats: summarizePullRequests(prs, start, end),
+		})
+	}
+	return months
+}
+
+// groupPullRequests summarizes prs by the name keyOf gives them, sorted by name.
+// PRs without a name (no recorded author) are left out.
+func groupPullRequests(prs []models.PullRequest, from, until time.Time, keyOf func(models.PullRequest) string) []models.PullRequestGroup {
+	byName := make(map[string][]models.PullRequest)
+	for _, pr := range prs {
+		if name := keyOf(pr); name != "" {
+			byName[name] = append(byName[name], pr)
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]models.PullRequestGroup, 0, len(names))
+	for _, name := range names {
+		groups = append(groups, models.PullRequestGroup{
+			Name:    name,
+			Summary: summarizePullRequests(byName[name], from, until),
+			Months:  pullRequestMonths(byName[name], from, until),
+		})
+	}
+	return groups
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+type mockPullRequestStore struct {
+	events       []models.PullRequestEvent
+	since, until time.Time
+}
+
+func (m *mockPullRequestStore) GetPullRequestEvents(since, until time.Time) ([]models.PullRequestEvent, error) {
+	m.since, m.until = since, until
+	return m.events, nil
+}
+
+func TestRebuildPullRequests(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	pr := func(number int, action string, merged bool, offset time.Duration) models.PullRequestEvent {
+		return models.PullRequestEvent{EventType: "github.pr", Action: action, Repo: "heimdall", Number: number,
+			Author: "alice", Actor: "alice", Merged: merged, CreatedAt: start.Add(offset)}
+	}
+	review := func(eventType, action, actor string, offset time.Duration) models.PullRequestEvent {
+		return models.PullRequestEvent{EventType: eventType, Action: action, Repo: "heimdall", Number: 1,
+			Author: "alice", Actor: actor, CreatedAt: start.Add(offset)}
+	}
+
+	prs := rebuildPullRequests([]models.PullRequestEvent{
+		pr(1, "opened", false, 0),
+		review("github.comment", "created", "alice", time.Minute),
+		review("github.review", "submitted", "bob", time.Hour),
+		review("github.review", "edited", "bob", 2*time.Hour),
+		review("github.review_comment", "created", "carol", 3*time.Hour),
+		pr(1, "synchronize", false, 4*time.Hour),
+		pr(1, "closed", true, 5*time.Hour),
+		pr(2, "opened", false, 0),
+		pr(2, "closed", false, time.Hour),
+		pr(2, "reopened", false, 2*time.Hour),
+		// Opened before events were recorded
+		pr(3, "closed", false, time.Hour),
+	})
+
+	if len(prs) != 3 {
+		t.Fatalf("expected 3 PRs, got %d", len(prs))
+	}
+	merged, reopened, untracked := prs[0], prs[1], prs[2]
+	if merged.State != "merged" || len(merged.Intervals) != 1 || merged.Intervals[0].ClosedAt == nil ||
+		!merged.Intervals[0].ClosedAt.Equal(start.Add(5*time.Hour)) || !merged.Intervals[0].Merged {
+		t.Errorf("unexpected merged PR: %+v", merged)
+	}
+	if len(merged.ReviewedAt) != 2 || !merged.ReviewedAt[0].Equal(start.Add(time.Hour)) {
+		t.Errorf("expected reviews by bob and carol only, got %v", merged.ReviewedAt)
+	}
+	if reopened.State != "open" || len(reopened.Intervals) != 2 || reopened.Intervals[1].ClosedAt != nil {
+		t.Errorf("expected the reopened PR to be open, got %+v", reopened)
+	}
+	if closed := reopened.Intervals[0].ClosedAt; closed == nil || !closed.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected the first close to be kept, got %v", closed)
+	}
+	if untracked.OpenedAt != nil || untracked.State != "closed" || untracked.Intervals[0].OpenedAt != nil {
+		t.Errorf("unexpected untracked PR: %+v", untracked)
+	}
+}
+
+func TestSummarizePullRequests(t *testing.T) {
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2024, 3, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+	interval := func(opened, closed *time.Time, merged bool) []models.PullRequestInterval {
+		return []models.PullRequestInterval{{OpenedAt: opened, ClosedAt: closed, Merged: merged}}
+	}
+	prs := []models.PullRequest{
+		{Repo: "a", Author: "alice", State: "merged", OpenedAt: at(1, 0), Intervals: interval(at(1, 0), at(1, 2), true), ReviewedAt: []time.Time{*at(1, 1)}},
+		{Repo: "a", Author: "bob", State: "merged", OpenedAt: at(2, 0), Intervals: interval(at(2, 0), at(3, 0), true)},
+		{Repo: "b", Author: "bob", State: "closed", OpenedAt: at(4, 0), Intervals: interval(at(4, 0), at(5, 0), false)},
+		{Repo: "b", Author: "alice", State: "open", OpenedAt: at(8, 0), Intervals: interval(at(8, 0), nil, false)},
+		{Repo: "b", Author: "alice", State: "open", Intervals: interval(nil, nil, false)},
+	}
+
+	stats := summarizePullRequests(prs, *at(1, 0), *at(10, 0))
+	if stats.Opened != 4 || stats.Merged != 2 || stats.Closed != 1 || stats.Open != 2 || stats.Reviews != 1 {
+		t.Errorf("unexpected counts: %+v", stats)
+	}
+	if stats.MergeRatio == nil || *stats.MergeRatio != 2.0/3 {
+		t.Errorf("expected merge ratio 2/3, got %v", stats.MergeRatio)
+	}
+	if *stats.TimeToMergeP50Seconds != 2*3600 || *stats.TimeToMergeP90Seconds != 24*3600 {
+		t.Errorf("unexpected time to merge: p50 %d p90 %d", *stats.TimeToMergeP50Seconds, *stats.TimeToMergeP90Seconds)
+	}
+	if *stats.TimeToFirstReviewP50Seconds != 3600 {
+		t.Errorf("expected 1h to first review, got %d", *stats.TimeToFirstReviewP50Seconds)
+	}
+	if *stats.OldestOpenAgeSeconds != 2*24*3600 {
+		t.Errorf("expected the oldest open PR to be 2 days old, got %d", *stats.OldestOpenAgeSeconds)
+	}
+
+	// bob's closed PR was still open at the end of the 4th
+	early := summarizePullRequests(prs, *at(1, 0), *at(4, 12))
+	if early.Open != 2 || early.Merged != 2 || early.Closed != 0 {
+		t.Errorf("unexpected counts before the 5th: %+v", early)
+	}
+
+	authors := groupPullRequests(prs, *at(1, 0), *at(10, 0), func(pr models.PullRequest) string { return pr.Author })
+	if len(authors) != 2 || authors[0].Name != "alice" || authors[1].Summary.Merged != 1 || authors[1].Summary.Closed != 1 {
+		t.Errorf("unexpected author groups: %+v", authors)
+	}
+}
+
+func TestPullRequestMonths_CloseReopenClose(t *testing.T) {
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC)
+	}
+	pr := func(action string, at time.Time) models.PullRequestEvent {
+		return models.PullRequestEvent{EventType: "github.pr", Action: action, Repo: "heimdall", Number: 1,
+			Author: "alice", Actor: "alice", CreatedAt: at}
+	}
+
+	// Closed in January, reopened in March and closed again in April
+	prs := rebuildPullRequests([]models.PullRequestEvent{
+		pr("opened", day(time.January, 5)),
+		pr("closed", day(time.January, 20)),
+		pr("reopened", day(time.March, 10)),
+		pr("closed", day(time.April, 15)),
+	})
+	if len(prs) != 1 || len(prs[0].Intervals) != 2 || prs[0].State != "closed" {
+		t.Fatalf("expected one closed PR with two open intervals, got %+v", prs)
+	}
+
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	months := pullRequestMonths(prs, from, from.AddDate(0, 4, 0))
+	want := []struct {
+		month        string
+		closed, open int
+	}{
+		{"2024-01", 1, 0},
+		{"2024-02", 0, 0},
+		{"2024-03", 0, 1},
+		{"2024-04", 1, 0},
+	}
+	if len(months) != len(want) {
+		t.Fatalf("expected %d months, got %d", len(want), len(months))
+	}
+	for i, w := range want {
+		got := months[i]
+		if got.Month != w.month || got.Closed != w.closed || got.Open != w.open {
+			t.Errorf("%s: expected closed %d open %d, got %s closed %d open %d",
+				w.month, w.closed, w.open, got.Month, got.Closed, got.Open)
+		}
+	}
+}
+
+func TestPullRequestStatsHandler_Range(t *testing.T) {
+	store := &mockPullRequestStore{events: []models.PullRequestEvent{
+		{EventType: "github.pr", Action: "opened", Repo: "heimdall", Number: 1, Author: "alice",
+			CreatedAt: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)},
+		{EventType: "github.pr", Action: "closed", Merged: true, Repo: "heimdall", Number: 1, Author: "alice",
+			CreatedAt: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/pull-requests/stats?from=2024-02-15&to=2024-03-31", http.NoBody)
+	rec := httptest.NewRecorder()
+	NewPullRequestStatsHandler(store).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !store.until.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected to to be inclusive, got until %v", store.until)
+	}
+
+	var response PullRequestStatsResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.From != "2024-02-15" || response.To != "2024-03-31" {
+		t.Errorf("unexpected range: %s to %s", response.From, response.To)
+	}
+	if len(response.Months) != 2 || response.Months[0].Month != "2024-02" || response.Months[1].Merged != 1 {
+		t.Fatalf("unexpected months: %+v", response.Months)
+	}
+	if response.Summary.Opened != 0 || response.Summary.TimeToMergeP50Seconds == nil ||
+		*response.Summary.TimeToMergeP50Seconds != 24*24*3600 {
+		t.Errorf("expected a PR opened before the range to still have a time to merge, got %+v", response.Summary)
+	}
+	if len(response.Repos) != 1 || len(response.Authors) != 1 || response.Authors[0].Name != "alice" {
+		t.Errorf("unexpected groups: repos %+v authors %+v", response.Repos, response.Authors)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/api/pull-requests/stats?from=2024-04-01&to=2024-03-01", http.NoBody)
+	NewPullRequestStatsHandler(store).ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an inverted range, got %d", rec.Code)
+	}
+}
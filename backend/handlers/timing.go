@@ -0,0 +1,12 @@
+package handlers
+
+import "time"
+
+// secondsBetween returns the whole seconds from start to end, never negative
+func secondsBetween(start, end time.Time) *int64 {
+	seconds := int64(end.Sub(start).Seconds())
+	if seconds < 0 {
+		seconds = 0
+	}
+	return &seconds
+}
@@ -2,37 +2,69 @@ package handlers
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
-	"heimdall-backend/database"
 	"heimdall-backend/logger"
 	"heimdall-backend/models"
-	"heimdall-backend/transformers"
+	"heimdall-backend/webhooks"
 )
 
 // WebhookHandler handles incoming webhook requests
 type WebhookHandler struct {
-	repo     database.EventStore
-	registry *transformers.Registry
+	ingester *Ingester
+	verifier *webhooks.QStashVerifier
 }
 
-// NewWebhookHandler creates a new webhook handler
-func NewWebhookHandler(repo database.EventStore, registry *transformers.Registry) *WebhookHandler {
+// NewWebhookHandler creates a new webhook handler.
+// Upstash-Signature verification is skipped when verifier has no signing keys, which
+// the server only allows with ALLOW_UNSIGNED_WEBHOOKS.
+func NewWebhookHandler(ingester *Ingester, verifier *webhooks.QStashVerifier) *WebhookHandler {
 	return &WebhookHandler{
-		repo:     repo,
-		registry: registry,
+		ingester: ingester,
+		verifier: verifier,
 	}
 }
 
 // ServeHTTP handles the webhook request
 func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	log := logger.FromContext(r.Context())
+	// Tag every log line for this delivery with QStash's message ID and retry count
+	retried, _ := strconv.Atoi(r.Header.Get("Upstash-Retried"))
+	log := logger.FromContext(r.Context()).WithFields(map[string]interface{}{
+		"qstash_message_id": r.Header.Get("Upstash-Message-Id"),
+		"qstash_retried":    retried,
+	})
+
+	// The signature covers the exact body bytes, so read them before decoding
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes))
+	if err != nil {
+		log.Error().Err(err).Msg("failed to read webhook body")
+		rejectPermanent(w, r, http.StatusBadRequest, "Invalid payload")
+		return
+	}
+
+	if h.verifier.Enabled() {
+		if err := h.verifier.Verify(r.Header.Get("Upstash-Signature"), body); err != nil {
+			log.Warn().Err(err).Msg("QStash signature verification failed")
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	req := ingestRequest{
+		Headers:    r.Header,
+		Source:     "qstash",
+		DeliveryID: r.Header.Get("Upstash-Message-Id"),
+	}
 
 	var payload models.QStashPayload
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+	if err := json.Unmarshal(body, &payload); err != nil {
 		log.Error().Err(err).Msg("invalid webhook payload")
-		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		// Keep the whole body so the envelope itself can be inspected
+		req.EventData = body
+		h.ingester.reject(w, r, log, req, http.StatusBadRequest, "Invalid payload", err)
 		return
 	}
 
@@ -40,47 +72,14 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Str("event_type", payload.EventType).
 		Msg("received webhook")
 
-	// Check if we have a transformer for this event type
-	if !h.registry.HasTransformer(payload.EventType) {
-		log.Warn().
-			Str("event_type", payload.EventType).
-			Msg("unknown event type")
-		http.Error(w, "Unknown event type", http.StatusBadRequest)
-		return
-	}
-
 	// Determine timestamp - use payload timestamp if provided, otherwise current time
 	timestamp := time.Now().UTC()
 	if payload.Timestamp > 0 {
 		timestamp = time.Unix(payload.Timestamp, 0).UTC()
 	}
 
-	// Transform the event
-	dashboardEvent, err := h.registry.Transform(payload.EventType, payload.Event, timestamp)
-	if err != nil {
-		log.Error().
-			Err(err).
-			Str("event_type", payload.EventType).
-			Msg("failed to transform event")
-		http.Error(w, "Failed to process event", http.StatusInternalServerError)
-		return
-	}
-
-	// Insert into database
-	if err := h.repo.InsertEvent(&dashboardEvent); err != nil {
-		log.Error().
-			Err(err).
-			Str("event_type", dashboardEvent.EventType).
-			Msg("failed to insert event")
-		http.Error(w, "Failed to save event", http.StatusInternalServerError)
-		return
-	}
-
-	log.Info().
-		Str("event_type", dashboardEvent.EventType).
-		Str("title", dashboardEvent.Title).
-		Str("event_id", dashboardEvent.ID).
-		Msg("processed event successfully")
-
-	w.WriteHeader(http.StatusOK)
+	req.EventType = payload.EventType
+	req.EventData = payload.Event
+	req.Timestamp = timestamp
+	h.ingester.ingest(w, r, log, req)
 }
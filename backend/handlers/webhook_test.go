@@ -2,15 +2,21 @@ package handlers
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"heimdall-backend/ingest"
 	"heimdall-backend/models"
 	"heimdall-backend/transformers"
+	"heimdall-backend/webhooks"
 )
 
 // mockEventStore is a mock implementation of database.EventStore for testing
@@ -21,13 +27,30 @@ type mockEventStore struct {
 	insertCalls int
 }
 
-func (m *mockEventStore) InsertEvent(event *models.DashboardEvent) error {
+func (m *mockEventStore) InsertEvents(events []models.DashboardEvent) ([]bool, error) {
 	m.insertCalls++
 	if m.insertErr != nil {
-		return m.insertErr
+		return nil, m.insertErr
 	}
+	created := make([]bool, len(events))
+	for i := range events {
+		created[i] = m.insert(&events[i])
+	}
+	return created, nil
+}
+
+func (m *mockEventStore) insert(event *models.DashboardEvent) bool {
+	if event.ExternalID != "" {
+		for _, existing := range m.events {
+			if existing.Source == event.Source && existing.ExternalID == event.ExternalID {
+				event.ID = existing.ID
+				return false
+			}
+		}
+	}
+	event.ID = fmt.Sprintf("evt_%d", len(m.events)+1)
 	m.events = append(m.events, *event)
-	return nil
+	return true
 }
 
 func (m *mockEventStore) GetRecentEvents(limit int) ([]models.DashboardEvent, error) {
@@ -75,7 +98,7 @@ func (m *mockEventStore) GetMonthlyStats(year, month int) (models.MonthlyStats,
 func TestWebhookHandler_ValidPayload(t *testing.T) {
 	mockRepo := &mockEventStore{}
 	registry := transformers.NewRegistry()
-	handler := NewWebhookHandler(mockRepo, registry)
+	handler := NewWebhookHandler(NewIngester(mockRepo, registry, nil), nil)
 
 	payload := models.QStashPayload{
 		EventType: "github.push",
@@ -110,7 +133,7 @@ func TestWebhookHandler_ValidPayload(t *testing.T) {
 func TestWebhookHandler_InvalidJSON(t *testing.T) {
 	mockRepo := &mockEventStore{}
 	registry := transformers.NewRegistry()
-	handler := NewWebhookHandler(mockRepo, registry)
+	handler := NewWebhookHandler(NewIngester(mockRepo, registry, nil), nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader([]byte("invalid json")))
 	req.Header.Set("Content-Type", "application/json")
@@ -130,7 +153,7 @@ func TestWebhookHandler_InvalidJSON(t *testing.T) {
 func TestWebhookHandler_UnknownEventType(t *testing.T) {
 	mockRepo := &mockEventStore{}
 	registry := transformers.NewRegistry()
-	handler := NewWebhookHandler(mockRepo, registry)
+	handler := NewWebhookHandler(NewIngester(mockRepo, registry, nil), nil)
 
 	payload := models.QStashPayload{
 		EventType: "unknown.event",
@@ -159,7 +182,7 @@ func TestWebhookHandler_DatabaseError(t *testing.T) {
 		insertErr: errors.New("database connection failed"),
 	}
 	registry := transformers.NewRegistry()
-	handler := NewWebhookHandler(mockRepo, registry)
+	handler := NewWebhookHandler(NewIngester(mockRepo, registry, nil), nil)
 
 	payload := models.QStashPayload{
 		EventType: "github.push",
@@ -187,7 +210,7 @@ func TestWebhookHandler_DatabaseError(t *testing.T) {
 func TestWebhookHandler_UsesPayloadTimestamp(t *testing.T) {
 	mockRepo := &mockEventStore{}
 	registry := transformers.NewRegistry()
-	handler := NewWebhookHandler(mockRepo, registry)
+	handler := NewWebhookHandler(NewIngester(mockRepo, registry, nil), nil)
 
 	expectedTime := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
 	payload := models.QStashPayload{
@@ -225,7 +248,7 @@ func TestWebhookHandler_UsesPayloadTimestamp(t *testing.T) {
 func TestWebhookHandler_FallbackToCurrentTime(t *testing.T) {
 	mockRepo := &mockEventStore{}
 	registry := transformers.NewRegistry()
-	handler := NewWebhookHandler(mockRepo, registry)
+	handler := NewWebhookHandler(NewIngester(mockRepo, registry, nil), nil)
 
 	beforeTest := time.Now().UTC().Add(-time.Second)
 
@@ -263,3 +286,305 @@ func TestWebhookHandler_FallbackToCurrentTime(t *testing.T) {
 		t.Errorf("expected timestamp to be around current time, got %v", insertedEvent.CreatedAt)
 	}
 }
+
+// qstashSignature builds a valid Upstash-Signature token for body
+func qstashSignature(key string, body []byte) string {
+	sum := sha256.Sum256(body)
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims := fmt.Sprintf(`{"iss":"Upstash","exp":%d,"nbf":%d,"body":%q}`,
+		time.Now().Add(time.Minute).Unix(), time.Now().Unix(), base64.RawURLEncoding.EncodeToString(sum[:]))
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookHandler_QStashSignature(t *testing.T) {
+	verifier := &webhooks.QStashVerifier{CurrentSigningKey: "current", NextSigningKey: "next"}
+	body := []byte(`{"type":"github.push","timestamp":1705314600,"event":{"ref":"refs/heads/main","repository":{"name":"test"},"commits":[{"id":"abc"}]}}`)
+
+	tests := []struct {
+		name           string
+		signature      string
+		expectedStatus int
+		expectedCalls  int
+	}{
+		{name: "valid current key", signature: qstashSignature("current", body), expectedStatus: http.StatusOK, expectedCalls: 1},
+		{name: "valid next key", signature: qstashSignature("next", body), expectedStatus: http.StatusOK, expectedCalls: 1},
+		{name: "wrong key", signature: qstashSignature("attacker", body), expectedStatus: http.StatusUnauthorized},
+		{name: "missing signature", signature: "", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockEventStore{}
+			handler := NewWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), verifier)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+			if tt.signature != "" {
+				req.Header.Set("Upstash-Signature", tt.signature)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if mockRepo.insertCalls != tt.expectedCalls {
+				t.Errorf("expected %d insert calls, got %d", tt.expectedCalls, mockRepo.insertCalls)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_PermanentFailureFromQStash(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), nil)
+
+	body, _ := json.Marshal(models.QStashPayload{EventType: "unknown.event", Event: json.RawMessage(`{}`)})
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+	req.Header.Set("Upstash-Message-Id", "msg_123")
+	req.Header.Set("Upstash-Retried", "2")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != 489 {
+		t.Errorf("expected status 489, got %d", rec.Code)
+	}
+
+	if rec.Header().Get("Upstash-NonRetryable-Error") != "true" {
+		t.Error("expected Upstash-NonRetryable-Error header")
+	}
+}
+
+func TestWebhookHandler_TransientDatabaseError(t *testing.T) {
+	mockRepo := &mockEventStore{
+		insertErr: errors.New("dial tcp: connection refused"),
+	}
+	handler := NewWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), nil)
+
+	body, _ := json.Marshal(models.QStashPayload{
+		EventType: "github.push",
+		Event:     json.RawMessage(`{"ref":"refs/heads/main","repository":{"name":"test"},"commits":[{"id":"abc"}]}`),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+	req.Header.Set("Upstash-Message-Id", "msg_123")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", rec.Code)
+	}
+
+	if rec.Header().Get("Upstash-NonRetryable-Error") != "" {
+		t.Error("transient failures must stay retryable")
+	}
+}
+
+func TestWebhookHandler_DuplicateDeliveryAcknowledged(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), nil)
+
+	body, _ := json.Marshal(models.QStashPayload{
+		EventType: "github.push",
+		Event:     json.RawMessage(`{"ref":"refs/heads/main","repository":{"name":"test"},"commits":[{"id":"abc"}]}`),
+	})
+
+	var responses []IngestResponse
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+		req.Header.Set("Upstash-Message-Id", "msg_dup")
+		req.Header.Set("Upstash-Retried", fmt.Sprint(i))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("delivery %d: expected status 200, got %d", i, rec.Code)
+		}
+
+		var resp IngestResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if len(mockRepo.events) != 1 {
+		t.Errorf("expected 1 stored event, got %d", len(mockRepo.events))
+	}
+
+	if responses[0].Duplicate || !responses[1].Duplicate {
+		t.Errorf("expected only the second delivery to be a duplicate, got %+v", responses)
+	}
+
+	if responses[0].ID == "" || responses[0].ID != responses[1].ID {
+		t.Errorf("expected both responses to return the stored event ID, got %+v", responses)
+	}
+
+	if mockRepo.events[0].Source != "qstash" || mockRepo.events[0].ExternalID != "msg_dup" {
+		t.Errorf("expected qstash/msg_dup identity, got %s/%s", mockRepo.events[0].Source, mockRepo.events[0].ExternalID)
+	}
+}
+
+func TestWebhookHandler_ArchivesRawPayload(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), nil)
+
+	event := json.RawMessage(`{"ref":"refs/heads/main","repository":{"name":"test"},"head_commit":{"message":"Test","author":{"name":"User"}},"commits":[{"id":"abc"}]}`)
+	body, _ := json.Marshal(models.QStashPayload{EventType: "github.push", Event: event})
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	payload := mockRepo.events[0].Payload
+	if payload == nil {
+		t.Fatal("expected raw payload to be attached to the event")
+	}
+	if payload.EventType != "github.push" {
+		t.Errorf("expected payload event type github.push, got %s", payload.EventType)
+	}
+	if string(payload.Data) != string(event) {
+		t.Errorf("expected archived payload %s, got %s", event, payload.Data)
+	}
+}
+
+// mockQueue records queued events and can simulate a full queue
+type mockQueue struct {
+	events []models.DashboardEvent
+	err    error
+}
+
+func (q *mockQueue) Enqueue(events []models.DashboardEvent) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.events = append(q.events, events...)
+	return nil
+}
+
+func TestWebhookHandler_AsyncQueuesEvent(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	queue := &mockQueue{}
+	ingester := NewIngester(mockRepo, transformers.NewRegistry(), nil)
+	ingester.SetQueue(queue)
+	handler := NewWebhookHandler(ingester, nil)
+
+	body := []byte(`{"type":"github.push","timestamp":1718452800,"event":{"ref":"refs/heads/main","repository":{"name":"test"},"head_commit":{"message":"Test","author":{"name":"User"}},"commits":[]}}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+	req.Header.Set("Upstash-Message-Id", "msg_async")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d", rec.Code)
+	}
+	if len(mockRepo.events) != 0 {
+		t.Errorf("expected no synchronous insert, got %d", len(mockRepo.events))
+	}
+	if len(queue.events) != 1 {
+		t.Fatalf("expected 1 queued event, got %d", len(queue.events))
+	}
+
+	queued := queue.events[0]
+	var resp IngestResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ID == "" || resp.ID != queued.ID || !resp.Queued {
+		t.Errorf("expected response to carry queued event ID %q, got %+v", queued.ID, resp)
+	}
+	if queued.ExternalID != "msg_async" || queued.Payload == nil {
+		t.Errorf("expected delivery identity and payload on queued event, got %+v", queued)
+	}
+}
+
+func TestWebhookHandler_AsyncQueueFull(t *testing.T) {
+	ingester := NewIngester(&mockEventStore{}, transformers.NewRegistry(), nil)
+	ingester.SetQueue(&mockQueue{err: ingest.ErrQueueFull})
+	handler := NewWebhookHandler(ingester, nil)
+
+	body := []byte(`{"type":"github.push","event":{"ref":"refs/heads/main","repository":{"name":"test"},"head_commit":{"message":"Test","author":{"name":"User"}},"commits":[]}}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header when the queue is full")
+	}
+}
+
+func TestWebhookHandler_MultipleEventsStoredTogether(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), nil)
+
+	body := []byte(`{"type":"github.push","event":{"ref":"refs/heads/main","repository":{"name":"test"},"commits":[{"id":"a","message":"one"},{"id":"b","message":"two"}]}}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+	req.Header.Set("Upstash-Message-Id", "msg_multi")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+	if mockRepo.insertCalls != 1 {
+		t.Errorf("expected one atomic insert, got %d calls", mockRepo.insertCalls)
+	}
+	if len(mockRepo.events) != 2 {
+		t.Fatalf("expected 2 stored events, got %d", len(mockRepo.events))
+	}
+	if mockRepo.events[0].ExternalID != "msg_multi:0" || mockRepo.events[1].ExternalID != "msg_multi:1" {
+		t.Errorf("expected positional external IDs, got %s and %s", mockRepo.events[0].ExternalID, mockRepo.events[1].ExternalID)
+	}
+	if mockRepo.events[0].Payload != mockRepo.events[1].Payload {
+		t.Error("expected events of one delivery to share the archived payload")
+	}
+
+	var resp IngestResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.ID != "evt_1" || len(resp.IDs) != 2 || resp.Duplicate {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestWebhookHandler_IgnoredActionStoresNothing(t *testing.T) {
+	mockRepo := &mockEventStore{}
+	handler := NewWebhookHandler(NewIngester(mockRepo, transformers.NewRegistry(), nil), nil)
+
+	body := []byte(`{"type":"github.pr","event":{"action":"labeled","number":7,"pull_request":{},"repository":{"name":"test"}}}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected status 202, got %d", rec.Code)
+	}
+	if mockRepo.insertCalls != 0 {
+		t.Errorf("expected no insert, got %d calls", mockRepo.insertCalls)
+	}
+
+	var resp IngestResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !resp.Ignored {
+		t.Errorf("expected ignored response, got %+v", resp)
+	}
+}
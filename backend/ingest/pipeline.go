@@ -0,0 +1,412 @@
+// Package ingest buffers transformed events and writes them to the database in
+// batches, so webhook requests are acknowledged without waiting on an INSERT.
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+var (
+	// ErrQueueFull is returned by Enqueue when the pipeline cannot take more events
+	ErrQueueFull = errors.New("ingest queue is full")
+	// ErrClosed is returned by Enqueue after Shutdown has started
+	ErrClosed = errors.New("ingest pipeline is shut down")
+)
+
+// Backoff between flush attempts while the database is unavailable
+const (
+	initialFlushBackoff = 500 * time.Millisecond
+	maxFlushBackoff     = 30 * time.Second
+)
+
+// Config controls queue size, batching and durability
+type Config struct {
+	QueueSize     int           // Deliveries buffered before Enqueue reports ErrQueueFull
+	Workers       int           // Concurrent flushers
+	BatchSize     int           // Events per database round trip (a delivery is never split)
+	FlushInterval time.Duration // Maximum time an event waits for its batch to fill
+	SpoolDir      string        // Accepted events are written here until stored; empty keeps them in memory only
+}
+
+// withDefaults fills unset fields with values suited to a single small instance
+func (c Config) withDefaults() Config {
+	if c.QueueSize <= 0 {
+		c.QueueSize = 1000
+	}
+	if c.Workers <= 0 {
+		c.Workers = 2
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = 500 * time.Millisecond
+	}
+	return c
+}
+
+// Stats is a point-in-time snapshot of the pipeline's queue and counters
+type Stats struct {
+	QueueDepth    int        `json:"queue_depth"` // Queued deliveries
+	QueueCapacity int        `json:"queue_capacity"`
+	Workers       int        `json:"workers"`
+	BatchSize     int        `json:"batch_size"`
+	Durable       bool       `json:"durable"`
+	Enqueued      int64      `json:"enqueued"`
+	Recovered     int64      `json:"recovered"` // Loaded from the spool at startup
+	Rejected      int64      `json:"rejected"`  // Refused with ErrQueueFull
+	Inserted      int64      `json:"inserted"`
+	Duplicates    int64      `json:"duplicates"`
+	DeadLettered  int64      `json:"dead_lettered"`
+	Batches       int64      `json:"batches"`
+	FlushErrors   int64      `json:"flush_errors"`
+	LastFlushAt   *time.Time `json:"last_flush_at,omitempty"`
+}
+
+// item is the events of one delivery and the spool file that backs them, if any.
+// An item is stored or dead-lettered as a unit.
+type item struct {
+	events    []models.DashboardEvent
+	spoolPath string
+}
+
+// Pipeline accepts events into a bounded queue and flushes them in batches from
+// a pool of workers. Events that can never be stored go to the dead-letter store.
+type Pipeline struct {
+	store       database.BatchEventStore
+	deadLetters database.DeadLetterStore
+	cfg         Config
+	log         *logger.Logger
+
+	queue   chan item
+	closing chan struct{} // Closed when Shutdown starts, releases the spool loader
+	mu      sync.RWMutex  // Guards closed and sending on queue
+	closed  bool
+	wg      sync.WaitGroup
+
+	// stop aborts flush retries once the shutdown deadline has passed
+	stop       context.Context
+	cancelStop context.CancelFunc
+	shutdown   sync.Once
+
+	enqueued     atomic.Int64
+	recovered    atomic.Int64
+	rejected     atomic.Int64
+	inserted     atomic.Int64
+	duplicates   atomic.Int64
+	deadLettered atomic.Int64
+	batches      atomic.Int64
+	flushErrors  atomic.Int64
+	lastFlush    atomic.Int64 // Unix nanoseconds
+}
+
+// New creates a pipeline. deadLetters may be nil, in which case events that can
+// never be stored are only logged (and kept in the spool when one is configured).
+func New(store database.BatchEventStore, deadLetters database.DeadLetterStore, cfg Config, log *logger.Logger) (*Pipeline, error) {
+	cfg = cfg.withDefaults()
+
+	if cfg.SpoolDir != "" {
+		if err := os.MkdirAll(cfg.SpoolDir, 0o750); err != nil {
+			return nil, fmt.Errorf("failed to create spool directory: %w", err)
+		}
+	}
+
+	stop, cancelStop := context.WithCancel(context.Background())
+	return &Pipeline{
+		store:       store,
+		deadLetters: deadLetters,
+		cfg:         cfg,
+		log:         log,
+		queue:       make(chan item, cfg.QueueSize),
+		closing:     make(chan struct{}),
+		stop:        stop,
+		cancelStop:  cancelStop,
+	}, nil
+}
+
+// Start launches the workers and re-queues any events left in the spool by a
+// previous process
+func (p *Pipeline) Start() {
+	for i := 0; i < p.cfg.Workers; i++ {
+		p.wg.Add(1)
+		go p.worker()
+	}
+
+	// The spool is scanned before Start returns so that its cleanup of temporary
+	// files cannot race with events enqueued afterwards
+	if p.cfg.SpoolDir != "" {
+		items, err := readSpool(p.cfg.SpoolDir)
+		if err != nil {
+			p.log.Error().Err(err).Str("spool_dir", p.cfg.SpoolDir).Msg("failed to read ingest spool")
+		}
+		if len(items) > 0 {
+			p.log.Info().Int("deliveries", len(items)).Msg("recovering spooled events")
+			go p.recoverSpool(items)
+		}
+	}
+}
+
+// Enqueue accepts the events of one delivery for asynchronous storage. Every event
+// must have an ID. When a spool directory is configured the events are on disk
+// before Enqueue returns.
+func (p *Pipeline) Enqueue(events []models.DashboardEvent) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrClosed
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	for _, event := range events {
+		if event.ID == "" {
+			return fmt.Errorf("event has no ID")
+		}
+	}
+
+	// Cheap check first so an overloaded queue does not also cost a disk write
+	if len(p.queue) >= cap(p.queue) {
+		p.rejected.Add(1)
+		return ErrQueueFull
+	}
+
+	it := item{events: events}
+	if p.cfg.SpoolDir != "" {
+		path, err := writeSpool(p.cfg.SpoolDir, events)
+		if err != nil {
+			return fmt.Errorf("failed to spool event: %w", err)
+		}
+		it.spoolPath = path
+	}
+
+	select {
+	case p.queue <- it:
+		p.enqueued.Add(int64(len(events)))
+		return nil
+	default:
+		// Lost the race for the last slot
+		p.removeSpool(it)
+		p.rejected.Add(1)
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting events and waits for the queue to drain. If ctx ends
+// first, pending flush retries are abandoned; spooled events survive for the next start.
+func (p *Pipeline) Shutdown(ctx context.Context) error {
+	p.shutdown.Do(func() {
+		close(p.closing)
+		p.mu.Lock()
+		p.closed = true
+		close(p.queue)
+		p.mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.cancelStop()
+		return nil
+	case <-ctx.Done():
+		pending := len(p.queue)
+		p.cancelStop()
+		<-done
+		return fmt.Errorf("ingest drain incomplete with %d deliveries queued: %w", pending, ctx.Err())
+	}
+}
+
+// Stats returns the current queue depth and counters
+func (p *Pipeline) Stats() Stats {
+	stats := Stats{
+		QueueDepth:    len(p.queue),
+		QueueCapacity: cap(p.queue),
+		Workers:       p.cfg.Workers,
+		BatchSize:     p.cfg.BatchSize,
+		Durable:       p.cfg.SpoolDir != "",
+		Enqueued:      p.enqueued.Load(),
+		Recovered:     p.recovered.Load(),
+		Rejected:      p.rejected.Load(),
+		Inserted:      p.inserted.Load(),
+		Duplicates:    p.duplicates.Load(),
+		DeadLettered:  p.deadLettered.Load(),
+		Batches:       p.batches.Load(),
+		FlushErrors:   p.flushErrors.Load(),
+	}
+	if last := p.lastFlush.Load(); last != 0 {
+		t := time.Unix(0, last).UTC()
+		stats.LastFlushAt = &t
+	}
+	return stats
+}
+
+// worker collects events into batches and flushes them when full or when the
+// flush interval elapses. It drains the queue after Shutdown closes it.
+func (p *Pipeline) worker() {
+	defer p.wg.Done()
+
+	var batch []item
+	batchEvents := 0
+	ticker := time.NewTicker(p.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case it, ok := <-p.queue:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+			batch = append(batch, it)
+			batchEvents += len(it.events)
+			if batchEvents >= p.cfg.BatchSize {
+				p.flush(batch)
+				batch, batchEvents = nil, 0
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				p.flush(batch)
+				batch, batchEvents = nil, 0
+			}
+		}
+	}
+}
+
+// flush stores a batch, retrying while the database is unavailable. A batch that
+// fails for any other reason is split so one bad event cannot block the rest.
+func (p *Pipeline) flush(batch []item) {
+	if len(batch) == 0 {
+		return
+	}
+
+	var events []models.DashboardEvent
+	for _, it := range batch {
+		events = append(events, it.events...)
+	}
+
+	backoff := initialFlushBackoff
+	for {
+		inserted, err := p.store.InsertEventBatch(events)
+		if err == nil {
+			p.batches.Add(1)
+			p.inserted.Add(int64(inserted))
+			p.duplicates.Add(int64(len(events) - inserted))
+			p.lastFlush.Store(time.Now().UnixNano())
+			for _, it := range batch {
+				p.removeSpool(it)
+			}
+			return
+		}
+		p.flushErrors.Add(1)
+
+		if !database.IsTransientError(err) {
+			if len(batch) == 1 {
+				p.deadLetter(batch[0], err)
+				return
+			}
+			p.log.Warn().Err(err).Int("batch_size", len(batch)).Msg("batch insert failed, retrying deliveries individually")
+			for _, it := range batch {
+				p.flush([]item{it})
+			}
+			return
+		}
+
+		p.log.Warn().Err(err).Int("batch_size", len(batch)).Dur("backoff", backoff).Msg("database unavailable, retrying batch")
+		select {
+		case <-time.After(backoff):
+		case <-p.stop.Done():
+			p.log.Error().
+				Int("batch_size", len(batch)).
+				Bool("spooled", p.cfg.SpoolDir != "").
+				Msg("shutdown deadline reached before batch was stored")
+			return
+		}
+		backoff *= 2
+		if backoff > maxFlushBackoff {
+			backoff = maxFlushBackoff
+		}
+	}
+}
+
+// deadLetter moves a delivery whose events can never be stored to the dead-letter store
+func (p *Pipeline) deadLetter(it item, cause error) {
+	event := it.events[0]
+	log := p.log.WithFields(map[string]interface{}{
+		"event_id":   event.ID,
+		"event_type": event.EventType,
+		"events":     len(it.events),
+	})
+
+	if p.deadLetters == nil || event.Payload == nil {
+		log.Error().Err(cause).Msg("failed to store event and no dead-letter store is available")
+		return
+	}
+
+	dl := models.DeadLetter{
+		Source:     event.Source,
+		DeliveryID: event.ExternalID,
+		EventType:  event.Payload.EventType,
+		Payload:    string(event.Payload.Data),
+		Error:      cause.Error(),
+		ReceivedAt: event.Payload.ReceivedAt,
+	}
+	if err := p.deadLetters.InsertDeadLetter(&dl); err != nil {
+		log.Error().Err(err).AnErr("cause", cause).Msg("failed to store dead letter for queued event")
+		return
+	}
+
+	p.deadLettered.Add(1)
+	p.removeSpool(it)
+	log.Warn().Err(cause).Str("dead_letter_id", dl.ID).Msg("queued event moved to dead-letter store")
+}
+
+// recoverSpool re-queues deliveries accepted by a previous process but never stored
+func (p *Pipeline) recoverSpool(items []item) {
+	for _, it := range items {
+		if !p.requeue(it) {
+			return
+		}
+		p.recovered.Add(int64(len(it.events)))
+	}
+}
+
+// requeue blocks until the item is queued, returning false once shutdown starts
+func (p *Pipeline) requeue(it item) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return false
+	}
+	select {
+	case p.queue <- it:
+		return true
+	case <-p.closing:
+		return false
+	}
+}
+
+// removeSpool deletes the spool file of a stored or rejected event
+func (p *Pipeline) removeSpool(it item) {
+	if it.spoolPath == "" {
+		return
+	}
+	if err := os.Remove(it.spoolPath); err != nil && !os.IsNotExist(err) {
+		p.log.Warn().Err(err).Str("path", it.spoolPath).Msg("failed to remove spool file")
+	}
+}
@@ -0,0 +1,334 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+// fakeBatchStore records batches and deduplicates by Source/ExternalID
+type fakeBatchStore struct {
+	mu      sync.Mutex
+	batches [][]models.DashboardEvent
+	seen    map[string]bool
+	failN   int   // Fail this many calls with failErr before succeeding
+	failErr error // Returned while failN > 0
+	badID   string
+}
+
+func (f *fakeBatchStore) InsertEventBatch(events []models.DashboardEvent) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failN > 0 {
+		f.failN--
+		return 0, f.failErr
+	}
+	for _, e := range events {
+		if e.ID == f.badID {
+			return 0, errors.New("value too long for type character varying(500)")
+		}
+	}
+
+	if f.seen == nil {
+		f.seen = make(map[string]bool)
+	}
+	inserted := 0
+	for _, e := range events {
+		key := e.Source + "/" + e.ExternalID
+		if e.ExternalID != "" && f.seen[key] {
+			continue
+		}
+		f.seen[key] = true
+		inserted++
+	}
+	f.batches = append(f.batches, append([]models.DashboardEvent(nil), events...))
+	return inserted, nil
+}
+
+func (f *fakeBatchStore) stored() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, b := range f.batches {
+		n += len(b)
+	}
+	return n
+}
+
+// fakeDeadLetters captures dead letters
+type fakeDeadLetters struct {
+	mu      sync.Mutex
+	letters []models.DeadLetter
+}
+
+func (f *fakeDeadLetters) InsertDeadLetter(dl *models.DeadLetter) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	dl.ID = "dl_1"
+	f.letters = append(f.letters, *dl)
+	return nil
+}
+
+func (f *fakeDeadLetters) ListDeadLetters(models.DeadLetterFilter) ([]models.DeadLetter, int, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeDeadLetters) GetDeadLetter(string) (models.DeadLetter, error) {
+	return models.DeadLetter{}, nil
+}
+
+func (f *fakeDeadLetters) RecordReplayFailure(string, string) error { return nil }
+
+func (f *fakeDeadLetters) DeleteDeadLetter(string) error { return nil }
+
+func (f *fakeDeadLetters) PurgeDeadLetters(time.Time) (int64, error) { return 0, nil }
+
+func testEvent(id string) models.DashboardEvent {
+	return models.DashboardEvent{
+		ID:         id,
+		EventType:  "github.push",
+		Title:      "Push to heimdall",
+		Metadata:   map[string]interface{}{"repo": "heimdall"},
+		CreatedAt:  time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC),
+		Source:     "github",
+		ExternalID: "delivery-" + id,
+		Payload: &models.RawPayload{
+			EventType:  "github.push",
+			Data:       json.RawMessage(`{"ref":"refs/heads/main"}`),
+			ReceivedAt: time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+// testDelivery wraps a single test event as a delivery
+func testDelivery(id string) []models.DashboardEvent {
+	return []models.DashboardEvent{testEvent(id)}
+}
+
+func newTestPipeline(t *testing.T, store *fakeBatchStore, dls database.DeadLetterStore, cfg Config) *Pipeline {
+	t.Helper()
+	p, err := New(store, dls, cfg, logger.New(false))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return p
+}
+
+func TestPipeline_FlushesFullBatches(t *testing.T) {
+	store := &fakeBatchStore{}
+	p := newTestPipeline(t, store, nil, Config{Workers: 1, BatchSize: 3, FlushInterval: time.Hour})
+	p.Start()
+
+	for _, id := range []string{"a", "b", "c"} {
+		if err := p.Enqueue(testDelivery(id)); err != nil {
+			t.Fatalf("Enqueue(%s): %v", id, err)
+		}
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for store.stored() < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if len(store.batches) != 1 || len(store.batches[0]) != 3 {
+		t.Fatalf("expected one batch of 3 events, got %v", store.batches)
+	}
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if stats := p.Stats(); stats.Inserted != 3 || stats.Batches != 1 {
+		t.Errorf("expected 3 inserted in 1 batch, got %+v", stats)
+	}
+}
+
+func TestPipeline_ShutdownDrainsQueue(t *testing.T) {
+	store := &fakeBatchStore{}
+	p := newTestPipeline(t, store, nil, Config{Workers: 2, BatchSize: 100, FlushInterval: time.Hour})
+	p.Start()
+
+	for i := 0; i < 10; i++ {
+		if err := p.Enqueue(testDelivery(string(rune('a' + i)))); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if store.stored() != 10 {
+		t.Errorf("expected 10 events stored on shutdown, got %d", store.stored())
+	}
+	if err := p.Enqueue(testDelivery("late")); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed after shutdown, got %v", err)
+	}
+}
+
+func TestPipeline_Backpressure(t *testing.T) {
+	store := &fakeBatchStore{}
+	// Workers are never started, so the queue only fills
+	p := newTestPipeline(t, store, nil, Config{QueueSize: 2})
+
+	if err := p.Enqueue(testDelivery("a")); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if err := p.Enqueue(testDelivery("b")); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if err := p.Enqueue(testDelivery("c")); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+
+	stats := p.Stats()
+	if stats.QueueDepth != 2 || stats.QueueCapacity != 2 || stats.Rejected != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestPipeline_CountsDuplicates(t *testing.T) {
+	store := &fakeBatchStore{}
+	p := newTestPipeline(t, store, nil, Config{Workers: 1})
+	p.Start()
+
+	first := testEvent("a")
+	redelivery := testEvent("b")
+	redelivery.ExternalID = first.ExternalID
+	_ = p.Enqueue([]models.DashboardEvent{first})
+	_ = p.Enqueue([]models.DashboardEvent{redelivery})
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if stats := p.Stats(); stats.Inserted != 1 || stats.Duplicates != 1 {
+		t.Errorf("expected 1 inserted and 1 duplicate, got %+v", stats)
+	}
+}
+
+func TestPipeline_RetriesTransientFailures(t *testing.T) {
+	store := &fakeBatchStore{failN: 1, failErr: errors.New("dial tcp: connection refused")}
+	p := newTestPipeline(t, store, nil, Config{Workers: 1})
+	p.Start()
+
+	_ = p.Enqueue(testDelivery("a"))
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if store.stored() != 1 {
+		t.Errorf("expected event stored after retry, got %d", store.stored())
+	}
+	if stats := p.Stats(); stats.FlushErrors != 1 {
+		t.Errorf("expected 1 flush error, got %d", stats.FlushErrors)
+	}
+}
+
+func TestPipeline_DeadLettersPermanentFailures(t *testing.T) {
+	store := &fakeBatchStore{badID: "bad"}
+	dls := &fakeDeadLetters{}
+	p := newTestPipeline(t, store, dls, Config{Workers: 1, BatchSize: 10, FlushInterval: time.Hour})
+	p.Start()
+
+	_ = p.Enqueue(testDelivery("good"))
+	_ = p.Enqueue(testDelivery("bad"))
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if store.stored() != 1 {
+		t.Errorf("expected the good event to be stored individually, got %d", store.stored())
+	}
+	if len(dls.letters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(dls.letters))
+	}
+	dl := dls.letters[0]
+	if dl.Source != "github" || dl.DeliveryID != "delivery-bad" || dl.EventType != "github.push" {
+		t.Errorf("unexpected dead letter identity: %+v", dl)
+	}
+	if dl.Payload != `{"ref":"refs/heads/main"}` {
+		t.Errorf("expected raw payload in dead letter, got %s", dl.Payload)
+	}
+}
+
+func TestPipeline_SpoolSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	// First process accepts events but never flushes them
+	first := newTestPipeline(t, &fakeBatchStore{}, nil, Config{SpoolDir: dir})
+	for _, id := range []string{"a", "b"} {
+		if err := first.Enqueue(testDelivery(id)); err != nil {
+			t.Fatalf("Enqueue: %v", err)
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("expected 2 spool files, got %d", len(files))
+	}
+	// A crash mid-write leaves only a temporary file behind
+	if err := os.WriteFile(filepath.Join(dir, "c.json.tmp"), []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Second process recovers them
+	store := &fakeBatchStore{}
+	second := newTestPipeline(t, store, nil, Config{SpoolDir: dir, Workers: 1, FlushInterval: 10 * time.Millisecond})
+	second.Start()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for store.stored() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if err := second.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if store.stored() != 2 {
+		t.Fatalf("expected 2 recovered events stored, got %d", store.stored())
+	}
+	recovered := store.batches[0][0]
+	if recovered.Payload == nil || string(recovered.Payload.Data) != `{"ref":"refs/heads/main"}` {
+		t.Errorf("expected payload to survive the spool, got %+v", recovered.Payload)
+	}
+	if stats := second.Stats(); stats.Recovered != 2 {
+		t.Errorf("expected 2 recovered events, got %d", stats.Recovered)
+	}
+
+	remaining, _ := os.ReadDir(dir)
+	if len(remaining) != 0 {
+		t.Errorf("expected spool to be empty after flush, found %d files", len(remaining))
+	}
+}
+
+func TestPipeline_DeliveryFlushedAsUnit(t *testing.T) {
+	dir := t.TempDir()
+	store := &fakeBatchStore{}
+	p := newTestPipeline(t, store, nil, Config{Workers: 1, BatchSize: 2, FlushInterval: time.Hour, SpoolDir: dir})
+	p.Start()
+
+	delivery := []models.DashboardEvent{testEvent("a"), testEvent("b"), testEvent("c")}
+	for i := range delivery {
+		delivery[i].Payload = delivery[0].Payload
+	}
+	if err := p.Enqueue(delivery); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if len(store.batches) != 1 || len(store.batches[0]) != 3 {
+		t.Fatalf("expected the delivery in a single batch, got %v", store.batches)
+	}
+	if stats := p.Stats(); stats.Enqueued != 3 || stats.Inserted != 3 {
+		t.Errorf("expected 3 events enqueued and inserted, got %+v", stats)
+	}
+}
@@ -0,0 +1,146 @@
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+const (
+	spoolExt     = ".json"
+	spoolTempExt = ".tmp"
+)
+
+// spoolRecord is the on-disk form of a queued delivery. DashboardEvent never
+// serializes its payload, so the payload the events share is stored alongside them.
+type spoolRecord struct {
+	Events  []models.DashboardEvent `json:"events"`
+	Payload *spoolPayload           `json:"payload,omitempty"`
+}
+
+type spoolPayload struct {
+	ReceivedAt time.Time       `json:"received_at"`
+	EventType  string          `json:"event_type"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// writeSpool durably stores a delivery as <dir>/<first event ID>.json. The file is
+// written under a temporary name and renamed once synced, so readers never see
+// partial files.
+func writeSpool(dir string, events []models.DashboardEvent) (string, error) {
+	event := events[0]
+	record := spoolRecord{Events: events}
+	if event.Payload != nil {
+		record.Payload = &spoolPayload{
+			ReceivedAt: event.Payload.ReceivedAt,
+			EventType:  event.Payload.EventType,
+			Data:       event.Payload.Data,
+		}
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal spool record: %w", err)
+	}
+
+	// Event IDs are generated UUIDs; reject anything that could escape the directory
+	if event.ID != filepath.Base(event.ID) {
+		return "", fmt.Errorf("invalid event ID for spool: %q", event.ID)
+	}
+	path := filepath.Join(dir, event.ID+spoolExt)
+	tmpPath := path + spoolTempExt
+
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return "", err
+	}
+
+	// Persist the rename itself
+	if d, err := os.Open(dir); err == nil {
+		//nolint:errcheck // Best effort: not every filesystem supports syncing directories
+		d.Sync()
+		d.Close()
+	}
+
+	return path, nil
+}
+
+// readSpool loads every complete spool record in dir. Leftover temporary files
+// belong to events that were never acknowledged and are removed.
+func readSpool(dir string) ([]item, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []item
+	var errs []string
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+
+		if strings.HasSuffix(name, spoolTempExt) {
+			os.Remove(path)
+			continue
+		}
+		if entry.IsDir() || !strings.HasSuffix(name, spoolExt) {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+
+		var record spoolRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+
+		if len(record.Events) == 0 {
+			os.Remove(path)
+			continue
+		}
+		if record.Payload != nil {
+			payload := &models.RawPayload{
+				ReceivedAt: record.Payload.ReceivedAt,
+				EventType:  record.Payload.EventType,
+				Data:       record.Payload.Data,
+			}
+			for i := range record.Events {
+				record.Events[i].Payload = payload
+			}
+		}
+		items = append(items, item{events: record.Events, spoolPath: path})
+	}
+
+	if len(errs) > 0 {
+		return items, fmt.Errorf("unreadable spool files: %s", strings.Join(errs, "; "))
+	}
+	return items, nil
+}
@@ -9,12 +9,16 @@ import (
 	"syscall"
 	"time"
 
+	"heimdall-backend/categories"
 	"heimdall-backend/config"
 	"heimdall-backend/database"
 	"heimdall-backend/handlers"
+	"heimdall-backend/ingest"
 	"heimdall-backend/logger"
 	"heimdall-backend/middleware"
+	"heimdall-backend/monitor"
 	"heimdall-backend/transformers"
+	"heimdall-backend/webhooks"
 
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
@@ -59,14 +63,133 @@ func main() {
 
 	// Initialize dependencies
 	eventRepo := database.NewEventRepository(db)
+	deadLetterRepo := database.NewDeadLetterRepository(db)
 	transformerRegistry := transformers.NewRegistry()
 
+	// Events are categorized when stored; a rules file replaces the built-in taxonomy
+	categorizer := categories.Default()
+	if cfg.CategoryRulesFile != "" {
+		taxonomy, err := categories.LoadTaxonomy(cfg.CategoryRulesFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load category rules")
+		}
+		if categorizer, err = categories.New(*taxonomy); err != nil {
+			log.Fatal().Err(err).Msg("failed to configure category rules")
+		}
+		log.Info().Int("rules", len(taxonomy.Rules)).Msg("loaded category rules")
+	}
+	eventRepo.SetCategorizer(categorizer)
+
+	// Stored events follow the loaded taxonomy, so edited rules also apply to existing rows
+	recategorizeCtx, cancelRecategorize := context.WithTimeout(context.Background(), 5*time.Minute)
+	if updated, err := eventRepo.RecategorizeEvents(recategorizeCtx); err != nil {
+		log.Error().Err(err).Msg("failed to recategorize stored events")
+	} else if updated > 0 {
+		log.Info().Int64("events", updated).Msg("recategorized stored events")
+	}
+	cancelRecategorize()
+
+	// Declarative sources are registered before any handler can use the registry
+	var customSources []*webhooks.CustomSource
+	if cfg.CustomSourcesFile != "" {
+		mappingConfig, err := transformers.LoadMappingConfig(cfg.CustomSourcesFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load custom sources")
+		}
+		if err := transformerRegistry.RegisterMappings(mappingConfig); err != nil {
+			log.Fatal().Err(err).Msg("failed to register custom sources")
+		}
+		for _, sourceConfig := range mappingConfig.Sources {
+			source, err := webhooks.NewCustomSource(sourceConfig)
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to configure custom source")
+			}
+			customSources = append(customSources, source)
+		}
+		log.Info().Int("sources", len(customSources)).Msg("loaded custom webhook sources")
+	}
+	ingester := handlers.NewIngester(eventRepo, transformerRegistry, deadLetterRepo)
+
+	// Optionally acknowledge webhooks after enqueue and store them in batches. Events
+	// are acknowledged before they are stored, so they must be spooled to disk first.
+	var pipeline *ingest.Pipeline
+	if cfg.IngestAsync {
+		if cfg.IngestSpoolDir == "" {
+			log.Fatal().Msg("INGEST_ASYNC requires INGEST_SPOOL_DIR - acknowledged events would only be kept in memory")
+		}
+		pipeline, err = ingest.New(eventRepo, deadLetterRepo, ingest.Config{
+			QueueSize:     cfg.IngestQueueSize,
+			Workers:       cfg.IngestWorkers,
+			BatchSize:     cfg.IngestBatchSize,
+			FlushInterval: cfg.IngestFlushInterval,
+			SpoolDir:      cfg.IngestSpoolDir,
+		}, log)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to create ingest pipeline")
+		}
+		pipeline.Start()
+		ingester.SetQueue(pipeline)
+
+		stats := pipeline.Stats()
+		log.Info().
+			Int("queue_size", stats.QueueCapacity).
+			Int("workers", stats.Workers).
+			Int("batch_size", stats.BatchSize).
+			Str("spool_dir", cfg.IngestSpoolDir).
+			Msg("asynchronous ingestion enabled")
+	}
+
+	// Probe the configured URLs and record their state changes
+	var monitorTargets []monitor.Target
+	if cfg.MonitorsFile != "" {
+		monitorConfig, err := monitor.LoadConfig(cfg.MonitorsFile)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to load monitors")
+		}
+		monitorTargets = monitorConfig.Targets
+		log.Info().Int("targets", len(monitorTargets)).Msg("uptime monitor enabled")
+	}
+	uptimeMonitor := monitor.New(eventRepo, monitorTargets, log)
+	uptimeMonitor.Start()
+
 	// Create handlers
 	healthHandler := handlers.NewHealthHandler(cfg)
 	eventsHandler := handlers.NewEventsHandler(eventRepo)
 	statsHandler := handlers.NewStatsHandler(eventRepo, log)
 	wrappedHandler := handlers.NewWrappedHandler(eventRepo, log)
-	webhookHandler := handlers.NewWebhookHandler(eventRepo, transformerRegistry)
+	monitorsHandler := handlers.NewMonitorsHandler(uptimeMonitor)
+	incidentsHandler := handlers.NewIncidentsHandler(eventRepo)
+	categoriesHandler := handlers.NewCategoriesHandler(categorizer)
+	pipelinesHandler := handlers.NewPipelinesHandler(eventRepo)
+	doraHandler := handlers.NewDORAHandler(eventRepo)
+	deploymentStatsHandler := handlers.NewDeploymentStatsHandler(eventRepo)
+	pullRequestStatsHandler := handlers.NewPullRequestStatsHandler(eventRepo)
+	qstashVerifier := &webhooks.QStashVerifier{
+		CurrentSigningKey: cfg.QStashCurrentSigningKey,
+		NextSigningKey:    cfg.QStashNextSigningKey,
+	}
+	webhookHandler := handlers.NewWebhookHandler(ingester, qstashVerifier)
+
+	// Native provider endpoints verify their own signatures instead of relying on the edge route.
+	// A provider without a secret is not mounted, so a missing variable cannot turn off verification.
+	nativeSources := []struct {
+		source    webhooks.Source
+		secretEnv string
+		secret    string
+	}{
+		{&webhooks.GitHubSource{Secret: cfg.GitHubWebhookSecret}, "GITHUB_WEBHOOK_SECRET", cfg.GitHubWebhookSecret},
+		{&webhooks.VercelSource{Secret: cfg.VercelWebhookSecret}, "VERCEL_WEBHOOK_SECRET", cfg.VercelWebhookSecret},
+		{&webhooks.RailwaySource{Token: cfg.RailwayWebhookToken}, "RAILWAY_WEBHOOK_TOKEN", cfg.RailwayWebhookToken},
+		{&webhooks.SentrySource{Secret: cfg.SentryClientSecret}, "SENTRY_CLIENT_SECRET", cfg.SentryClientSecret},
+		{&webhooks.GitLabSource{Token: cfg.GitLabWebhookToken}, "GITLAB_WEBHOOK_TOKEN", cfg.GitLabWebhookToken},
+		{&webhooks.NetlifySource{Secret: cfg.NetlifyWebhookSecret}, "NETLIFY_WEBHOOK_SECRET", cfg.NetlifyWebhookSecret},
+		{&webhooks.RenderSource{Secret: cfg.RenderWebhookSecret}, "RENDER_WEBHOOK_SECRET", cfg.RenderWebhookSecret},
+		{&webhooks.LinearSource{Secret: cfg.LinearWebhookSecret}, "LINEAR_WEBHOOK_SECRET", cfg.LinearWebhookSecret},
+		{&webhooks.JiraSource{Secret: cfg.JiraWebhookSecret}, "JIRA_WEBHOOK_SECRET", cfg.JiraWebhookSecret},
+		{&webhooks.PagerDutySource{Secret: cfg.PagerDutyWebhookSecret}, "PAGERDUTY_WEBHOOK_SECRET", cfg.PagerDutyWebhookSecret},
+		{&webhooks.DockerHubSource{Token: cfg.DockerHubWebhookToken}, "DOCKERHUB_WEBHOOK_TOKEN", cfg.DockerHubWebhookToken},
+	}
+	deadLetterHandler := handlers.NewDeadLetterHandler(deadLetterRepo, ingester)
 
 	// Create rate limiter for webhook endpoint (stricter limits for writes)
 	webhookRateLimiter := middleware.NewRateLimiter(cfg.RateLimitRPS, cfg.RateLimitBurst)
@@ -87,8 +210,66 @@ func main() {
 	api.Handle("/events", readRateLimiter.Limit(eventsHandler)).Methods("GET", "OPTIONS")
 	api.Handle("/stats", readRateLimiter.Limit(statsHandler)).Methods("GET", "OPTIONS")
 	api.PathPrefix("/wrapped/").Handler(readRateLimiter.Limit(wrappedHandler)).Methods("GET", "OPTIONS")
-	// Apply stricter rate limiting to webhook endpoint
-	api.Handle("/webhook", webhookRateLimiter.Limit(webhookHandler)).Methods("POST", "OPTIONS")
+	api.Handle("/monitors", readRateLimiter.Limit(monitorsHandler)).Methods("GET", "OPTIONS")
+	api.Handle("/incidents", readRateLimiter.Limit(incidentsHandler)).Methods("GET", "OPTIONS")
+	api.Handle("/categories", readRateLimiter.Limit(categoriesHandler)).Methods("GET", "OPTIONS")
+	api.Handle("/pipelines/{sha}", readRateLimiter.Limit(pipelinesHandler)).Methods("GET", "OPTIONS")
+	api.Handle("/metrics/dora", readRateLimiter.Limit(doraHandler)).Methods("GET", "OPTIONS")
+	api.Handle("/deployments/stats", readRateLimiter.Limit(deploymentStatsHandler)).Methods("GET", "OPTIONS")
+	api.Handle("/pull-requests/stats", readRateLimiter.Limit(pullRequestStatsHandler)).Methods("GET", "OPTIONS")
+	if pipeline != nil {
+		api.Handle("/metrics/ingest", readRateLimiter.Limit(handlers.NewIngestMetricsHandler(pipeline))).Methods("GET", "OPTIONS")
+	}
+	// Apply stricter rate limiting to webhook endpoint. Like the native providers, the
+	// QStash endpoint is only mounted without signing keys when unsigned webhooks are allowed.
+	switch {
+	case qstashVerifier.Enabled():
+		api.Handle("/webhook", webhookRateLimiter.Limit(webhookHandler)).Methods("POST", "OPTIONS")
+	case cfg.AllowUnsignedWebhooks:
+		log.Warn().Msg("QSTASH_CURRENT_SIGNING_KEY not set - /api/webhook accepts unsigned requests (ALLOW_UNSIGNED_WEBHOOKS)")
+		api.Handle("/webhook", webhookRateLimiter.Limit(webhookHandler)).Methods("POST", "OPTIONS")
+	default:
+		log.Info().Msg("QSTASH_CURRENT_SIGNING_KEY not set - /api/webhook disabled")
+	}
+	for _, native := range nativeSources {
+		source := native.source
+		if native.secret == "" {
+			if !cfg.AllowUnsignedWebhooks {
+				log.Info().Str("source", source.Name()).Msg(native.secretEnv + " not set - provider endpoint disabled")
+				continue
+			}
+			log.Warn().Str("source", source.Name()).Msg(native.secretEnv + " not set - accepting unsigned deliveries (ALLOW_UNSIGNED_WEBHOOKS)")
+			source = webhooks.Unsigned(source)
+		}
+		handler := handlers.NewProviderWebhookHandler(ingester, source)
+		api.Handle("/webhook/"+source.Name(), webhookRateLimiter.Limit(handler)).Methods("POST", "OPTIONS")
+	}
+	for _, custom := range customSources {
+		var source webhooks.Source = custom
+		if !custom.Authenticated() {
+			if !cfg.AllowUnsignedWebhooks {
+				log.Info().Str("source", custom.Name()).Msg("custom source has no auth - endpoint disabled")
+				continue
+			}
+			log.Warn().Str("source", custom.Name()).Msg("custom source has no auth - accepting unsigned deliveries (ALLOW_UNSIGNED_WEBHOOKS)")
+			source = webhooks.Unsigned(custom)
+		}
+		handler := handlers.NewProviderWebhookHandler(ingester, source)
+		api.Handle("/webhook/custom/"+custom.Slug(), webhookRateLimiter.Limit(handler)).Methods("POST", "OPTIONS")
+	}
+
+	// Admin routes require a bearer token and are not mounted without one
+	if cfg.AdminToken != "" {
+		admin := api.PathPrefix("/admin").Subrouter()
+		admin.Use(middleware.RequireToken(cfg.AdminToken))
+		admin.HandleFunc("/dead-letters", deadLetterHandler.List).Methods("GET")
+		admin.HandleFunc("/dead-letters", deadLetterHandler.Purge).Methods("DELETE")
+		admin.HandleFunc("/dead-letters/{id}", deadLetterHandler.Get).Methods("GET")
+		admin.HandleFunc("/dead-letters/{id}", deadLetterHandler.Delete).Methods("DELETE")
+		admin.HandleFunc("/dead-letters/{id}/replay", deadLetterHandler.Replay).Methods("POST")
+	} else {
+		log.Warn().Msg("ADMIN_TOKEN not set - admin routes are disabled")
+	}
 
 	// Create server with timeouts
 	srv := &http.Server{
@@ -123,5 +304,19 @@ func main() {
 		log.Error().Err(err).Msg("server forced to shutdown")
 	}
 
+	// Stop probing before the database connection goes away
+	if err := uptimeMonitor.Shutdown(ctx); err != nil {
+		log.Error().Err(err).Msg("uptime monitor did not stop in time")
+	}
+
+	// Flush queued events once no new requests can arrive
+	if pipeline != nil {
+		if err := pipeline.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("ingest queue not fully drained")
+		} else {
+			log.Info().Msg("ingest queue drained")
+		}
+	}
+
 	log.Info().Msg("server exited gracefully")
 }
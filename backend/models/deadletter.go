@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// DeadLetter is a webhook delivery that could not be turned into a dashboard event
+type DeadLetter struct {
+	ReceivedAt    time.Time         `json:"received_at"` // Event timestamp passed to the transformer
+	CreatedAt     time.Time         `json:"created_at"`
+	LastAttemptAt time.Time         `json:"last_attempt_at"`
+	Headers       map[string]string `json:"headers,omitempty"`
+	ID            string            `json:"id"`
+	Source        string            `json:"source"`
+	DeliveryID    string            `json:"delivery_id,omitempty"`
+	EventType     string            `json:"event_type"`
+	Payload       string            `json:"payload,omitempty"` // Raw event body, omitted from list responses
+	Error         string            `json:"error"`
+	Attempts      int               `json:"attempts"`
+}
+
+// DeadLetterFilter contains parameters for listing dead letters
+type DeadLetterFilter struct {
+	Source    string // Filter by source (optional)
+	EventType string // Filter by event type (optional)
+	Limit     int    // Max entries to return (default 50, max 500)
+	Offset    int    // Pagination offset (default 0)
+}
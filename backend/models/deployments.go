@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// DeploymentRun is one deploy from its first reported state to the state that
+// finished it, paired by provider deployment ID
+type DeploymentRun struct {
+	EventType       string    `json:"event_type"`
+	Project         string    `json:"project"`
+	Environment     string    `json:"environment"`
+	DeploymentID    string    `json:"deployment_id"`
+	Status          string    `json:"status"` // SUCCESS, FAILED or CANCELED
+	CommitSHA       string    `json:"commit_sha,omitempty"`
+	URL             string    `json:"url,omitempty"`
+	StartedAt       time.Time `json:"started_at"`
+	FinishedAt      time.Time `json:"finished_at"`
+	DurationSeconds *int64    `json:"duration_seconds,omitempty"` // Reported by the provider or start to finish; nil for a lone state
+}
+
+// DeploymentStats summarizes deploy outcomes and build durations over a period
+type DeploymentStats struct {
+	Runs                int      `json:"runs"`
+	Succeeded           int      `json:"succeeded"`
+	Failed              int      `json:"failed"` // Failed or crashed
+	Canceled            int      `json:"canceled"`
+	SuccessRate         *float64 `json:"success_rate,omitempty"` // Succeeded out of succeeded and failed
+	DurationP50Seconds  *int64   `json:"duration_p50_seconds,omitempty"`
+	DurationP90Seconds  *int64   `json:"duration_p90_seconds,omitempty"`
+	DurationP95Seconds  *int64   `json:"duration_p95_seconds,omitempty"`
+	DurationMaxSeconds  *int64   `json:"duration_max_seconds,omitempty"`
+	DurationSampleCount int      `json:"duration_sample_count"` // Runs with a known duration
+}
+
+// DeploymentTrend compares a week with the week before it
+type DeploymentTrend struct {
+	RunsChange               int      `json:"runs_change"`
+	SuccessRateChange        *float64 `json:"success_rate_change,omitempty"`
+	DurationP50ChangeSeconds *int64   `json:"duration_p50_change_seconds,omitempty"`
+}
+
+// DeploymentWeek is the deploy stats of one week, starting Monday 00:00 UTC
+type DeploymentWeek struct {
+	WeekStart string `json:"week_start"` // YYYY-MM-DD
+	DeploymentStats
+	Trend *DeploymentTrend `json:"trend,omitempty"` // Nil for the first week
+}
+
+// DeploymentStatsGroup is the deploy stats of one project and environment
+type DeploymentStatsGroup struct {
+	Project                string           `json:"project"`
+	Environment            string           `json:"environment"`
+	Summary                DeploymentStats  `json:"summary"`
+	LongestFailureStreak   int              `json:"longest_failure_streak"` // Most consecutive failures; cancellations do not break a streak
+	CurrentFailureStreak   int              `json:"current_failure_streak"` // Failures since the last success
+	CurrentStreakStartedAt *time.Time       `json:"current_streak_started_at,omitempty"`
+	Slowest                []DeploymentRun  `json:"slowest"`
+	Weeks                  []DeploymentWeek `json:"weeks"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Deployment is one finished deploy, reduced from the lifecycle events that share
+// its provider deployment ID
+type Deployment struct {
+	EventType    string     // vercel.deploy, railway.deploy, ...
+	Project      string     // Project or service name
+	Environment  string     // Deploy environment, "production" when the provider sends none
+	DeploymentID string     // Provider deployment ID
+	Status       string     // SUCCESS or FAILED
+	CommitSHA    string     // Commit the deploy was built from, empty when unknown
+	FinishedAt   time.Time  // When the deploy succeeded or failed
+	CommittedAt  *time.Time // First push or merge of CommitSHA, nil when none was recorded
+}
+
+// DORAMetrics are the four DORA key metrics over a period
+type DORAMetrics struct {
+	Deployments          int      `json:"deployments"`        // Successful deploys
+	FailedDeployments    int      `json:"failed_deployments"` // Deploys that failed or crashed
+	DeploysPerWeek       float64  `json:"deploys_per_week"`
+	LeadTimeSeconds      *int64   `json:"lead_time_seconds,omitempty"` // Median commit to successful deploy
+	ChangeFailureRate    *float64 `json:"change_failure_rate,omitempty"`
+	Restores             int      `json:"restores"`                          // Failures followed by a successful deploy
+	TimeToRestoreSeconds *int64   `json:"time_to_restore_seconds,omitempty"` // Median failure to next success
+}
+
+// DORAWeek is the metrics of one week, starting Monday 00:00 UTC
+type DORAWeek struct {
+	WeekStart string `json:"week_start"` // YYYY-MM-DD
+	DORAMetrics
+}
+
+// DORAGroup is the metrics of one project and environment
+type DORAGroup struct {
+	Project     string      `json:"project"`
+	Environment string      `json:"environment"`
+	Summary     DORAMetrics `json:"summary"`
+	Weeks       []DORAWeek  `json:"weeks"`
+}
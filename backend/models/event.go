@@ -19,12 +19,42 @@ type DashboardEvent struct {
 	ID        string                 `json:"id"`
 	EventType string                 `json:"event_type"`
 	Title     string                 `json:"title"`
+	// Category and Subcategory are assigned from the event type when the event is stored
+	Category    string `json:"category,omitempty"`
+	Subcategory string `json:"subcategory,omitempty"`
+	// Source and ExternalID identify the provider delivery for deduplication
+	Source     string `json:"source,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+	// Payload is the original transformer input, archived on insert (never serialized)
+	Payload *RawPayload `json:"-"`
+}
+
+// RawPayload is an archived webhook payload that produced one or more events
+type RawPayload struct {
+	ReceivedAt time.Time       // Timestamp passed to the transformer
+	EventType  string          // Registry event type the payload was transformed with
+	Data       json.RawMessage // Transformer input, verbatim
+}
+
+// ArchivedEvent pairs a stored event with the payload it was transformed from
+type ArchivedEvent struct {
+	Event     DashboardEvent
+	PayloadID string // Shared by all events produced by the same delivery
+	Payload   RawPayload
+}
+
+// ArchiveFilter selects archived events for reprocessing
+type ArchiveFilter struct {
+	Since     time.Time // Only events created at or after this time (optional)
+	Until     time.Time // Only events created before this time (optional)
+	EventType string    // Registry event type of the payload (optional)
 }
 
 // EventsFilter contains parameters for filtering events
 type EventsFilter struct {
 	Since     time.Time // Filter events after this time (optional)
 	EventType string    // Filter by event type (optional)
+	Category  string    // Filter by stored category (optional)
 	Limit     int       // Max events to return (default 50, max 500)
 	Offset    int       // Pagination offset (default 0)
 }
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// IncidentEvent is a stored incident.* event reduced to the lifecycle step it records
+type IncidentEvent struct {
+	IncidentID string    // Provider incident ID from the event metadata
+	Action     string    // "triggered", "acknowledged", "escalated", "resolved" or "reopened"
+	Number     int       // Incident number shown by the provider
+	Title      string    // Incident title
+	Service    string    // Service the incident was opened on
+	Urgency    string    // "high" or "low"
+	Assignee   string    // First assignee at the time of the event
+	URL        string    // Link to the incident
+	CreatedAt  time.Time // When the step happened
+}
+
+// Incident pairs the lifecycle events of one incident
+type Incident struct {
+	ID             string     `json:"id"`
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	Service        string     `json:"service"`
+	Urgency        string     `json:"urgency"`
+	Assignee       string     `json:"assignee"`
+	URL            string     `json:"url"`
+	Status         string     `json:"status"` // "triggered", "acknowledged" or "resolved"
+	Escalations    int        `json:"escalations"`
+	TriggeredAt    time.Time  `json:"triggered_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	TTASeconds     *int64     `json:"tta_seconds,omitempty"` // Time to acknowledge
+	TTRSeconds     *int64     `json:"ttr_seconds,omitempty"` // Time to resolve
+}
+
+// IncidentSummary aggregates the incidents of a window
+type IncidentSummary struct {
+	Total        int    `json:"total"`
+	Open         int    `json:"open"`
+	Acknowledged int    `json:"acknowledged"`
+	Resolved     int    `json:"resolved"`
+	HighUrgency  int    `json:"high_urgency"`
+	MeanTTA      *int64 `json:"mean_tta_seconds,omitempty"`
+	MeanTTR      *int64 `json:"mean_ttr_seconds,omitempty"`
+}
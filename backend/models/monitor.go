@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// MonitorCheck is a stored monitoring.check event reduced to the state change it records
+type MonitorCheck struct {
+	Monitor   string    // Monitor name from the event metadata
+	State     string    // "up", "degraded" or "down"
+	CreatedAt time.Time // When the monitor entered State
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// CommitEvent is a stored event that references a commit, reduced to the fields
+// used to place it in a pipeline
+type CommitEvent struct {
+	ID              string
+	EventType       string
+	Title           string
+	Action          string // PR, MR and release action
+	Kind            string // CI event kind: workflow_run, workflow_job, check_suite or pipeline
+	Merged          bool   // PR or MR was merged
+	Status          string // Deploy status or CI conclusion
+	Environment     string // Deploy environment
+	DeploymentID    string // Provider deployment ID, shared by the states of one deploy
+	Repo            string
+	Branch          string
+	URL             string // Link to the deploy, run, PR or release
+	CommitSHA       string // Commit the event was built from or refers to
+	MergeCommitSHA  string // Commit created by merging a PR or MR
+	DurationSeconds int64  // Reported run or deploy duration, zero when unknown
+	CreatedAt       time.Time
+}
+
+// Pipeline is the path of one commit from push to production
+type Pipeline struct {
+	SHA                 string          `json:"sha"`
+	Repo                string          `json:"repo,omitempty"`
+	Branch              string          `json:"branch,omitempty"`
+	Stages              []PipelineStage `json:"stages"`
+	StartedAt           time.Time       `json:"started_at"`
+	ReachedProductionAt *time.Time      `json:"reached_production_at,omitempty"`
+	LeadTimeSeconds     *int64          `json:"lead_time_seconds,omitempty"` // Start to first successful production deploy
+}
+
+// PipelineStage is one step of a pipeline: a push, merge, CI run, artifact, deploy or release
+type PipelineStage struct {
+	Stage                string    `json:"stage"`
+	EventType            string    `json:"event_type"`
+	Title                string    `json:"title"`
+	Status               string    `json:"status,omitempty"`
+	Environment          string    `json:"environment,omitempty"`
+	URL                  string    `json:"url,omitempty"`
+	MatchedBy            string    `json:"matched_by"` // "commit", "merge_commit" or "branch" (releases only)
+	StartedAt            time.Time `json:"started_at"`
+	FinishedAt           time.Time `json:"finished_at"`
+	DurationSeconds      int64     `json:"duration_seconds"`
+	SincePreviousSeconds *int64    `json:"since_previous_seconds,omitempty"` // From the previous stage's finish; unset when this stage started before it
+	EventIDs             []string  `json:"event_ids"`
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// PullRequestEvent is a stored PR lifecycle or review event, reduced to the
+// fields used to rebuild the PR's history
+type PullRequestEvent struct {
+	EventType string // github.pr, github.review, github.review_comment or github.comment
+	Action    string
+	Repo      string
+	Number    int
+	Author    string // PR author
+	Actor     string // Reviewer or commenter; the author for github.pr
+	Merged    bool   // github.pr only
+	URL       string // PR URL, github.pr only
+	CreatedAt time.Time
+}
+
+// PullRequest is the lifecycle of one PR rebuilt from its events
+type PullRequest struct {
+	Repo       string
+	Number     int
+	Author     string
+	URL        string
+	State      string                // open, merged or closed
+	OpenedAt   *time.Time            // First opened; nil when opened before events were recorded
+	Intervals  []PullRequestInterval // Periods the PR was open, oldest first; never empty
+	ReviewedAt []time.Time           // Reviews, review comments and comments by others, oldest first
+}
+
+// PullRequestInterval is one period a PR was open, from being opened or reopened
+// until it was next merged or closed
+type PullRequestInterval struct {
+	OpenedAt *time.Time // Nil when the PR was opened before events were recorded
+	ClosedAt *time.Time // Nil while the PR is open
+	Merged   bool
+}
+
+// PullRequestStats summarizes PR cycle times over a period
+type PullRequestStats struct {
+	Opened                      int      `json:"opened"`
+	Merged                      int      `json:"merged"`
+	Closed                      int      `json:"closed"`                // Closed without merging
+	MergeRatio                  *float64 `json:"merge_ratio,omitempty"` // Merged out of merged and closed
+	TimeToMergeP50Seconds       *int64   `json:"time_to_merge_p50_seconds,omitempty"`
+	TimeToMergeP90Seconds       *int64   `json:"time_to_merge_p90_seconds,omitempty"`
+	TimeToFirstReviewP50Seconds *int64   `json:"time_to_first_review_p50_seconds,omitempty"`
+	Reviews                     int      `json:"reviews"`
+	Open                        int      `json:"open"` // Open at the end of the period
+	OpenAgeP50Seconds           *int64   `json:"open_age_p50_seconds,omitempty"`
+	OldestOpenAgeSeconds        *int64   `json:"oldest_open_age_seconds,omitempty"`
+}
+
+// PullRequestMonth is the PR stats of one calendar month (UTC)
+type PullRequestMonth struct {
+	Month string `json:"month"` // YYYY-MM
+	PullRequestStats
+}
+
+// PullRequestGroup is the PR stats of one repo or author
+type PullRequestGroup struct {
+	Name    string             `json:"name"`
+	Summary PullRequestStats   `json:"summary"`
+	Months  []PullRequestMonth `json:"months"`
+}
@@ -0,0 +1,125 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Defaults for optional target fields
+const (
+	defaultInterval       = time.Minute
+	defaultTimeout        = 10 * time.Second
+	defaultExpectedStatus = http.StatusOK
+	minInterval           = 5 * time.Second
+)
+
+// Config is the monitors file: the list of targets to probe
+type Config struct {
+	Targets []Target `json:"targets"`
+}
+
+// Target is one URL probed on a fixed interval. A probe is "down" when the request
+// fails or returns a status other than ExpectedStatus, and "degraded" when it
+// succeeds but takes longer than LatencyThreshold.
+type Target struct {
+	Name             string   `json:"name"`
+	URL              string   `json:"url"`
+	Method           string   `json:"method,omitempty"`            // Default GET
+	Interval         Duration `json:"interval,omitempty"`          // Default 1m
+	Timeout          Duration `json:"timeout,omitempty"`           // Default 10s
+	ExpectedStatus   int      `json:"expected_status,omitempty"`   // Default 200
+	LatencyThreshold Duration `json:"latency_threshold,omitempty"` // Zero disables the degraded state
+}
+
+// Duration is a time.Duration written in JSON as a Go duration string ("30s", "2m")
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON writes the duration as a string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadConfig reads and validates a monitors file, filling in defaults
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from trusted configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monitors file: %w", err)
+	}
+
+	var cfg Config
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse monitors file %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid monitors file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks every target and fills in defaults
+func (c *Config) Validate() error {
+	names := make(map[string]bool, len(c.Targets))
+	for i := range c.Targets {
+		target := &c.Targets[i]
+		if target.Name == "" {
+			return fmt.Errorf("monitor %d: name is required", i)
+		}
+		if names[target.Name] {
+			return fmt.Errorf("monitor %q: duplicate name", target.Name)
+		}
+		names[target.Name] = true
+
+		parsed, err := url.Parse(target.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("monitor %q: url must be an absolute http or https URL", target.Name)
+		}
+
+		if target.Method == "" {
+			target.Method = http.MethodGet
+		}
+		if target.Interval == 0 {
+			target.Interval = Duration(defaultInterval)
+		}
+		if time.Duration(target.Interval) < minInterval {
+			return fmt.Errorf("monitor %q: interval must be at least %s", target.Name, minInterval)
+		}
+		if target.Timeout == 0 {
+			target.Timeout = Duration(defaultTimeout)
+		}
+		if target.Timeout < 0 || target.Timeout > target.Interval {
+			return fmt.Errorf("monitor %q: timeout must be positive and no longer than the interval", target.Name)
+		}
+		if target.ExpectedStatus == 0 {
+			target.ExpectedStatus = defaultExpectedStatus
+		}
+		if target.ExpectedStatus < 100 || target.ExpectedStatus > 599 {
+			return fmt.Errorf("monitor %q: expected_status %d is not an HTTP status", target.Name, target.ExpectedStatus)
+		}
+		if target.LatencyThreshold < 0 {
+			return fmt.Errorf("monitor %q: latency_threshold must not be negative", target.Name)
+		}
+	}
+	return nil
+}
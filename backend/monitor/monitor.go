@@ -0,0 +1,357 @@
+// Package monitor probes HTTP endpoints on an interval and records a
+// monitoring.check event whenever a target changes state, so outages appear in
+// the feed next to the deploys and errors around them.
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"heimdall-backend/database"
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+// State is a target's health as of its latest probe
+type State string
+
+// Target states. Degraded targets respond correctly but slowly and count as available.
+const (
+	StateUnknown  State = "unknown"
+	StateUp       State = "up"
+	StateDegraded State = "degraded"
+	StateDown     State = "down"
+)
+
+// maxBodyDrain bounds how much of a response body is read so connections can be reused
+const maxBodyDrain = 64 << 10
+
+// uptimeWindows are the periods reported by Status, keyed by their JSON name
+var uptimeWindows = []struct {
+	name   string
+	period time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+}
+
+// TargetStatus is a target's current state and uptime, as served by /api/monitors
+type TargetStatus struct {
+	Name          string              `json:"name"`
+	URL           string              `json:"url"`
+	State         State               `json:"state"`
+	Since         *time.Time          `json:"since,omitempty"` // When the target entered State
+	LastCheckedAt *time.Time          `json:"last_checked_at,omitempty"`
+	LatencyMs     int64               `json:"latency_ms"`
+	StatusCode    int                 `json:"status_code,omitempty"`
+	Error         string              `json:"error,omitempty"`
+	Uptime        map[string]*float64 `json:"uptime"` // Percent available per window, null without data
+}
+
+// result is the outcome of one probe
+type result struct {
+	state      State
+	statusCode int
+	latency    time.Duration
+	err        string
+	checkedAt  time.Time
+}
+
+// targetState tracks what has been recorded for a target and its latest probe
+type targetState struct {
+	recorded State     // State of the last stored monitoring.check event
+	since    time.Time // When recorded was entered
+	last     *result
+}
+
+// Monitor runs one prober goroutine per target
+type Monitor struct {
+	store   database.MonitorStore
+	targets []Target
+	client  *http.Client
+	log     *logger.Logger
+
+	mu     sync.Mutex // Guards states
+	states map[string]*targetState
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a monitor for targets, which must already be validated
+func New(store database.MonitorStore, targets []Target, log *logger.Logger) *Monitor {
+	states := make(map[string]*targetState, len(targets))
+	for _, target := range targets {
+		states[target.Name] = &targetState{recorded: StateUnknown}
+	}
+
+	return &Monitor{
+		store:   store,
+		targets: targets,
+		client:  &http.Client{},
+		log:     log,
+		states:  states,
+	}
+}
+
+// Start loads each target's last recorded state, so a restart does not record
+// a change that did not happen, and starts probing
+func (m *Monitor) Start() {
+	if len(m.targets) == 0 {
+		return
+	}
+
+	checks, err := m.store.GetMonitorChecks(time.Now())
+	if err != nil {
+		m.log.Warn().Err(err).Msg("failed to load monitor history - first probes will record their state")
+	}
+	for _, check := range checks {
+		if st, ok := m.states[check.Monitor]; ok {
+			st.recorded = State(check.State)
+			st.since = check.CreatedAt
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	for _, target := range m.targets {
+		m.wg.Add(1)
+		go m.run(ctx, target)
+	}
+}
+
+// Shutdown stops probing and waits for in-flight probes to finish
+func (m *Monitor) Shutdown(ctx context.Context) error {
+	if m.cancel == nil {
+		return nil
+	}
+	m.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run probes target immediately and then on every interval until ctx is cancelled
+func (m *Monitor) run(ctx context.Context, target Target) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(time.Duration(target.Interval))
+	defer ticker.Stop()
+
+	for {
+		res := m.probe(ctx, target)
+		if ctx.Err() != nil {
+			return
+		}
+		m.record(target, res)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// probe requests target once and classifies the response
+func (m *Monitor) probe(ctx context.Context, target Target) result {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(target.Timeout))
+	defer cancel()
+
+	res := result{checkedAt: time.Now().UTC()}
+	req, err := http.NewRequestWithContext(ctx, target.Method, target.URL, http.NoBody)
+	if err != nil {
+		res.state = StateDown
+		res.err = err.Error()
+		return res
+	}
+	req.Header.Set("User-Agent", "heimdall-monitor")
+
+	start := time.Now()
+	resp, err := m.client.Do(req)
+	res.latency = time.Since(start)
+	if err != nil {
+		res.state = StateDown
+		res.err = err.Error()
+		return res
+	}
+	//nolint:errcheck // Draining is best effort
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyDrain))
+	resp.Body.Close()
+
+	res.statusCode = resp.StatusCode
+	switch {
+	case resp.StatusCode != target.ExpectedStatus:
+		res.state = StateDown
+		res.err = fmt.Sprintf("unexpected status %d (expected %d)", resp.StatusCode, target.ExpectedStatus)
+	case target.LatencyThreshold > 0 && res.latency > time.Duration(target.LatencyThreshold):
+		res.state = StateDegraded
+	default:
+		res.state = StateUp
+	}
+	return res
+}
+
+// record keeps the latest probe and stores an event when the state changed. A failed
+// insert leaves the recorded state alone so the next probe tries again.
+func (m *Monitor) record(target Target, res result) {
+	m.mu.Lock()
+	st := m.states[target.Name]
+	st.last = &res
+	previous := st.recorded
+	m.mu.Unlock()
+
+	if res.state == previous {
+		return
+	}
+
+	event := checkEvent(target, res, previous)
+	if _, err := m.store.InsertEvents([]models.DashboardEvent{event}); err != nil {
+		m.log.Error().Err(err).Str("monitor", target.Name).Msg("failed to record monitor state change")
+		return
+	}
+
+	m.mu.Lock()
+	st.recorded = res.state
+	st.since = res.checkedAt
+	m.mu.Unlock()
+
+	m.log.Info().
+		Str("monitor", target.Name).
+		Str("state", string(res.state)).
+		Str("previous_state", string(previous)).
+		Msg("monitor state changed")
+}
+
+// checkEvent builds the monitoring.check event for a state change
+func checkEvent(target Target, res result, previous State) models.DashboardEvent {
+	latencyMs := res.latency.Milliseconds()
+
+	var title string
+	switch res.state {
+	case StateDown:
+		title = fmt.Sprintf("%s is down: %s", target.Name, res.err)
+	case StateDegraded:
+		title = fmt.Sprintf("%s is degraded: %dms exceeds the %dms threshold",
+			target.Name, latencyMs, time.Duration(target.LatencyThreshold).Milliseconds())
+	default:
+		if previous == StateDown || previous == StateDegraded {
+			title = fmt.Sprintf("%s recovered (%dms)", target.Name, latencyMs)
+		} else {
+			title = fmt.Sprintf("%s is up (%dms)", target.Name, latencyMs)
+		}
+	}
+
+	return models.DashboardEvent{
+		EventType: "monitoring.check",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"monitor":              target.Name,
+			"url":                  target.URL,
+			"state":                string(res.state),
+			"previous_state":       string(previous),
+			"status_code":          res.statusCode,
+			"expected_status":      target.ExpectedStatus,
+			"latency_ms":           latencyMs,
+			"latency_threshold_ms": time.Duration(target.LatencyThreshold).Milliseconds(),
+			"error":                res.err,
+		},
+		CreatedAt: res.checkedAt,
+		Source:    "monitor",
+	}
+}
+
+// Status reports every target's current state and its uptime over 24 hours,
+// 7 days and 30 days, computed from the recorded state changes
+func (m *Monitor) Status() ([]TargetStatus, error) {
+	now := time.Now().UTC()
+	longest := uptimeWindows[len(uptimeWindows)-1].period
+
+	checks, err := m.store.GetMonitorChecks(now.Add(-longest))
+	if err != nil {
+		return nil, err
+	}
+	history := make(map[string][]models.MonitorCheck)
+	for _, check := range checks {
+		history[check.Monitor] = append(history[check.Monitor], check)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]TargetStatus, 0, len(m.targets))
+	for _, target := range m.targets {
+		st := m.states[target.Name]
+		status := TargetStatus{
+			Name:   target.Name,
+			URL:    target.URL,
+			State:  st.recorded,
+			Uptime: make(map[string]*float64, len(uptimeWindows)),
+		}
+		if !st.since.IsZero() {
+			since := st.since
+			status.Since = &since
+		}
+		if st.last != nil {
+			checkedAt := st.last.checkedAt
+			status.LastCheckedAt = &checkedAt
+			status.LatencyMs = st.last.latency.Milliseconds()
+			status.StatusCode = st.last.statusCode
+			status.Error = st.last.err
+		}
+		for _, window := range uptimeWindows {
+			status.Uptime[window.name] = uptimePercent(history[target.Name], now.Add(-window.period), now)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// uptimePercent returns the share of [start, end) a monitor spent up or degraded,
+// rounded to two decimals. Each check's state lasts until the next check; time
+// before the first check is unknown and not counted. checks must be oldest first.
+func uptimePercent(checks []models.MonitorCheck, start, end time.Time) *float64 {
+	var available, known time.Duration
+	for i, check := range checks {
+		from, to := check.CreatedAt, end
+		if i+1 < len(checks) {
+			to = checks[i+1].CreatedAt
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if !to.After(from) {
+			continue
+		}
+
+		known += to.Sub(from)
+		if State(check.State) != StateDown {
+			available += to.Sub(from)
+		}
+	}
+
+	if known == 0 {
+		return nil
+	}
+	percent := math.Round(float64(available)/float64(known)*10000) / 100
+	return &percent
+}
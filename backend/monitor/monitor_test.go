@@ -0,0 +1,257 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"heimdall-backend/logger"
+	"heimdall-backend/models"
+)
+
+// fakeStore records inserted events and serves them back as monitor checks
+type fakeStore struct {
+	mu      sync.Mutex
+	events  []models.DashboardEvent
+	history []models.MonitorCheck
+}
+
+func (s *fakeStore) InsertEvents(events []models.DashboardEvent) ([]bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	created := make([]bool, len(events))
+	for i := range created {
+		created[i] = true
+	}
+	return created, nil
+}
+
+func (s *fakeStore) GetMonitorChecks(_ time.Time) ([]models.MonitorCheck, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.history, nil
+}
+
+func (s *fakeStore) states() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	states := make([]string, len(s.events))
+	for i, event := range s.events {
+		states[i] = event.Metadata["state"].(string)
+	}
+	return states
+}
+
+func testTarget(url string) Target {
+	return Target{
+		Name:             "api",
+		URL:              url,
+		Method:           http.MethodGet,
+		Interval:         Duration(time.Minute),
+		Timeout:          Duration(time.Second),
+		ExpectedStatus:   http.StatusOK,
+		LatencyThreshold: Duration(50 * time.Millisecond),
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "monitors.json")
+	data := `{"targets": [
+		{"name": "api", "url": "https://api.example.com/health", "interval": "30s", "latency_threshold": "750ms"},
+		{"name": "web", "url": "https://example.com", "expected_status": 204}
+	]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+
+	api := cfg.Targets[0]
+	if time.Duration(api.Interval) != 30*time.Second || time.Duration(api.LatencyThreshold) != 750*time.Millisecond {
+		t.Errorf("unexpected durations: %+v", api)
+	}
+	if api.Method != http.MethodGet || api.ExpectedStatus != http.StatusOK || time.Duration(api.Timeout) != defaultTimeout {
+		t.Errorf("expected defaults to be filled in: %+v", api)
+	}
+	if cfg.Targets[1].ExpectedStatus != http.StatusNoContent || time.Duration(cfg.Targets[1].Interval) != defaultInterval {
+		t.Errorf("unexpected web target: %+v", cfg.Targets[1])
+	}
+}
+
+func TestConfigValidate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []Target
+		want    string
+	}{
+		{"missing name", []Target{{URL: "https://example.com"}}, "name is required"},
+		{"duplicate name", []Target{{Name: "a", URL: "https://a.com"}, {Name: "a", URL: "https://b.com"}}, "duplicate name"},
+		{"relative url", []Target{{Name: "a", URL: "/health"}}, "absolute http or https URL"},
+		{"interval too short", []Target{{Name: "a", URL: "https://a.com", Interval: Duration(time.Second)}}, "interval must be at least"},
+		{"timeout longer than interval", []Target{{Name: "a", URL: "https://a.com", Timeout: Duration(2 * time.Minute)}}, "timeout"},
+		{"bad status", []Target{{Name: "a", URL: "https://a.com", ExpectedStatus: 42}}, "not an HTTP status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Targets: tt.targets}
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestProbe_ClassifiesResponses(t *testing.T) {
+	var status atomic.Int32
+	var delay atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(delay.Load()))
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	m := New(&fakeStore{}, nil, logger.New(false))
+	target := testTarget(server.URL)
+
+	status.Store(http.StatusOK)
+	if res := m.probe(context.Background(), target); res.state != StateUp {
+		t.Errorf("expected up, got %s (%s)", res.state, res.err)
+	}
+
+	status.Store(http.StatusServiceUnavailable)
+	res := m.probe(context.Background(), target)
+	if res.state != StateDown || res.statusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected down with 503, got %s/%d", res.state, res.statusCode)
+	}
+
+	status.Store(http.StatusOK)
+	delay.Store(int64(100 * time.Millisecond))
+	if res := m.probe(context.Background(), target); res.state != StateDegraded {
+		t.Errorf("expected degraded, got %s", res.state)
+	}
+
+	server.Close()
+	if res := m.probe(context.Background(), target); res.state != StateDown || res.err == "" {
+		t.Errorf("expected down with error for unreachable target, got %s", res.state)
+	}
+}
+
+func TestRecord_OnlyStateChanges(t *testing.T) {
+	store := &fakeStore{}
+	target := testTarget("https://api.example.com")
+	m := New(store, []Target{target}, logger.New(false))
+
+	for _, state := range []State{StateUp, StateUp, StateDown, StateDown, StateDegraded, StateUp} {
+		m.record(target, result{state: state, checkedAt: time.Now()})
+	}
+
+	got := strings.Join(store.states(), ",")
+	if got != "up,down,degraded,up" {
+		t.Errorf("expected one event per change, got %s", got)
+	}
+
+	store.mu.Lock()
+	last := store.events[len(store.events)-1]
+	store.mu.Unlock()
+	if last.EventType != "monitoring.check" || last.Metadata["previous_state"] != "degraded" {
+		t.Errorf("unexpected event: %+v", last)
+	}
+	if !strings.HasPrefix(last.Title, "api recovered") {
+		t.Errorf("unexpected title: %s", last.Title)
+	}
+}
+
+func TestStart_ResumesRecordedState(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	store := &fakeStore{history: []models.MonitorCheck{
+		{Monitor: "api", State: "up", CreatedAt: time.Now().Add(-time.Hour)},
+	}}
+	target := testTarget(server.URL)
+	target.LatencyThreshold = 0
+	m := New(store, []Target{target}, logger.New(false))
+
+	m.Start()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		m.mu.Lock()
+		probed := m.states["api"].last != nil
+		m.mu.Unlock()
+		if probed || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	if states := store.states(); len(states) != 0 {
+		t.Errorf("expected no event for an unchanged state after restart, got %v", states)
+	}
+}
+
+func TestUptimePercent(t *testing.T) {
+	end := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	start := end.Add(-10 * time.Hour)
+
+	tests := []struct {
+		name   string
+		checks []models.MonitorCheck
+		want   *float64
+	}{
+		{name: "no history", checks: nil, want: nil},
+		{
+			name:   "state carried in from before the window",
+			checks: []models.MonitorCheck{{State: "up", CreatedAt: start.Add(-time.Hour)}},
+			want:   floatPtr(100),
+		},
+		{
+			name: "one hour down",
+			checks: []models.MonitorCheck{
+				{State: "up", CreatedAt: start.Add(-time.Hour)},
+				{State: "down", CreatedAt: start.Add(2 * time.Hour)},
+				{State: "up", CreatedAt: start.Add(3 * time.Hour)},
+			},
+			want: floatPtr(90),
+		},
+		{
+			name: "degraded counts as available and unknown time is excluded",
+			checks: []models.MonitorCheck{
+				{State: "degraded", CreatedAt: start.Add(5 * time.Hour)},
+				{State: "down", CreatedAt: start.Add(9 * time.Hour)},
+			},
+			want: floatPtr(80),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := uptimePercent(tt.checks, start, end)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("expected nil, got %v", *got)
+			case tt.want != nil && (got == nil || *got != *tt.want):
+				t.Errorf("expected %v, got %v", *tt.want, got)
+			}
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
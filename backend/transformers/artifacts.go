@@ -0,0 +1,55 @@
+package transformers
+
+import (
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// publishedArtifact is a published image or package normalized across registries (Docker Hub, GHCR)
+type publishedArtifact struct {
+	Registry  string // dockerhub, ghcr, npm, ...
+	Image     string // Image or package name, e.g. acme/api or ghcr.io/acme/api
+	Tag       string
+	Digest    string
+	Pusher    string
+	URL       string
+	CommitSHA string
+	Branch    string
+}
+
+// artifactRegistryNames phrases registries for titles
+var artifactRegistryNames = map[string]string{
+	"dockerhub": "Docker Hub",
+	"ghcr":      "GHCR",
+}
+
+// artifactEvent builds the artifact.* event shared by the registry transformers
+func artifactEvent(eventType string, artifact publishedArtifact, timestamp time.Time) models.DashboardEvent {
+	registry := artifactRegistryNames[artifact.Registry]
+	if registry == "" {
+		registry = artifact.Registry
+	}
+	reference := artifact.Image
+	if artifact.Tag != "" {
+		reference += ":" + artifact.Tag
+	}
+
+	return models.DashboardEvent{
+		EventType: eventType,
+		Title:     fmt.Sprintf("%s pushed %s to %s", artifact.Pusher, reference, registry),
+		Metadata: map[string]interface{}{
+			"registry":   artifact.Registry,
+			"image":      artifact.Image,
+			"tag":        artifact.Tag,
+			"digest":     artifact.Digest,
+			"pusher":     artifact.Pusher,
+			"author":     artifact.Pusher,
+			"url":        artifact.URL,
+			"commit_sha": artifact.CommitSHA,
+			"branch":     artifact.Branch,
+		},
+		CreatedAt: timestamp,
+	}
+}
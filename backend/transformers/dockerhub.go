@@ -0,0 +1,56 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// TransformDockerHubPush transforms a Docker Hub repository push webhook into an
+// artifact.image event. Docker Hub does not include the digest of the pushed image.
+func TransformDockerHubPush(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var pushEvent struct {
+		PushData struct {
+			PushedAt int64  `json:"pushed_at"`
+			Pusher   string `json:"pusher"`
+			Tag      string `json:"tag"`
+		} `json:"push_data"`
+		Repository struct {
+			RepoName  string `json:"repo_name"`
+			RepoURL   string `json:"repo_url"`
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+			IsPrivate bool   `json:"is_private"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &pushEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Docker Hub event: %w", err)
+	}
+
+	repo := pushEvent.Repository
+	image := repo.RepoName
+	if image == "" {
+		image = repo.Namespace + "/" + repo.Name
+	}
+	url := "https://hub.docker.com/r/" + image
+	if pushEvent.PushData.Tag != "" {
+		url += "/tags?name=" + pushEvent.PushData.Tag
+	}
+
+	event := artifactEvent("artifact.image", publishedArtifact{
+		Registry: "dockerhub",
+		Image:    image,
+		Tag:      pushEvent.PushData.Tag,
+		Pusher:   pushEvent.PushData.Pusher,
+		URL:      url,
+	}, timestamp)
+	event.Metadata["private"] = repo.IsPrivate
+
+	// Docker Hub sends no delivery ID; a push is identified by image, tag and time
+	event.Source = "dockerhub"
+	event.ExternalID = fmt.Sprintf("%s:%s:%d", image, pushEvent.PushData.Tag, pushEvent.PushData.PushedAt)
+	return event, nil
+}
@@ -0,0 +1,49 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformDockerHubPush(t *testing.T) {
+	input := json.RawMessage(`{
+		"callback_url": "https://registry.hub.docker.com/u/acme/api/hook/abc/",
+		"push_data": {"pushed_at": 1705314600, "pusher": "ci-bot", "tag": "v1.4.0"},
+		"repository": {
+			"name": "api",
+			"namespace": "acme",
+			"repo_name": "acme/api",
+			"repo_url": "https://registry.hub.docker.com/u/acme/api/",
+			"is_private": true
+		}
+	}`)
+
+	event, err := TransformDockerHubPush(input, time.Unix(1705314600, 0).UTC())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "artifact.image" {
+		t.Errorf("expected event type artifact.image, got %s", event.EventType)
+	}
+	if expected := "ci-bot pushed acme/api:v1.4.0 to Docker Hub"; event.Title != expected {
+		t.Errorf("expected title %q, got %q", expected, event.Title)
+	}
+	if event.Source != "dockerhub" || event.ExternalID != "acme/api:v1.4.0:1705314600" {
+		t.Errorf("unexpected identity: %s/%s", event.Source, event.ExternalID)
+	}
+	expected := map[string]interface{}{
+		"registry": "dockerhub",
+		"image":    "acme/api",
+		"tag":      "v1.4.0",
+		"digest":   "",
+		"pusher":   "ci-bot",
+		"url":      "https://hub.docker.com/r/acme/api/tags?name=v1.4.0",
+		"private":  true,
+	}
+	for key, value := range expected {
+		if event.Metadata[key] != value {
+			t.Errorf("expected %s %v, got %v", key, value, event.Metadata[key])
+		}
+	}
+}
@@ -89,11 +89,13 @@ func TransformGitHubPR(eventData json.RawMessage, timestamp time.Time) (models.D
 			User  struct {
 				Login string `json:"login"`
 			} `json:"user"`
-			State   string `json:"state"`
-			HTMLURL string `json:"html_url"`
-			Merged  bool   `json:"merged"`
-			Head    struct {
+			State          string `json:"state"`
+			HTMLURL        string `json:"html_url"`
+			Merged         bool   `json:"merged"`
+			MergeCommitSHA string `json:"merge_commit_sha"`
+			Head           struct {
 				Ref string `json:"ref"`
+				SHA string `json:"sha"`
 			} `json:"head"`
 			Base struct {
 				Ref string `json:"ref"`
@@ -118,21 +120,28 @@ func TransformGitHubPR(eventData json.RawMessage, timestamp time.Time) (models.D
 		prEvent.PullRequest.Base.Ref,
 	)
 
+	metadata := map[string]interface{}{
+		"repo":           prEvent.Repository.Name,
+		"repository_url": prEvent.Repository.HTMLURL,
+		"action":         prEvent.Action,
+		"author":         prEvent.PullRequest.User.Login,
+		"state":          prEvent.PullRequest.State,
+		"pr_url":         prEvent.PullRequest.HTMLURL,
+		"number":         prEvent.Number,
+		"merged":         prEvent.PullRequest.Merged,
+		"head_branch":    prEvent.PullRequest.Head.Ref,
+		"base_branch":    prEvent.PullRequest.Base.Ref,
+		"head_sha":       prEvent.PullRequest.Head.SHA,
+	}
+	// Until the PR is merged merge_commit_sha is only GitHub's test merge
+	if prEvent.PullRequest.Merged {
+		metadata["merge_commit_sha"] = prEvent.PullRequest.MergeCommitSHA
+	}
+
 	return models.DashboardEvent{
 		EventType: "github.pr",
 		Title:     title,
-		Metadata: map[string]interface{}{
-			"repo":           prEvent.Repository.Name,
-			"repository_url": prEvent.Repository.HTMLURL,
-			"action":         prEvent.Action,
-			"author":         prEvent.PullRequest.User.Login,
-			"state":          prEvent.PullRequest.State,
-			"pr_url":         prEvent.PullRequest.HTMLURL,
-			"number":         prEvent.Number,
-			"merged":         prEvent.PullRequest.Merged,
-			"head_branch":    prEvent.PullRequest.Head.Ref,
-			"base_branch":    prEvent.PullRequest.Base.Ref,
-		},
+		Metadata:  metadata,
 		CreatedAt: timestamp,
 	}, nil
 }
@@ -184,8 +193,9 @@ func TransformGitHubRelease(eventData json.RawMessage, timestamp time.Time) (mod
 			Author  struct {
 				Login string `json:"login"`
 			} `json:"author"`
-			HTMLURL string `json:"html_url"`
-			Draft   bool   `json:"draft"`
+			HTMLURL         string `json:"html_url"`
+			Draft           bool   `json:"draft"`
+			TargetCommitish string `json:"target_commitish"`
 		} `json:"release"`
 		Repository struct {
 			Name string `json:"name"`
@@ -201,17 +211,37 @@ func TransformGitHubRelease(eventData json.RawMessage, timestamp time.Time) (mod
 		title = releaseEvent.Release.TagName
 	}
 
+	metadata := map[string]interface{}{
+		"repo":             releaseEvent.Repository.Name,
+		"action":           releaseEvent.Action,
+		"tag":              releaseEvent.Release.TagName,
+		"author":           releaseEvent.Release.Author.Login,
+		"release_url":      releaseEvent.Release.HTMLURL,
+		"draft":            releaseEvent.Release.Draft,
+		"target_commitish": releaseEvent.Release.TargetCommitish,
+	}
+	// The target is usually a branch; only a commit target identifies the released commit
+	if isCommitSHA(releaseEvent.Release.TargetCommitish) {
+		metadata["commit_sha"] = releaseEvent.Release.TargetCommitish
+	}
+
 	return models.DashboardEvent{
 		EventType: "github.release",
 		Title:     fmt.Sprintf("Release %s: %s", releaseEvent.Release.TagName, title),
-		Metadata: map[string]interface{}{
-			"repo":        releaseEvent.Repository.Name,
-			"action":      releaseEvent.Action,
-			"tag":         releaseEvent.Release.TagName,
-			"author":      releaseEvent.Release.Author.Login,
-			"release_url": releaseEvent.Release.HTMLURL,
-			"draft":       releaseEvent.Release.Draft,
-		},
+		Metadata:  metadata,
 		CreatedAt: timestamp,
 	}, nil
 }
+
+// isCommitSHA reports whether s is a full hex commit SHA-1
+func isCommitSHA(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,242 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// GitHub Actions and check suites report progress through several deliveries per
+// run (requested, in_progress, completed). Only the completed delivery carries a
+// conclusion and duration, so earlier actions produce no events.
+//
+// One Actions run is reported as a workflow_run, a check_suite and one
+// workflow_job per job. The workflow_run is the github.ci event; the Actions
+// check suite is dropped and jobs become github.ci_job events, which the stats
+// leave out so a run is only counted once.
+
+// TransformGitHubWorkflowRun transforms a GitHub Actions workflow_run event
+func TransformGitHubWorkflowRun(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var runEvent struct {
+		Action      string `json:"action"`
+		WorkflowRun struct {
+			ID           int64     `json:"id"`
+			Name         string    `json:"name"`
+			HeadBranch   string    `json:"head_branch"`
+			HeadSHA      string    `json:"head_sha"`
+			Status       string    `json:"status"`
+			Conclusion   string    `json:"conclusion"`
+			HTMLURL      string    `json:"html_url"`
+			RunNumber    int       `json:"run_number"`
+			RunAttempt   int       `json:"run_attempt"`
+			Event        string    `json:"event"`
+			RunStartedAt time.Time `json:"run_started_at"`
+			UpdatedAt    time.Time `json:"updated_at"`
+			Actor        struct {
+				Login string `json:"login"`
+			} `json:"actor"`
+		} `json:"workflow_run"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &runEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow_run event: %w", err)
+	}
+	if runEvent.Action != "completed" {
+		return nil, nil
+	}
+
+	run := runEvent.WorkflowRun
+	title := fmt.Sprintf("Workflow %s %s on %s/%s",
+		run.Name, ciOutcome(run.Conclusion), runEvent.Repository.Name, run.HeadBranch)
+
+	return []models.DashboardEvent{{
+		EventType: "github.ci",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"kind":             "workflow_run",
+			"repo":             runEvent.Repository.Name,
+			"repository_url":   runEvent.Repository.HTMLURL,
+			"workflow":         run.Name,
+			"status":           ciStatus(run.Status, run.Conclusion),
+			"conclusion":       run.Conclusion,
+			"duration_seconds": ciDuration(run.RunStartedAt, run.UpdatedAt),
+			"branch":           run.HeadBranch,
+			"commit_sha":       run.HeadSHA,
+			"run_url":          run.HTMLURL,
+			"run_id":           run.ID,
+			"run_number":       run.RunNumber,
+			"run_attempt":      run.RunAttempt,
+			"trigger":          run.Event,
+			"actor":            run.Actor.Login,
+		},
+		CreatedAt: timestamp,
+	}}, nil
+}
+
+// TransformGitHubWorkflowJob transforms a GitHub Actions workflow_job event into a
+// github.ci_job event that details its workflow run
+func TransformGitHubWorkflowJob(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var jobEvent struct {
+		Action      string `json:"action"`
+		WorkflowJob struct {
+			ID           int64     `json:"id"`
+			RunID        int64     `json:"run_id"`
+			Name         string    `json:"name"`
+			WorkflowName string    `json:"workflow_name"`
+			HeadBranch   string    `json:"head_branch"`
+			HeadSHA      string    `json:"head_sha"`
+			Status       string    `json:"status"`
+			Conclusion   string    `json:"conclusion"`
+			HTMLURL      string    `json:"html_url"`
+			RunnerName   string    `json:"runner_name"`
+			StartedAt    time.Time `json:"started_at"`
+			CompletedAt  time.Time `json:"completed_at"`
+		} `json:"workflow_job"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &jobEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow_job event: %w", err)
+	}
+	if jobEvent.Action != "completed" {
+		return nil, nil
+	}
+
+	job := jobEvent.WorkflowJob
+	title := fmt.Sprintf("Job %s (%s) %s on %s/%s",
+		job.Name, job.WorkflowName, ciOutcome(job.Conclusion), jobEvent.Repository.Name, job.HeadBranch)
+
+	return []models.DashboardEvent{{
+		EventType: "github.ci_job",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"kind":             "workflow_job",
+			"repo":             jobEvent.Repository.Name,
+			"repository_url":   jobEvent.Repository.HTMLURL,
+			"workflow":         job.WorkflowName,
+			"job":              job.Name,
+			"status":           ciStatus(job.Status, job.Conclusion),
+			"conclusion":       job.Conclusion,
+			"duration_seconds": ciDuration(job.StartedAt, job.CompletedAt),
+			"branch":           job.HeadBranch,
+			"commit_sha":       job.HeadSHA,
+			"run_url":          job.HTMLURL,
+			"run_id":           job.RunID,
+			"job_id":           job.ID,
+			"runner":           job.RunnerName,
+		},
+		CreatedAt: timestamp,
+	}}, nil
+}
+
+// TransformGitHubCheckSuite transforms a GitHub check_suite event. Check suites
+// cover every CI app installed on the repository; GitHub Actions suites are
+// skipped because their workflow_run is reported already.
+func TransformGitHubCheckSuite(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var suiteEvent struct {
+		Action     string `json:"action"`
+		CheckSuite struct {
+			ID         int64     `json:"id"`
+			HeadBranch string    `json:"head_branch"`
+			HeadSHA    string    `json:"head_sha"`
+			Status     string    `json:"status"`
+			Conclusion string    `json:"conclusion"`
+			CreatedAt  time.Time `json:"created_at"`
+			UpdatedAt  time.Time `json:"updated_at"`
+			App        struct {
+				Name string `json:"name"`
+				Slug string `json:"slug"`
+			} `json:"app"`
+		} `json:"check_suite"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &suiteEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal check_suite event: %w", err)
+	}
+	if suiteEvent.Action != "completed" || suiteEvent.CheckSuite.App.Slug == "github-actions" {
+		return nil, nil
+	}
+
+	suite := suiteEvent.CheckSuite
+	title := fmt.Sprintf("Checks by %s %s on %s/%s",
+		suite.App.Name, ciOutcome(suite.Conclusion), suiteEvent.Repository.Name, suite.HeadBranch)
+
+	// Check suites have no page of their own; link to the commit's checks tab
+	runURL := ""
+	if suiteEvent.Repository.HTMLURL != "" && suite.HeadSHA != "" {
+		runURL = fmt.Sprintf("%s/commit/%s/checks", suiteEvent.Repository.HTMLURL, suite.HeadSHA)
+	}
+
+	return []models.DashboardEvent{{
+		EventType: "github.ci",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"kind":             "check_suite",
+			"repo":             suiteEvent.Repository.Name,
+			"repository_url":   suiteEvent.Repository.HTMLURL,
+			"workflow":         suite.App.Name,
+			"status":           ciStatus(suite.Status, suite.Conclusion),
+			"conclusion":       suite.Conclusion,
+			"duration_seconds": ciDuration(suite.CreatedAt, suite.UpdatedAt),
+			"branch":           suite.HeadBranch,
+			"commit_sha":       suite.HeadSHA,
+			"run_url":          runURL,
+			"check_suite_id":   suite.ID,
+		},
+		CreatedAt: timestamp,
+	}}, nil
+}
+
+// ciStatus reports the conclusion once a run has finished and the GitHub status
+// (queued, in_progress) before that, so the dashboard's status filter sees
+// "failure" rather than "completed" for failed builds
+func ciStatus(status, conclusion string) string {
+	if conclusion != "" {
+		return conclusion
+	}
+	return status
+}
+
+// ciOutcome phrases a GitHub conclusion for use in a title
+func ciOutcome(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "passed"
+	case "failure":
+		return "failed"
+	case "cancelled":
+		return "was cancelled"
+	case "timed_out":
+		return "timed out"
+	case "skipped":
+		return "was skipped"
+	case "action_required":
+		return "needs action"
+	case "":
+		return "finished"
+	default:
+		return conclusion
+	}
+}
+
+// ciDuration returns the whole seconds between start and end, or 0 when either
+// timestamp is missing
+func ciDuration(start, end time.Time) int64 {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return int64(end.Sub(start) / time.Second)
+}
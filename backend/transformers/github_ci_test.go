@@ -0,0 +1,197 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformGitHubWorkflowRun(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	input := json.RawMessage(`{
+		"action": "completed",
+		"workflow_run": {
+			"id": 987,
+			"name": "CI",
+			"head_branch": "main",
+			"head_sha": "abc123",
+			"status": "completed",
+			"conclusion": "failure",
+			"html_url": "https://github.com/roe/heimdall/actions/runs/987",
+			"run_number": 42,
+			"run_attempt": 1,
+			"event": "push",
+			"run_started_at": "2024-01-15T10:26:00Z",
+			"updated_at": "2024-01-15T10:29:30Z",
+			"actor": {"login": "roe"}
+		},
+		"repository": {"name": "heimdall", "html_url": "https://github.com/roe/heimdall"}
+	}`)
+
+	events, err := TransformGitHubWorkflowRun(input, testTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.EventType != "github.ci" {
+		t.Errorf("expected github.ci, got %s", event.EventType)
+	}
+	if event.Title != "Workflow CI failed on heimdall/main" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+
+	expected := map[string]interface{}{
+		"kind":             "workflow_run",
+		"workflow":         "CI",
+		"status":           "failure",
+		"conclusion":       "failure",
+		"duration_seconds": int64(210),
+		"branch":           "main",
+		"commit_sha":       "abc123",
+		"run_url":          "https://github.com/roe/heimdall/actions/runs/987",
+	}
+	for key, want := range expected {
+		if got := event.Metadata[key]; got != want {
+			t.Errorf("metadata[%q] = %v, want %v", key, got, want)
+		}
+	}
+	if !event.CreatedAt.Equal(testTime) {
+		t.Errorf("expected timestamp %v, got %v", testTime, event.CreatedAt)
+	}
+}
+
+func TestTransformGitHubWorkflowJob(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "completed",
+		"workflow_job": {
+			"id": 55,
+			"run_id": 987,
+			"name": "test",
+			"workflow_name": "CI",
+			"head_branch": "feature/x",
+			"head_sha": "def456",
+			"status": "completed",
+			"conclusion": "success",
+			"html_url": "https://github.com/roe/heimdall/actions/runs/987/job/55",
+			"started_at": "2024-01-15T10:00:00Z",
+			"completed_at": "2024-01-15T10:01:05Z"
+		},
+		"repository": {"name": "heimdall"}
+	}`)
+
+	events, err := TransformGitHubWorkflowJob(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.EventType != "github.ci_job" {
+		t.Errorf("expected github.ci_job, got %s", event.EventType)
+	}
+	if event.Title != "Job test (CI) passed on heimdall/feature/x" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+	if event.Metadata["job"] != "test" || event.Metadata["workflow"] != "CI" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+	if event.Metadata["duration_seconds"] != int64(65) {
+		t.Errorf("expected 65s duration, got %v", event.Metadata["duration_seconds"])
+	}
+}
+
+func TestTransformGitHubCheckSuite(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "completed",
+		"check_suite": {
+			"id": 7,
+			"head_branch": "main",
+			"head_sha": "abc123",
+			"status": "completed",
+			"conclusion": "timed_out",
+			"created_at": "2024-01-15T10:00:00Z",
+			"updated_at": "2024-01-15T10:10:00Z",
+			"app": {"name": "Buildkite"}
+		},
+		"repository": {"name": "heimdall", "html_url": "https://github.com/roe/heimdall"}
+	}`)
+
+	events, err := TransformGitHubCheckSuite(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.Title != "Checks by Buildkite timed out on heimdall/main" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+	if event.Metadata["run_url"] != "https://github.com/roe/heimdall/commit/abc123/checks" {
+		t.Errorf("unexpected run_url: %v", event.Metadata["run_url"])
+	}
+	if event.Metadata["duration_seconds"] != int64(600) {
+		t.Errorf("expected 600s duration, got %v", event.Metadata["duration_seconds"])
+	}
+}
+
+func TestTransformGitHubCheckSuite_ActionsSkipped(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "completed",
+		"check_suite": {
+			"id": 8,
+			"head_sha": "abc123",
+			"status": "completed",
+			"conclusion": "success",
+			"app": {"name": "GitHub Actions", "slug": "github-actions"}
+		},
+		"repository": {"name": "heimdall"}
+	}`)
+
+	events, err := TransformGitHubCheckSuite(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected the Actions check suite to be skipped, got %d events", len(events))
+	}
+}
+
+func TestGitHubCI_IncompleteActionsIgnored(t *testing.T) {
+	tests := []struct {
+		name  string
+		fn    MultiTransformFunc
+		input string
+	}{
+		{"workflow run requested", TransformGitHubWorkflowRun, `{"action": "requested", "workflow_run": {"status": "queued"}}`},
+		{"workflow run in progress", TransformGitHubWorkflowRun, `{"action": "in_progress", "workflow_run": {"status": "in_progress"}}`},
+		{"workflow job queued", TransformGitHubWorkflowJob, `{"action": "queued", "workflow_job": {"status": "queued"}}`},
+		{"check suite rerequested", TransformGitHubCheckSuite, `{"action": "rerequested", "check_suite": {"status": "queued"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := tt.fn(json.RawMessage(tt.input), time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != 0 {
+				t.Errorf("expected no events, got %d", len(events))
+			}
+		})
+	}
+}
+
+func TestGitHubCI_InvalidJSON(t *testing.T) {
+	if _, err := TransformGitHubWorkflowRun(json.RawMessage(`{invalid`), time.Now()); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
@@ -0,0 +1,334 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"heimdall-backend/models"
+)
+
+// maxExcerptLength bounds the review and comment body kept in metadata
+const maxExcerptLength = 280
+
+// ignoredReviewActions are review actions that do not represent new review work
+var ignoredReviewActions = map[string]bool{
+	"edited": true,
+}
+
+// ignoredCommentActions are comment actions that do not represent new work.
+// Shared by review comments, issue comments and discussion comments.
+var ignoredCommentActions = map[string]bool{
+	"edited":  true,
+	"deleted": true,
+}
+
+// ignoredDiscussionActions are discussion housekeeping actions
+var ignoredDiscussionActions = map[string]bool{
+	"edited":           true,
+	"deleted":          true,
+	"labeled":          true,
+	"unlabeled":        true,
+	"pinned":           true,
+	"unpinned":         true,
+	"locked":           true,
+	"unlocked":         true,
+	"transferred":      true,
+	"category_changed": true,
+}
+
+// githubUser is the actor on a collaboration payload
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubRepository is the repository on a collaboration payload
+type githubRepository struct {
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// TransformGitHubReview transforms a GitHub pull_request_review event
+func TransformGitHubReview(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var reviewEvent struct {
+		Action string `json:"action"`
+		Review struct {
+			ID      int64      `json:"id"`
+			User    githubUser `json:"user"`
+			Body    string     `json:"body"`
+			State   string     `json:"state"`
+			HTMLURL string     `json:"html_url"`
+		} `json:"review"`
+		PullRequest struct {
+			Number int        `json:"number"`
+			Title  string     `json:"title"`
+			User   githubUser `json:"user"`
+		} `json:"pull_request"`
+		Repository githubRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &reviewEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal pull_request_review event: %w", err)
+	}
+
+	review := reviewEvent.Review
+	state := strings.ToLower(review.State)
+
+	var verb string
+	switch {
+	case reviewEvent.Action == "dismissed":
+		verb = "review dismissed"
+	case state == "approved":
+		verb = "approved"
+	case state == "changes_requested":
+		verb = "changes requested"
+	default:
+		verb = "reviewed"
+	}
+	title := fmt.Sprintf("PR #%d %s by %s: %s",
+		reviewEvent.PullRequest.Number, verb, review.User.Login, reviewEvent.PullRequest.Title)
+
+	return models.DashboardEvent{
+		EventType: "github.review",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"repo":           reviewEvent.Repository.Name,
+			"repository_url": reviewEvent.Repository.HTMLURL,
+			"action":         reviewEvent.Action,
+			"reviewer":       review.User.Login,
+			"author":         review.User.Login,
+			"review_state":   state,
+			"review_id":      review.ID,
+			"review_url":     review.HTMLURL,
+			"number":         reviewEvent.PullRequest.Number,
+			"pr_title":       reviewEvent.PullRequest.Title,
+			"pr_author":      reviewEvent.PullRequest.User.Login,
+			"body":           bodyExcerpt(review.Body),
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubReviewComment transforms a GitHub pull_request_review_comment event
+func TransformGitHubReviewComment(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var commentEvent struct {
+		Action  string `json:"action"`
+		Comment struct {
+			ID                  int64      `json:"id"`
+			User                githubUser `json:"user"`
+			Body                string     `json:"body"`
+			Path                string     `json:"path"`
+			Line                int        `json:"line"`
+			HTMLURL             string     `json:"html_url"`
+			PullRequestReviewID int64      `json:"pull_request_review_id"`
+		} `json:"comment"`
+		PullRequest struct {
+			Number int        `json:"number"`
+			Title  string     `json:"title"`
+			User   githubUser `json:"user"`
+		} `json:"pull_request"`
+		Repository githubRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &commentEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal pull_request_review_comment event: %w", err)
+	}
+
+	comment := commentEvent.Comment
+	title := fmt.Sprintf("PR #%d review comment by %s on %s",
+		commentEvent.PullRequest.Number, comment.User.Login, comment.Path)
+
+	return models.DashboardEvent{
+		EventType: "github.review_comment",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"repo":           commentEvent.Repository.Name,
+			"repository_url": commentEvent.Repository.HTMLURL,
+			"action":         commentEvent.Action,
+			"reviewer":       comment.User.Login,
+			"author":         comment.User.Login,
+			"review_id":      comment.PullRequestReviewID,
+			"comment_id":     comment.ID,
+			"comment_url":    comment.HTMLURL,
+			"path":           comment.Path,
+			"line":           comment.Line,
+			"number":         commentEvent.PullRequest.Number,
+			"pr_title":       commentEvent.PullRequest.Title,
+			"pr_author":      commentEvent.PullRequest.User.Login,
+			"body":           bodyExcerpt(comment.Body),
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubIssueComment transforms a GitHub issue_comment event. GitHub
+// sends conversation comments on pull requests as issue comments too; those are
+// marked with is_pull_request.
+func TransformGitHubIssueComment(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var commentEvent struct {
+		Action  string `json:"action"`
+		Comment struct {
+			ID      int64      `json:"id"`
+			User    githubUser `json:"user"`
+			Body    string     `json:"body"`
+			HTMLURL string     `json:"html_url"`
+		} `json:"comment"`
+		Issue struct {
+			Number      int             `json:"number"`
+			Title       string          `json:"title"`
+			User        githubUser      `json:"user"`
+			PullRequest json.RawMessage `json:"pull_request"`
+		} `json:"issue"`
+		Repository githubRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &commentEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal issue_comment event: %w", err)
+	}
+
+	issue := commentEvent.Issue
+	isPullRequest := len(issue.PullRequest) > 0 && string(issue.PullRequest) != "null"
+	kind := "Issue"
+	if isPullRequest {
+		kind = "PR"
+	}
+	title := fmt.Sprintf("%s #%d comment by %s: %s", kind, issue.Number, commentEvent.Comment.User.Login, issue.Title)
+
+	return models.DashboardEvent{
+		EventType: "github.comment",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"repo":            commentEvent.Repository.Name,
+			"repository_url":  commentEvent.Repository.HTMLURL,
+			"action":          commentEvent.Action,
+			"author":          commentEvent.Comment.User.Login,
+			"comment_id":      commentEvent.Comment.ID,
+			"comment_url":     commentEvent.Comment.HTMLURL,
+			"number":          issue.Number,
+			"issue_title":     issue.Title,
+			"issue_author":    issue.User.Login,
+			"is_pull_request": isPullRequest,
+			"body":            bodyExcerpt(commentEvent.Comment.Body),
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// githubDiscussion is the discussion on discussion and discussion_comment payloads
+type githubDiscussion struct {
+	Number   int        `json:"number"`
+	Title    string     `json:"title"`
+	Body     string     `json:"body"`
+	HTMLURL  string     `json:"html_url"`
+	User     githubUser `json:"user"`
+	Category struct {
+		Name string `json:"name"`
+	} `json:"category"`
+}
+
+// TransformGitHubDiscussion transforms a GitHub discussion event
+func TransformGitHubDiscussion(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var discussionEvent struct {
+		Action     string           `json:"action"`
+		Discussion githubDiscussion `json:"discussion"`
+		Answer     *struct {
+			User githubUser `json:"user"`
+		} `json:"answer"`
+		Sender     githubUser       `json:"sender"`
+		Repository githubRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &discussionEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal discussion event: %w", err)
+	}
+
+	discussion := discussionEvent.Discussion
+
+	// The author of the work is whoever acted: the opener for a new discussion,
+	// the maintainer marking an answer, closing or reopening
+	author := discussionEvent.Sender.Login
+	if author == "" {
+		author = discussion.User.Login
+	}
+
+	metadata := map[string]interface{}{
+		"repo":              discussionEvent.Repository.Name,
+		"repository_url":    discussionEvent.Repository.HTMLURL,
+		"action":            discussionEvent.Action,
+		"author":            author,
+		"number":            discussion.Number,
+		"discussion_title":  discussion.Title,
+		"discussion_url":    discussion.HTMLURL,
+		"discussion_author": discussion.User.Login,
+		"category":          discussion.Category.Name,
+		"body":              bodyExcerpt(discussion.Body),
+	}
+	if discussionEvent.Answer != nil {
+		metadata["answered_by"] = discussionEvent.Answer.User.Login
+	}
+
+	return models.DashboardEvent{
+		EventType: "github.discussion",
+		Title:     fmt.Sprintf("Discussion #%d %s: %s", discussion.Number, discussionEvent.Action, discussion.Title),
+		Metadata:  metadata,
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubDiscussionComment transforms a GitHub discussion_comment event
+func TransformGitHubDiscussionComment(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var commentEvent struct {
+		Action  string `json:"action"`
+		Comment struct {
+			ID       int64      `json:"id"`
+			User     githubUser `json:"user"`
+			Body     string     `json:"body"`
+			HTMLURL  string     `json:"html_url"`
+			ParentID *int64     `json:"parent_id"`
+		} `json:"comment"`
+		Discussion githubDiscussion `json:"discussion"`
+		Repository githubRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &commentEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal discussion_comment event: %w", err)
+	}
+
+	discussion := commentEvent.Discussion
+	comment := commentEvent.Comment
+
+	return models.DashboardEvent{
+		EventType: "github.discussion_comment",
+		Title: fmt.Sprintf("Discussion #%d comment by %s: %s",
+			discussion.Number, comment.User.Login, discussion.Title),
+		Metadata: map[string]interface{}{
+			"repo":              commentEvent.Repository.Name,
+			"repository_url":    commentEvent.Repository.HTMLURL,
+			"action":            commentEvent.Action,
+			"author":            comment.User.Login,
+			"comment_id":        comment.ID,
+			"comment_url":       comment.HTMLURL,
+			"is_reply":          comment.ParentID != nil,
+			"number":            discussion.Number,
+			"discussion_title":  discussion.Title,
+			"discussion_url":    discussion.HTMLURL,
+			"discussion_author": discussion.User.Login,
+			"category":          discussion.Category.Name,
+			"body":              bodyExcerpt(comment.Body),
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// bodyExcerpt collapses whitespace in a Markdown body and cuts it to
+// maxExcerptLength characters, so feeds stay readable and metadata stays small
+func bodyExcerpt(body string) string {
+	excerpt := strings.Join(strings.Fields(body), " ")
+	if utf8.RuneCountInString(excerpt) <= maxExcerptLength {
+		return excerpt
+	}
+	runes := []rune(excerpt)
+	return strings.TrimSpace(string(runes[:maxExcerptLength-1])) + "…"
+}
@@ -0,0 +1,199 @@
+package transformers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+const githubTestRepository = `"repository": {"name": "heimdall", "html_url": "https://github.com/acme/heimdall"}`
+
+func TestTransformGitHubReview(t *testing.T) {
+	tests := []struct {
+		name          string
+		action        string
+		state         string
+		expectedTitle string
+	}{
+		{name: "approved", action: "submitted", state: "approved", expectedTitle: "PR #42 approved by sam: Add streaks"},
+		{name: "changes requested", action: "submitted", state: "changes_requested", expectedTitle: "PR #42 changes requested by sam: Add streaks"},
+		{name: "commented", action: "submitted", state: "commented", expectedTitle: "PR #42 reviewed by sam: Add streaks"},
+		{name: "dismissed", action: "dismissed", state: "dismissed", expectedTitle: "PR #42 review dismissed by sam: Add streaks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"action": "` + tt.action + `",
+				"review": {
+					"id": 9,
+					"user": {"login": "sam"},
+					"body": "Looks good,\n\nbut please rename the helper.",
+					"state": "` + strings.ToUpper(tt.state) + `",
+					"html_url": "https://github.com/acme/heimdall/pull/42#pullrequestreview-9"
+				},
+				"pull_request": {"number": 42, "title": "Add streaks", "user": {"login": "roe"}},
+				` + githubTestRepository + `
+			}`)
+
+			event, err := TransformGitHubReview(input, time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != "github.review" {
+				t.Errorf("expected github.review, got %s", event.EventType)
+			}
+			if event.Title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, event.Title)
+			}
+
+			expected := map[string]interface{}{
+				"reviewer":     "sam",
+				"review_state": tt.state,
+				"number":       42,
+				"pr_author":    "roe",
+				"body":         "Looks good, but please rename the helper.",
+			}
+			for key, want := range expected {
+				if got := event.Metadata[key]; got != want {
+					t.Errorf("metadata[%q] = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTransformGitHubReviewComment(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "created",
+		"comment": {
+			"id": 77,
+			"user": {"login": "sam"},
+			"body": "Nit: typo",
+			"path": "backend/main.go",
+			"line": 12,
+			"pull_request_review_id": 9
+		},
+		"pull_request": {"number": 42, "title": "Add streaks", "user": {"login": "roe"}},
+		` + githubTestRepository + `
+	}`)
+
+	event, err := TransformGitHubReviewComment(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "github.review_comment" || event.Title != "PR #42 review comment by sam on backend/main.go" {
+		t.Errorf("unexpected event: %s %s", event.EventType, event.Title)
+	}
+	if event.Metadata["line"] != 12 || event.Metadata["review_id"] != int64(9) || event.Metadata["body"] != "Nit: typo" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+}
+
+func TestTransformGitHubIssueComment(t *testing.T) {
+	tests := []struct {
+		name          string
+		pullRequest   string
+		expectedTitle string
+		expectedIsPR  bool
+	}{
+		{name: "issue", pullRequest: "", expectedTitle: "Issue #7 comment by sam: Crash on start"},
+		{name: "pull request", pullRequest: `"pull_request": {"url": "https://api.github.com/repos/acme/heimdall/pulls/7"},`, expectedTitle: "PR #7 comment by sam: Crash on start", expectedIsPR: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"action": "created",
+				"comment": {"id": 5, "user": {"login": "sam"}, "body": "Reproduced"},
+				"issue": {` + tt.pullRequest + `"number": 7, "title": "Crash on start", "user": {"login": "roe"}},
+				` + githubTestRepository + `
+			}`)
+
+			event, err := TransformGitHubIssueComment(input, time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != "github.comment" || event.Title != tt.expectedTitle {
+				t.Errorf("unexpected event: %s %s", event.EventType, event.Title)
+			}
+			if event.Metadata["is_pull_request"] != tt.expectedIsPR || event.Metadata["number"] != 7 {
+				t.Errorf("unexpected metadata: %v", event.Metadata)
+			}
+		})
+	}
+}
+
+func TestTransformGitHubDiscussion(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "answered",
+		"discussion": {
+			"number": 3,
+			"title": "How do I add a source?",
+			"body": "Question body",
+			"user": {"login": "roe"},
+			"category": {"name": "Q&A"}
+		},
+		"answer": {"user": {"login": "sam"}},
+		"sender": {"login": "roe"},
+		` + githubTestRepository + `
+	}`)
+
+	event, err := TransformGitHubDiscussion(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "github.discussion" || event.Title != "Discussion #3 answered: How do I add a source?" {
+		t.Errorf("unexpected event: %s %s", event.EventType, event.Title)
+	}
+	if event.Metadata["answered_by"] != "sam" || event.Metadata["category"] != "Q&A" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+}
+
+func TestTransformGitHubDiscussionComment(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "created",
+		"comment": {"id": 11, "user": {"login": "sam"}, "body": "Use a mapping file", "parent_id": 10},
+		"discussion": {"number": 3, "title": "How do I add a source?", "user": {"login": "roe"}},
+		` + githubTestRepository + `
+	}`)
+
+	event, err := TransformGitHubDiscussionComment(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "github.discussion_comment" || event.Metadata["author"] != "sam" || event.Metadata["is_reply"] != true {
+		t.Errorf("unexpected event: %s %v", event.EventType, event.Metadata)
+	}
+}
+
+func TestCollaborationEvents_SkipEdits(t *testing.T) {
+	registry := NewRegistry()
+	for _, eventType := range []string{
+		"github.pull_request_review",
+		"github.pull_request_review_comment",
+		"github.issue_comment",
+		"github.discussion",
+		"github.discussion_comment",
+	} {
+		events, err := registry.Transform(eventType, json.RawMessage(`{"action": "edited"}`), time.Now())
+		if err != nil || len(events) != 0 {
+			t.Errorf("%s: expected edits to be skipped, got %d events (%v)", eventType, len(events), err)
+		}
+	}
+}
+
+func TestBodyExcerpt(t *testing.T) {
+	if got := bodyExcerpt("  line one\n\n  line two  "); got != "line one line two" {
+		t.Errorf("expected collapsed whitespace, got %q", got)
+	}
+
+	long := strings.Repeat("ä", maxExcerptLength+10)
+	got := bodyExcerpt(long)
+	if utf8.RuneCountInString(got) != maxExcerptLength || !strings.HasSuffix(got, "…") {
+		t.Errorf("expected a %d character excerpt ending in an ellipsis, got %d", maxExcerptLength, utf8.RuneCountInString(got))
+	}
+}
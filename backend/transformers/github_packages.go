@@ -0,0 +1,117 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// githubPackage is the package on package and registry_package payloads
+type githubPackage struct {
+	ID             int64      `json:"id"`
+	Name           string     `json:"name"`
+	Namespace      string     `json:"namespace"`
+	Ecosystem      string     `json:"ecosystem"`
+	PackageType    string     `json:"package_type"`
+	HTMLURL        string     `json:"html_url"`
+	Owner          githubUser `json:"owner"`
+	PackageVersion struct {
+		ID                int64      `json:"id"`
+		Version           string     `json:"version"`
+		Name              string     `json:"name"`
+		HTMLURL           string     `json:"html_url"`
+		TargetCommitish   string     `json:"target_commitish"`
+		TargetOID         string     `json:"target_oid"`
+		Author            githubUser `json:"author"`
+		ContainerMetadata *struct {
+			Tag struct {
+				Name   string `json:"name"`
+				Digest string `json:"digest"`
+			} `json:"tag"`
+		} `json:"container_metadata"`
+	} `json:"package_version"`
+}
+
+// TransformGitHubPackage transforms a GitHub package or registry_package event.
+// Container versions become artifact.image events; other ecosystems (npm,
+// Maven, ...) become artifact.package events. Untagged container versions,
+// such as the per-platform manifests of a multi-arch build, are skipped.
+//
+// GitHub sends both events for the same publication, so the event is
+// identified by package version and tag, and storing it twice is a no-op.
+func TransformGitHubPackage(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var packageEvent struct {
+		Action          string           `json:"action"`
+		Package         *githubPackage   `json:"package"`
+		RegistryPackage *githubPackage   `json:"registry_package"`
+		Sender          githubUser       `json:"sender"`
+		Repository      githubRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &packageEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal package event: %w", err)
+	}
+
+	pkg := packageEvent.Package
+	if pkg == nil {
+		pkg = packageEvent.RegistryPackage
+	}
+	if pkg == nil {
+		return nil, fmt.Errorf("payload has no package")
+	}
+
+	version := pkg.PackageVersion
+	ecosystem := strings.ToLower(pkg.PackageType)
+	if ecosystem == "" {
+		ecosystem = strings.ToLower(pkg.Ecosystem)
+	}
+
+	artifact := publishedArtifact{
+		Registry:  ecosystem,
+		Image:     pkg.Name,
+		Tag:       version.Version,
+		Pusher:    version.Author.Login,
+		URL:       version.HTMLURL,
+		CommitSHA: version.TargetOID,
+		Branch:    version.TargetCommitish,
+	}
+	if artifact.Pusher == "" {
+		artifact.Pusher = packageEvent.Sender.Login
+	}
+	if artifact.URL == "" {
+		artifact.URL = pkg.HTMLURL
+	}
+
+	eventType := "artifact.package"
+	if ecosystem == "container" || ecosystem == "docker" {
+		if version.ContainerMetadata == nil || version.ContainerMetadata.Tag.Name == "" {
+			return nil, nil
+		}
+		namespace := pkg.Namespace
+		if namespace == "" {
+			namespace = pkg.Owner.Login
+		}
+		eventType = "artifact.image"
+		artifact.Registry = "ghcr"
+		artifact.Image = "ghcr.io/" + strings.ToLower(namespace) + "/" + pkg.Name
+		artifact.Tag = version.ContainerMetadata.Tag.Name
+		artifact.Digest = version.ContainerMetadata.Tag.Digest
+		if artifact.Digest == "" && strings.HasPrefix(version.Name, "sha256:") {
+			artifact.Digest = version.Name
+		}
+	}
+
+	event := artifactEvent(eventType, artifact, timestamp)
+	event.Metadata["action"] = packageEvent.Action
+	event.Metadata["repo"] = packageEvent.Repository.Name
+	event.Metadata["package_id"] = pkg.ID
+	event.Metadata["version_id"] = version.ID
+	if version.ID != 0 {
+		event.Source = "github"
+		event.ExternalID = fmt.Sprintf("package:%d:%s", version.ID, artifact.Tag)
+	}
+	return []models.DashboardEvent{event}, nil
+}
@@ -0,0 +1,117 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformGitHubPackage(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	version := `{
+		"id": 501,
+		"version": "sha256:4f1e",
+		"name": "sha256:4f1e",
+		"html_url": "https://github.com/acme/api/pkgs/container/api/501",
+		"target_commitish": "main",
+		"target_oid": "abc123",
+		"author": {"login": "octocat"},
+		"container_metadata": {"tag": {"name": "v1.4.0", "digest": "sha256:4f1e"}}
+	}`
+
+	// package and registry_package carry the same package under different keys
+	for _, key := range []string{"package", "registry_package"} {
+		t.Run(key, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"action": "published",
+				"` + key + `": {
+					"id": 77,
+					"name": "api",
+					"namespace": "Acme",
+					"package_type": "CONTAINER",
+					"html_url": "https://github.com/orgs/acme/packages/container/package/api",
+					"owner": {"login": "Acme"},
+					"package_version": ` + version + `
+				},
+				"repository": {"name": "api"},
+				"sender": {"login": "octocat"}
+			}`)
+
+			events, err := TransformGitHubPackage(input, testTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+
+			event := events[0]
+			if event.EventType != "artifact.image" {
+				t.Errorf("expected event type artifact.image, got %s", event.EventType)
+			}
+			if expected := "octocat pushed ghcr.io/acme/api:v1.4.0 to GHCR"; event.Title != expected {
+				t.Errorf("expected title %q, got %q", expected, event.Title)
+			}
+			if event.Source != "github" || event.ExternalID != "package:501:v1.4.0" {
+				t.Errorf("unexpected identity: %s/%s", event.Source, event.ExternalID)
+			}
+			expected := map[string]interface{}{
+				"registry":   "ghcr",
+				"image":      "ghcr.io/acme/api",
+				"tag":        "v1.4.0",
+				"digest":     "sha256:4f1e",
+				"pusher":     "octocat",
+				"commit_sha": "abc123",
+				"branch":     "main",
+				"repo":       "api",
+			}
+			for key, value := range expected {
+				if event.Metadata[key] != value {
+					t.Errorf("expected %s %v, got %v", key, value, event.Metadata[key])
+				}
+			}
+		})
+	}
+}
+
+func TestTransformGitHubPackage_SkipsUntaggedContainerVersions(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "published",
+		"package": {
+			"name": "api",
+			"package_type": "CONTAINER",
+			"owner": {"login": "acme"},
+			"package_version": {"id": 502, "name": "sha256:9a9a", "container_metadata": {"tag": {"name": "", "digest": "sha256:9a9a"}}}
+		}
+	}`)
+
+	events, err := TransformGitHubPackage(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected untagged version to be skipped, got %d events", len(events))
+	}
+}
+
+func TestTransformGitHubPackage_NonContainer(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "published",
+		"package": {
+			"name": "@acme/sdk",
+			"package_type": "npm",
+			"package_version": {"id": 9, "version": "2.1.0", "author": {"login": "octocat"}}
+		}
+	}`)
+
+	events, err := TransformGitHubPackage(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].EventType != "artifact.package" {
+		t.Fatalf("expected one artifact.package event, got %+v", events)
+	}
+	if events[0].Metadata["registry"] != "npm" || events[0].Metadata["tag"] != "2.1.0" {
+		t.Errorf("unexpected metadata: %v", events[0].Metadata)
+	}
+}
@@ -0,0 +1,302 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// githubRefRepository is the repository on ref, fork and popularity payloads
+type githubRefRepository struct {
+	Name            string `json:"name"`
+	FullName        string `json:"full_name"`
+	HTMLURL         string `json:"html_url"`
+	DefaultBranch   string `json:"default_branch"`
+	StargazersCount int    `json:"stargazers_count"`
+	ForksCount      int    `json:"forks_count"`
+	WatchersCount   int    `json:"watchers_count"`
+}
+
+// TransformGitHubCreate transforms a GitHub create event (branch or tag created)
+func TransformGitHubCreate(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	return transformGitHubRef(eventData, timestamp, "created")
+}
+
+// TransformGitHubDelete transforms a GitHub delete event (branch or tag deleted)
+func TransformGitHubDelete(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	return transformGitHubRef(eventData, timestamp, "deleted")
+}
+
+// transformGitHubRef builds the github.branch or github.tag event shared by create and delete.
+// Repository creation, reported as ref_type "repository", is treated as a branch.
+func transformGitHubRef(eventData json.RawMessage, timestamp time.Time, action string) (models.DashboardEvent, error) {
+	var refEvent struct {
+		Ref        string              `json:"ref"`
+		RefType    string              `json:"ref_type"`
+		Sender     githubUser          `json:"sender"`
+		Repository githubRefRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &refEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal %s event: %w", action, err)
+	}
+
+	repo := refEvent.Repository
+	metadata := map[string]interface{}{
+		"repo":           repo.Name,
+		"repository_url": repo.HTMLURL,
+		"action":         action,
+		"author":         refEvent.Sender.Login,
+		"ref":            refEvent.Ref,
+		"ref_type":       refEvent.RefType,
+	}
+
+	if refEvent.RefType == "tag" {
+		metadata["tag"] = refEvent.Ref
+		if action == "created" && repo.HTMLURL != "" {
+			metadata["tag_url"] = repo.HTMLURL + "/releases/tag/" + refEvent.Ref
+		}
+		return models.DashboardEvent{
+			EventType: "github.tag",
+			Title:     fmt.Sprintf("Tag %s %s in %s", refEvent.Ref, action, repo.Name),
+			Metadata:  metadata,
+			CreatedAt: timestamp,
+		}, nil
+	}
+
+	metadata["branch"] = refEvent.Ref
+	metadata["default_branch"] = repo.DefaultBranch
+	if action == "created" && repo.HTMLURL != "" {
+		metadata["branch_url"] = repo.HTMLURL + "/tree/" + refEvent.Ref
+	}
+	return models.DashboardEvent{
+		EventType: "github.branch",
+		Title:     fmt.Sprintf("Branch %s %s in %s", refEvent.Ref, action, repo.Name),
+		Metadata:  metadata,
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubFork transforms a GitHub fork event into a github.community event
+func TransformGitHubFork(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var forkEvent struct {
+		Forkee struct {
+			FullName string     `json:"full_name"`
+			HTMLURL  string     `json:"html_url"`
+			Owner    githubUser `json:"owner"`
+		} `json:"forkee"`
+		Sender     githubUser          `json:"sender"`
+		Repository githubRefRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &forkEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal fork event: %w", err)
+	}
+
+	repo := forkEvent.Repository
+	metadata := communityMetadata("fork", "created", forkEvent.Sender.Login, repo)
+	metadata["fork"] = forkEvent.Forkee.FullName
+	metadata["fork_url"] = forkEvent.Forkee.HTMLURL
+
+	return models.DashboardEvent{
+		EventType: "github.community",
+		Title:     fmt.Sprintf("%s forked %s (%d forks)", forkEvent.Sender.Login, repo.Name, repo.ForksCount),
+		Metadata:  metadata,
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubStar transforms a GitHub star event into a github.community event
+func TransformGitHubStar(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var starEvent struct {
+		Action     string              `json:"action"`
+		Sender     githubUser          `json:"sender"`
+		Repository githubRefRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &starEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal star event: %w", err)
+	}
+
+	repo := starEvent.Repository
+	verb := "starred"
+	if starEvent.Action == "deleted" {
+		verb = "unstarred"
+	}
+
+	event := models.DashboardEvent{
+		EventType: "github.community",
+		Title:     fmt.Sprintf("%s %s %s (%d stars)", starEvent.Sender.Login, verb, repo.Name, repo.StargazersCount),
+		Metadata:  communityMetadata("star", starEvent.Action, starEvent.Sender.Login, repo),
+		CreatedAt: timestamp,
+	}
+	if starEvent.Action == "created" {
+		event.Source, event.ExternalID = "github", starredExternalID(starEvent.Sender.Login, repo)
+	}
+	return event, nil
+}
+
+// TransformGitHubWatch transforms a GitHub watch event into a github.community event.
+// Despite its name, GitHub sends watch alongside star when a repository is
+// starred; both share an external ID so the star is only stored once.
+func TransformGitHubWatch(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var watchEvent struct {
+		Action     string              `json:"action"`
+		Sender     githubUser          `json:"sender"`
+		Repository githubRefRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &watchEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal watch event: %w", err)
+	}
+
+	repo := watchEvent.Repository
+	return models.DashboardEvent{
+		EventType:  "github.community",
+		Title:      fmt.Sprintf("%s starred %s (%d stars)", watchEvent.Sender.Login, repo.Name, repo.StargazersCount),
+		Metadata:   communityMetadata("watch", watchEvent.Action, watchEvent.Sender.Login, repo),
+		Source:     "github",
+		ExternalID: starredExternalID(watchEvent.Sender.Login, repo),
+		CreatedAt:  timestamp,
+	}, nil
+}
+
+// starredExternalID identifies a star by who starred which repository and the star
+// count it produced, which the star and watch deliveries for it report alike
+func starredExternalID(login string, repo githubRefRepository) string {
+	return fmt.Sprintf("starred:%s:%s:%d", repo.FullName, login, repo.StargazersCount)
+}
+
+// communityMetadata holds the keys shared by every github.community event
+func communityMetadata(kind, action, user string, repo githubRefRepository) map[string]interface{} {
+	return map[string]interface{}{
+		"kind":           kind,
+		"action":         action,
+		"user":           user,
+		"repo":           repo.Name,
+		"repository_url": repo.HTMLURL,
+		"stars":          repo.StargazersCount,
+		"forks":          repo.ForksCount,
+		"watchers":       repo.WatchersCount,
+	}
+}
+
+// githubDeployment is the deployment on deployment and deployment_status payloads
+type githubDeployment struct {
+	ID          int64      `json:"id"`
+	SHA         string     `json:"sha"`
+	Ref         string     `json:"ref"`
+	Task        string     `json:"task"`
+	Environment string     `json:"environment"`
+	Description string     `json:"description"`
+	Creator     githubUser `json:"creator"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// TransformGitHubDeployment transforms a GitHub deployment event. A new
+// deployment is recorded as BUILDING; its outcome arrives as a deployment_status.
+func TransformGitHubDeployment(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var deploymentEvent struct {
+		Deployment githubDeployment    `json:"deployment"`
+		Repository githubRefRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &deploymentEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal deployment event: %w", err)
+	}
+
+	metadata := githubDeployMetadata(deploymentEvent.Deployment, deploymentEvent.Repository, "BUILDING")
+	metadata["event_type"] = "deployment"
+
+	return models.DashboardEvent{
+		EventType: "github.deploy",
+		Title:     githubDeployTitle(deploymentEvent.Repository.Name, "BUILDING", deploymentEvent.Deployment.Environment),
+		Metadata:  metadata,
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubDeploymentStatus transforms a GitHub deployment_status event.
+// Only outcomes are recorded: queued and in-progress statuses repeat the
+// BUILDING state of the deployment event, and inactive only means that a newer
+// deployment to the environment took over.
+func TransformGitHubDeploymentStatus(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var statusEvent struct {
+		DeploymentStatus struct {
+			State          string `json:"state"`
+			Description    string `json:"description"`
+			EnvironmentURL string `json:"environment_url"`
+			LogURL         string `json:"log_url"`
+			TargetURL      string `json:"target_url"`
+		} `json:"deployment_status"`
+		Deployment githubDeployment    `json:"deployment"`
+		Repository githubRefRepository `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &statusEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal deployment_status event: %w", err)
+	}
+
+	var status string
+	switch statusEvent.DeploymentStatus.State {
+	case "success":
+		status = "SUCCESS"
+	case "failure", "error":
+		status = "FAILED"
+	default:
+		return nil, nil
+	}
+
+	deployment := statusEvent.Deployment
+	deployStatus := statusEvent.DeploymentStatus
+	metadata := githubDeployMetadata(deployment, statusEvent.Repository, status)
+	metadata["event_type"] = "deployment_status"
+	metadata["state"] = deployStatus.State
+	metadata["description"] = deployStatus.Description
+	metadata["url"] = deployStatus.EnvironmentURL
+	metadata["logs_url"] = deployStatus.LogURL
+	if deployStatus.LogURL == "" {
+		metadata["logs_url"] = deployStatus.TargetURL
+	}
+	if !deployment.CreatedAt.IsZero() && timestamp.After(deployment.CreatedAt) {
+		metadata["duration_seconds"] = int64(timestamp.Sub(deployment.CreatedAt).Seconds())
+	}
+
+	return []models.DashboardEvent{{
+		EventType: "github.deploy",
+		Title:     githubDeployTitle(statusEvent.Repository.Name, status, deployment.Environment),
+		Metadata:  metadata,
+		CreatedAt: timestamp,
+	}}, nil
+}
+
+// githubDeployTitle matches the "<project>: <status> to <environment>" titles of other deploy events
+func githubDeployTitle(repo, status, environment string) string {
+	if environment == "" {
+		environment = "production"
+	}
+	return fmt.Sprintf("%s: %s to %s", repo, status, environment)
+}
+
+// githubDeployMetadata holds the keys shared by deployment and deployment_status events
+func githubDeployMetadata(deployment githubDeployment, repo githubRefRepository, status string) map[string]interface{} {
+	commitURL := ""
+	if repo.HTMLURL != "" && deployment.SHA != "" {
+		commitURL = repo.HTMLURL + "/commit/" + deployment.SHA
+	}
+	return map[string]interface{}{
+		"project":        repo.Name,
+		"repo":           repo.Name,
+		"repository_url": repo.HTMLURL,
+		"status":         status,
+		"environment":    deployment.Environment,
+		"deployment_id":  deployment.ID,
+		"task":           deployment.Task,
+		"branch":         deployment.Ref,
+		"commit_sha":     deployment.SHA,
+		"commit_url":     commitURL,
+		"author":         deployment.Creator.Login,
+	}
+}
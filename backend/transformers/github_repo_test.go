@@ -0,0 +1,205 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const githubRepoTestRepository = `"repository": {
+	"name": "heimdall",
+	"full_name": "acme/heimdall",
+	"html_url": "https://github.com/acme/heimdall",
+	"default_branch": "main",
+	"stargazers_count": 120,
+	"forks_count": 8,
+	"watchers_count": 120
+}`
+
+func TestTransformGitHubCreateAndDelete(t *testing.T) {
+	tests := []struct {
+		name          string
+		transform     TransformFunc
+		refType       string
+		expectedType  string
+		expectedTitle string
+	}{
+		{name: "branch created", transform: TransformGitHubCreate, refType: "branch", expectedType: "github.branch", expectedTitle: "Branch feature/x created in heimdall"},
+		{name: "branch deleted", transform: TransformGitHubDelete, refType: "branch", expectedType: "github.branch", expectedTitle: "Branch feature/x deleted in heimdall"},
+		{name: "tag created", transform: TransformGitHubCreate, refType: "tag", expectedType: "github.tag", expectedTitle: "Tag feature/x created in heimdall"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"ref": "feature/x",
+				"ref_type": "` + tt.refType + `",
+				"sender": {"login": "roe"},
+				` + githubRepoTestRepository + `
+			}`)
+
+			event, err := tt.transform(input, time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != tt.expectedType || event.Title != tt.expectedTitle {
+				t.Errorf("unexpected event: %s %s", event.EventType, event.Title)
+			}
+			if event.Metadata["author"] != "roe" || event.Metadata["ref"] != "feature/x" {
+				t.Errorf("unexpected metadata: %v", event.Metadata)
+			}
+		})
+	}
+}
+
+func TestTransformGitHubCommunityEvents(t *testing.T) {
+	tests := []struct {
+		name          string
+		transform     TransformFunc
+		input         string
+		expectedKind  string
+		expectedTitle string
+	}{
+		{
+			name:          "fork",
+			transform:     TransformGitHubFork,
+			input:         `{"forkee": {"full_name": "sam/heimdall", "html_url": "https://github.com/sam/heimdall"}, "sender": {"login": "sam"}, ` + githubRepoTestRepository + `}`,
+			expectedKind:  "fork",
+			expectedTitle: "sam forked heimdall (8 forks)",
+		},
+		{
+			name:          "star",
+			transform:     TransformGitHubStar,
+			input:         `{"action": "created", "sender": {"login": "sam"}, ` + githubRepoTestRepository + `}`,
+			expectedKind:  "star",
+			expectedTitle: "sam starred heimdall (120 stars)",
+		},
+		{
+			name:          "unstar",
+			transform:     TransformGitHubStar,
+			input:         `{"action": "deleted", "sender": {"login": "sam"}, ` + githubRepoTestRepository + `}`,
+			expectedKind:  "star",
+			expectedTitle: "sam unstarred heimdall (120 stars)",
+		},
+		{
+			name:          "watch",
+			transform:     TransformGitHubWatch,
+			input:         `{"action": "started", "sender": {"login": "sam"}, ` + githubRepoTestRepository + `}`,
+			expectedKind:  "watch",
+			expectedTitle: "sam starred heimdall (120 stars)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := tt.transform(json.RawMessage(tt.input), time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != "github.community" {
+				t.Errorf("expected github.community, got %s", event.EventType)
+			}
+			if event.Title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, event.Title)
+			}
+			if event.Metadata["kind"] != tt.expectedKind || event.Metadata["user"] != "sam" || event.Metadata["stars"] != 120 {
+				t.Errorf("unexpected metadata: %v", event.Metadata)
+			}
+		})
+	}
+}
+
+func TestTransformGitHubStarAndWatch_SharedExternalID(t *testing.T) {
+	star, err := TransformGitHubStar(json.RawMessage(`{"action": "created", "sender": {"login": "sam"}, `+githubRepoTestRepository+`}`), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	watch, err := TransformGitHubWatch(json.RawMessage(`{"action": "started", "sender": {"login": "sam"}, `+githubRepoTestRepository+`}`), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if star.ExternalID == "" || star.Source != watch.Source || star.ExternalID != watch.ExternalID {
+		t.Errorf("expected star and watch to share an identity, got %s/%s and %s/%s",
+			star.Source, star.ExternalID, watch.Source, watch.ExternalID)
+	}
+
+	unstar, err := TransformGitHubStar(json.RawMessage(`{"action": "deleted", "sender": {"login": "sam"}, `+githubRepoTestRepository+`}`), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if unstar.ExternalID != "" {
+		t.Errorf("expected an unstar to keep the delivery ID, got %s", unstar.ExternalID)
+	}
+}
+
+const githubTestDeployment = `"deployment": {
+	"id": 501,
+	"sha": "abc123",
+	"ref": "main",
+	"task": "deploy",
+	"environment": "production",
+	"creator": {"login": "roe"},
+	"created_at": "2024-01-15T10:30:00Z"
+}`
+
+func TestTransformGitHubDeployment(t *testing.T) {
+	input := json.RawMessage(`{` + githubTestDeployment + `, ` + githubRepoTestRepository + `}`)
+
+	event, err := TransformGitHubDeployment(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "github.deploy" || event.Title != "heimdall: BUILDING to production" {
+		t.Errorf("unexpected event: %s %s", event.EventType, event.Title)
+	}
+	if event.Metadata["status"] != "BUILDING" || event.Metadata["commit_sha"] != "abc123" || event.Metadata["branch"] != "main" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+}
+
+func TestTransformGitHubDeploymentStatus(t *testing.T) {
+	finished := time.Date(2024, 1, 15, 10, 33, 0, 0, time.UTC)
+
+	tests := []struct {
+		state          string
+		expectedStatus string
+	}{
+		{"success", "SUCCESS"},
+		{"failure", "FAILED"},
+		{"error", "FAILED"},
+		{"in_progress", ""},
+		{"inactive", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"deployment_status": {"state": "` + tt.state + `", "environment_url": "https://heimdall.example.com", "log_url": "https://github.com/acme/heimdall/actions/runs/1"},
+				` + githubTestDeployment + `,
+				` + githubRepoTestRepository + `
+			}`)
+
+			events, err := TransformGitHubDeploymentStatus(input, finished)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedStatus == "" {
+				if len(events) != 0 {
+					t.Errorf("expected no events, got %d", len(events))
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+
+			event := events[0]
+			if event.Metadata["status"] != tt.expectedStatus || event.Metadata["duration_seconds"] != int64(180) {
+				t.Errorf("unexpected metadata: %v", event.Metadata)
+			}
+			if event.Metadata["logs_url"] != "https://github.com/acme/heimdall/actions/runs/1" {
+				t.Errorf("unexpected logs_url: %v", event.Metadata["logs_url"])
+			}
+		})
+	}
+}
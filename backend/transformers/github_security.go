@@ -0,0 +1,287 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// ignoredCodeScanningActions are code scanning actions that repeat an existing
+// alert rather than change its state
+var ignoredCodeScanningActions = map[string]bool{
+	"appeared_in_branch": true,
+}
+
+// TransformGitHubDependabotAlert transforms a GitHub dependabot_alert event
+func TransformGitHubDependabotAlert(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var alertEvent struct {
+		Action string `json:"action"`
+		Alert  struct {
+			Number     int    `json:"number"`
+			State      string `json:"state"`
+			HTMLURL    string `json:"html_url"`
+			Dependency struct {
+				Package struct {
+					Ecosystem string `json:"ecosystem"`
+					Name      string `json:"name"`
+				} `json:"package"`
+				ManifestPath string `json:"manifest_path"`
+			} `json:"dependency"`
+			SecurityAdvisory struct {
+				GHSAID   string `json:"ghsa_id"`
+				CVEID    string `json:"cve_id"`
+				Summary  string `json:"summary"`
+				Severity string `json:"severity"`
+			} `json:"security_advisory"`
+			SecurityVulnerability struct {
+				VulnerableVersionRange string `json:"vulnerable_version_range"`
+				FirstPatchedVersion    struct {
+					Identifier string `json:"identifier"`
+				} `json:"first_patched_version"`
+			} `json:"security_vulnerability"`
+			DismissedReason string `json:"dismissed_reason"`
+		} `json:"alert"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &alertEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal dependabot_alert event: %w", err)
+	}
+
+	alert := alertEvent.Alert
+	severity := normalizeSeverity(alert.SecurityAdvisory.Severity)
+	title := fmt.Sprintf("Dependabot alert #%d %s: %s (%s) in %s",
+		alert.Number, alertEvent.Action, alert.Dependency.Package.Name, severity, alertEvent.Repository.Name)
+
+	return models.DashboardEvent{
+		EventType: "security.dependabot",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"repo":             alertEvent.Repository.Name,
+			"repository_url":   alertEvent.Repository.HTMLURL,
+			"alert_number":     alert.Number,
+			"alert_url":        alert.HTMLURL,
+			"action":           alertEvent.Action,
+			"state":            alert.State,
+			"resolution":       alert.DismissedReason,
+			"severity":         severity,
+			"package":          alert.Dependency.Package.Name,
+			"ecosystem":        alert.Dependency.Package.Ecosystem,
+			"manifest_path":    alert.Dependency.ManifestPath,
+			"vulnerable_range": alert.SecurityVulnerability.VulnerableVersionRange,
+			"patched_version":  alert.SecurityVulnerability.FirstPatchedVersion.Identifier,
+			"cve_id":           alert.SecurityAdvisory.CVEID,
+			"ghsa_id":          alert.SecurityAdvisory.GHSAID,
+			"summary":          alert.SecurityAdvisory.Summary,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubCodeScanningAlert transforms a GitHub code_scanning_alert event
+func TransformGitHubCodeScanningAlert(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var alertEvent struct {
+		Action    string `json:"action"`
+		Ref       string `json:"ref"`
+		CommitOID string `json:"commit_oid"`
+		Alert     struct {
+			Number  int    `json:"number"`
+			State   string `json:"state"`
+			HTMLURL string `json:"html_url"`
+			Rule    struct {
+				ID                    string `json:"id"`
+				Severity              string `json:"severity"`
+				SecuritySeverityLevel string `json:"security_severity_level"`
+				Description           string `json:"description"`
+			} `json:"rule"`
+			Tool struct {
+				Name string `json:"name"`
+			} `json:"tool"`
+			MostRecentInstance struct {
+				Location struct {
+					Path      string `json:"path"`
+					StartLine int    `json:"start_line"`
+				} `json:"location"`
+			} `json:"most_recent_instance"`
+			DismissedReason string `json:"dismissed_reason"`
+		} `json:"alert"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &alertEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal code_scanning_alert event: %w", err)
+	}
+
+	alert := alertEvent.Alert
+	// Security rules carry a CVSS-style level; quality rules only have note/warning/error
+	severity := normalizeSeverity(alert.Rule.SecuritySeverityLevel)
+	if severity == "unknown" {
+		severity = normalizeSeverity(alert.Rule.Severity)
+	}
+	title := fmt.Sprintf("Code scanning alert #%d %s: %s (%s) in %s",
+		alert.Number, alertEvent.Action, alert.Rule.Description, severity, alertEvent.Repository.Name)
+
+	return models.DashboardEvent{
+		EventType: "security.code_scanning",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"repo":           alertEvent.Repository.Name,
+			"repository_url": alertEvent.Repository.HTMLURL,
+			"alert_number":   alert.Number,
+			"alert_url":      alert.HTMLURL,
+			"action":         alertEvent.Action,
+			"state":          alert.State,
+			"resolution":     alert.DismissedReason,
+			"severity":       severity,
+			"rule_id":        alert.Rule.ID,
+			"rule":           alert.Rule.Description,
+			"tool":           alert.Tool.Name,
+			"path":           alert.MostRecentInstance.Location.Path,
+			"line":           alert.MostRecentInstance.Location.StartLine,
+			"branch":         alertEvent.Ref,
+			"commit_sha":     alertEvent.CommitOID,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubSecretScanningAlert transforms a GitHub secret_scanning_alert event.
+// GitHub assigns no severity to leaked secrets; they are reported as critical.
+func TransformGitHubSecretScanningAlert(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var alertEvent struct {
+		Action string `json:"action"`
+		Alert  struct {
+			Number                int    `json:"number"`
+			State                 string `json:"state"`
+			HTMLURL               string `json:"html_url"`
+			SecretType            string `json:"secret_type"`
+			SecretTypeDisplayName string `json:"secret_type_display_name"`
+			Resolution            string `json:"resolution"`
+			Validity              string `json:"validity"`
+			ResolvedBy            struct {
+				Login string `json:"login"`
+			} `json:"resolved_by"`
+		} `json:"alert"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &alertEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal secret_scanning_alert event: %w", err)
+	}
+
+	alert := alertEvent.Alert
+	secretName := alert.SecretTypeDisplayName
+	if secretName == "" {
+		secretName = alert.SecretType
+	}
+	title := fmt.Sprintf("Secret scanning alert #%d %s: %s in %s",
+		alert.Number, alertEvent.Action, secretName, alertEvent.Repository.Name)
+
+	return models.DashboardEvent{
+		EventType: "security.secret_scanning",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"repo":           alertEvent.Repository.Name,
+			"repository_url": alertEvent.Repository.HTMLURL,
+			"alert_number":   alert.Number,
+			"alert_url":      alert.HTMLURL,
+			"action":         alertEvent.Action,
+			"state":          alert.State,
+			"resolution":     alert.Resolution,
+			"resolved_by":    alert.ResolvedBy.Login,
+			"severity":       "critical",
+			"secret_type":    alert.SecretType,
+			"validity":       alert.Validity,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitHubVulnerabilityAlert transforms the legacy repository_vulnerability_alert event
+func TransformGitHubVulnerabilityAlert(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var alertEvent struct {
+		Action string `json:"action"`
+		Alert  struct {
+			ID                  int64  `json:"id"`
+			Number              int    `json:"number"`
+			State               string `json:"state"`
+			AffectedPackageName string `json:"affected_package_name"`
+			AffectedRange       string `json:"affected_range"`
+			ExternalIdentifier  string `json:"external_identifier"`
+			ExternalReference   string `json:"external_reference"`
+			GHSAID              string `json:"ghsa_id"`
+			Severity            string `json:"severity"`
+			FixedIn             string `json:"fixed_in"`
+			DismissReason       string `json:"dismiss_reason"`
+		} `json:"alert"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+	}
+
+	if err := json.Unmarshal(eventData, &alertEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal repository_vulnerability_alert event: %w", err)
+	}
+
+	alert := alertEvent.Alert
+	severity := normalizeSeverity(alert.Severity)
+	title := fmt.Sprintf("Vulnerability alert %s: %s (%s) in %s",
+		alertEvent.Action, alert.AffectedPackageName, severity, alertEvent.Repository.Name)
+
+	// The external identifier is usually a CVE, but older advisories only have a GHSA
+	cveID := ""
+	if strings.HasPrefix(alert.ExternalIdentifier, "CVE-") {
+		cveID = alert.ExternalIdentifier
+	}
+
+	return models.DashboardEvent{
+		EventType: "security.vulnerability",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"repo":             alertEvent.Repository.Name,
+			"repository_url":   alertEvent.Repository.HTMLURL,
+			"alert_number":     alert.Number,
+			"alert_url":        alert.ExternalReference,
+			"action":           alertEvent.Action,
+			"state":            alert.State,
+			"resolution":       alert.DismissReason,
+			"severity":         severity,
+			"package":          alert.AffectedPackageName,
+			"vulnerable_range": alert.AffectedRange,
+			"patched_version":  alert.FixedIn,
+			"cve_id":           cveID,
+			"ghsa_id":          alert.GHSAID,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// normalizeSeverity maps the severity vocabularies GitHub uses across alert types
+// onto low, medium, high and critical
+func normalizeSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return "critical"
+	case "high", "error":
+		return "high"
+	case "medium", "moderate", "warning":
+		return "medium"
+	case "low", "note":
+		return "low"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,178 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformGitHubDependabotAlert(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	input := json.RawMessage(`{
+		"action": "created",
+		"alert": {
+			"number": 12,
+			"state": "open",
+			"html_url": "https://github.com/roe/heimdall/security/dependabot/12",
+			"dependency": {"package": {"ecosystem": "npm", "name": "lodash"}, "manifest_path": "package-lock.json"},
+			"security_advisory": {"ghsa_id": "GHSA-jf85-cpcp-j695", "cve_id": "CVE-2019-10744", "summary": "Prototype pollution", "severity": "critical"},
+			"security_vulnerability": {"vulnerable_version_range": "< 4.17.12", "first_patched_version": {"identifier": "4.17.12"}}
+		},
+		"repository": {"name": "heimdall", "html_url": "https://github.com/roe/heimdall"}
+	}`)
+
+	event, err := TransformGitHubDependabotAlert(input, testTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if event.EventType != "security.dependabot" {
+		t.Errorf("expected security.dependabot, got %s", event.EventType)
+	}
+	if event.Title != "Dependabot alert #12 created: lodash (critical) in heimdall" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+
+	expected := map[string]interface{}{
+		"severity":        "critical",
+		"package":         "lodash",
+		"ecosystem":       "npm",
+		"cve_id":          "CVE-2019-10744",
+		"ghsa_id":         "GHSA-jf85-cpcp-j695",
+		"state":           "open",
+		"action":          "created",
+		"patched_version": "4.17.12",
+		"alert_url":       "https://github.com/roe/heimdall/security/dependabot/12",
+	}
+	for key, want := range expected {
+		if got := event.Metadata[key]; got != want {
+			t.Errorf("metadata[%q] = %v, want %v", key, got, want)
+		}
+	}
+	if !event.CreatedAt.Equal(testTime) {
+		t.Errorf("expected timestamp %v, got %v", testTime, event.CreatedAt)
+	}
+}
+
+func TestTransformGitHubCodeScanningAlert(t *testing.T) {
+	tests := []struct {
+		name             string
+		rule             string
+		expectedSeverity string
+	}{
+		{
+			name:             "security severity level",
+			rule:             `{"id": "js/sql-injection", "severity": "error", "security_severity_level": "high", "description": "SQL injection"}`,
+			expectedSeverity: "high",
+		},
+		{
+			name:             "falls back to rule severity",
+			rule:             `{"id": "js/unused-local", "severity": "note", "description": "Unused variable"}`,
+			expectedSeverity: "low",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"action": "fixed",
+				"ref": "refs/heads/main",
+				"commit_oid": "abc123",
+				"alert": {
+					"number": 3,
+					"state": "fixed",
+					"html_url": "https://github.com/roe/heimdall/security/code-scanning/3",
+					"rule": ` + tt.rule + `,
+					"tool": {"name": "CodeQL"},
+					"most_recent_instance": {"location": {"path": "src/db.js", "start_line": 42}}
+				},
+				"repository": {"name": "heimdall"}
+			}`)
+
+			event, err := TransformGitHubCodeScanningAlert(input, time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != "security.code_scanning" {
+				t.Errorf("expected security.code_scanning, got %s", event.EventType)
+			}
+			if event.Metadata["severity"] != tt.expectedSeverity {
+				t.Errorf("expected severity %s, got %v", tt.expectedSeverity, event.Metadata["severity"])
+			}
+			if event.Metadata["state"] != "fixed" || event.Metadata["tool"] != "CodeQL" || event.Metadata["line"] != 42 {
+				t.Errorf("unexpected metadata: %v", event.Metadata)
+			}
+		})
+	}
+}
+
+func TestTransformGitHubSecretScanningAlert(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "resolved",
+		"alert": {
+			"number": 5,
+			"state": "resolved",
+			"html_url": "https://github.com/roe/heimdall/security/secret-scanning/5",
+			"secret_type": "github_personal_access_token",
+			"secret_type_display_name": "GitHub Personal Access Token",
+			"resolution": "revoked",
+			"resolved_by": {"login": "roe"}
+		},
+		"repository": {"name": "heimdall"}
+	}`)
+
+	event, err := TransformGitHubSecretScanningAlert(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Title != "Secret scanning alert #5 resolved: GitHub Personal Access Token in heimdall" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+	if event.Metadata["resolution"] != "revoked" || event.Metadata["severity"] != "critical" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+}
+
+func TestTransformGitHubVulnerabilityAlert(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "create",
+		"alert": {
+			"id": 91,
+			"affected_package_name": "requests",
+			"affected_range": "<= 2.19.1",
+			"external_identifier": "CVE-2018-18074",
+			"external_reference": "https://nvd.nist.gov/vuln/detail/CVE-2018-18074",
+			"ghsa_id": "GHSA-x84v-xcm2-53pg",
+			"severity": "moderate",
+			"fixed_in": "2.20.0"
+		},
+		"repository": {"name": "heimdall"}
+	}`)
+
+	event, err := TransformGitHubVulnerabilityAlert(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "security.vulnerability" {
+		t.Errorf("expected security.vulnerability, got %s", event.EventType)
+	}
+	if event.Metadata["severity"] != "medium" {
+		t.Errorf("expected moderate to normalize to medium, got %v", event.Metadata["severity"])
+	}
+	if event.Metadata["cve_id"] != "CVE-2018-18074" || event.Metadata["patched_version"] != "2.20.0" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+}
+
+func TestCodeScanningAppearedInBranchIgnored(t *testing.T) {
+	registry := NewRegistry()
+	events, err := registry.Transform("github.code_scanning_alert",
+		json.RawMessage(`{"action": "appeared_in_branch", "alert": {"number": 3}}`), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events, got %d", len(events))
+	}
+}
@@ -187,6 +187,45 @@ func TestTransformGitHubPR(t *testing.T) {
 	}
 }
 
+func TestTransformGitHubPR_CommitSHAs(t *testing.T) {
+	input := func(merged string) json.RawMessage {
+		return json.RawMessage(`{
+			"action": "closed",
+			"number": 100,
+			"pull_request": {
+				"title": "Fix critical bug",
+				"user": {"login": "maintainer"},
+				"state": "closed",
+				"merged": ` + merged + `,
+				"merge_commit_sha": "9f8e7d6c5b4a39281706f5e4d3c2b1a098765432",
+				"head": {"ref": "bugfix", "sha": "1234567890abcdef1234567890abcdef12345678"},
+				"base": {"ref": "main"}
+			},
+			"repository": {"name": "project"}
+		}`)
+	}
+
+	merged, err := TransformGitHubPR(input("true"), testTimestamp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if merged.Metadata["merge_commit_sha"] != "9f8e7d6c5b4a39281706f5e4d3c2b1a098765432" {
+		t.Errorf("unexpected merge_commit_sha: %v", merged.Metadata["merge_commit_sha"])
+	}
+	if merged.Metadata["head_sha"] != "1234567890abcdef1234567890abcdef12345678" {
+		t.Errorf("unexpected head_sha: %v", merged.Metadata["head_sha"])
+	}
+
+	// An unmerged PR's merge_commit_sha is a test merge and is not recorded
+	closed, err := TransformGitHubPR(input("false"), testTimestamp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := closed.Metadata["merge_commit_sha"]; ok {
+		t.Errorf("expected no merge_commit_sha for an unmerged PR, got %v", closed.Metadata["merge_commit_sha"])
+	}
+}
+
 func TestTransformGitHubIssue(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -340,3 +379,31 @@ func TestTransformGitHubRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformGitHubRelease_TargetCommitish(t *testing.T) {
+	tests := []struct {
+		target       string
+		expectCommit bool
+	}{
+		{target: "main", expectCommit: false},
+		{target: "0123456789abcdef0123456789abcdef01234567", expectCommit: true},
+	}
+
+	for _, tt := range tests {
+		input := json.RawMessage(`{
+			"action": "published",
+			"release": {"tag_name": "v1.1.0", "target_commitish": "` + tt.target + `"},
+			"repository": {"name": "heimdall"}
+		}`)
+		result, err := TransformGitHubRelease(input, testTimestamp)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Metadata["target_commitish"] != tt.target {
+			t.Errorf("unexpected target_commitish: %v", result.Metadata["target_commitish"])
+		}
+		if sha, ok := result.Metadata["commit_sha"]; ok != tt.expectCommit || (ok && sha != tt.target) {
+			t.Errorf("target %s: unexpected commit_sha %v", tt.target, result.Metadata["commit_sha"])
+		}
+	}
+}
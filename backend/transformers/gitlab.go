@@ -0,0 +1,372 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// GitLab transformers emit the same metadata keys as their GitHub counterparts
+// (repo, branch, author, commit_sha, *_url, ...) so filters and stats treat both
+// forges alike. "repo" is the project path without its namespace, like GitHub's
+// repository name.
+
+// gitlabZeroSHA is the "before"/"after" SHA GitLab sends for created and deleted refs
+const gitlabZeroSHA = "0000000000000000000000000000000000000000"
+
+// gitlabProject is the project object shared by every GitLab webhook
+type gitlabProject struct {
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+}
+
+// repo returns the project path without its namespace
+func (p gitlabProject) repo() string {
+	if p.PathWithNamespace == "" {
+		return p.Name
+	}
+	return p.PathWithNamespace[strings.LastIndex(p.PathWithNamespace, "/")+1:]
+}
+
+// ignoredMergeRequestActions are merge request actions that do not represent work.
+// GitLab nests the action under object_attributes, so SkipActions does not apply.
+var ignoredMergeRequestActions = map[string]bool{
+	"approved":   true,
+	"unapproved": true,
+	"approval":   true,
+	"unapproval": true,
+}
+
+// gitlabMergeRequestActions maps GitLab merge request actions onto GitHub's
+// pull_request vocabulary; a merge is a close with merged set
+var gitlabMergeRequestActions = map[string]string{
+	"open":   "opened",
+	"close":  "closed",
+	"reopen": "reopened",
+	"merge":  "closed",
+}
+
+// gitlabPipelineConclusions maps finished pipeline statuses onto GitHub conclusions.
+// Pipelines in any other status are still running and produce no events.
+var gitlabPipelineConclusions = map[string]string{
+	"success":  "success",
+	"failed":   "failure",
+	"canceled": "cancelled",
+	"skipped":  "skipped",
+}
+
+// TransformGitLabPush emits one gitlab.push event per commit, like
+// TransformGitHubPushCommits. Pushes without commits (branch deletions) produce a
+// single summary event.
+func TransformGitLabPush(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var pushEvent struct {
+		Ref               string        `json:"ref"`
+		After             string        `json:"after"`
+		CheckoutSHA       string        `json:"checkout_sha"`
+		UserName          string        `json:"user_name"`
+		UserUsername      string        `json:"user_username"`
+		TotalCommitsCount int           `json:"total_commits_count"`
+		Project           gitlabProject `json:"project"`
+		Commits           []struct {
+			ID      string `json:"id"`
+			Message string `json:"message"`
+			URL     string `json:"url"`
+			Author  struct {
+				Name string `json:"name"`
+			} `json:"author"`
+		} `json:"commits"`
+	}
+
+	if err := json.Unmarshal(eventData, &pushEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GitLab push event: %w", err)
+	}
+
+	repo := pushEvent.Project.repo()
+	branch := strings.TrimPrefix(pushEvent.Ref, "refs/heads/")
+
+	if len(pushEvent.Commits) == 0 {
+		title := fmt.Sprintf("Push to %s/%s", repo, branch)
+		if pushEvent.After == gitlabZeroSHA {
+			title = fmt.Sprintf("Branch %s deleted from %s", branch, repo)
+		}
+		return []models.DashboardEvent{{
+			EventType: "gitlab.push",
+			Title:     title,
+			Metadata: map[string]interface{}{
+				"repo":           repo,
+				"branch":         pushEvent.Ref,
+				"author":         pushEvent.UserName,
+				"commit_sha":     pushEvent.CheckoutSHA,
+				"repository_url": pushEvent.Project.WebURL,
+				"commit_count":   0,
+				"pusher":         pushEvent.UserUsername,
+			},
+			CreatedAt: timestamp,
+		}}, nil
+	}
+
+	// GitLab caps the commits array at 20; total_commits_count is the real size
+	commitCount := pushEvent.TotalCommitsCount
+	if commitCount < len(pushEvent.Commits) {
+		commitCount = len(pushEvent.Commits)
+	}
+
+	events := make([]models.DashboardEvent, 0, len(pushEvent.Commits))
+	for _, commit := range pushEvent.Commits {
+		summary, _, _ := strings.Cut(commit.Message, "\n")
+		events = append(events, models.DashboardEvent{
+			EventType: "gitlab.push",
+			Title:     fmt.Sprintf("Commit to %s/%s: %s", repo, branch, summary),
+			Metadata: map[string]interface{}{
+				"repo":           repo,
+				"branch":         pushEvent.Ref,
+				"message":        commit.Message,
+				"author":         commit.Author.Name,
+				"commit_sha":     commit.ID,
+				"commit_url":     commit.URL,
+				"repository_url": pushEvent.Project.WebURL,
+				"commit_count":   commitCount,
+				"pusher":         pushEvent.UserUsername,
+			},
+			CreatedAt: timestamp,
+		})
+	}
+	return events, nil
+}
+
+// TransformGitLabTagPush transforms a GitLab tag push event
+func TransformGitLabTagPush(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var tagEvent struct {
+		Ref          string        `json:"ref"`
+		After        string        `json:"after"`
+		CheckoutSHA  string        `json:"checkout_sha"`
+		UserName     string        `json:"user_name"`
+		UserUsername string        `json:"user_username"`
+		Project      gitlabProject `json:"project"`
+	}
+
+	if err := json.Unmarshal(eventData, &tagEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal GitLab tag push event: %w", err)
+	}
+
+	repo := tagEvent.Project.repo()
+	tag := strings.TrimPrefix(tagEvent.Ref, "refs/tags/")
+	action := "created"
+	if tagEvent.After == gitlabZeroSHA {
+		action = "deleted"
+	}
+
+	return models.DashboardEvent{
+		EventType: "gitlab.tag",
+		Title:     fmt.Sprintf("Tag %s %s in %s", tag, action, repo),
+		Metadata: map[string]interface{}{
+			"repo":           repo,
+			"action":         action,
+			"tag":            tag,
+			"author":         tagEvent.UserName,
+			"commit_sha":     tagEvent.CheckoutSHA,
+			"repository_url": tagEvent.Project.WebURL,
+			"tag_url":        tagEvent.Project.WebURL + "/-/tags/" + tag,
+			"pusher":         tagEvent.UserUsername,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformGitLabMergeRequest transforms a GitLab merge request event. Actions are
+// reported in GitHub terms (opened, closed, reopened, synchronize, edited) with
+// merged set when the request was merged; approvals produce no events.
+func TransformGitLabMergeRequest(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var mrEvent struct {
+		User struct {
+			Username string `json:"username"`
+		} `json:"user"`
+		Project          gitlabProject `json:"project"`
+		ObjectAttributes struct {
+			IID            int    `json:"iid"`
+			Title          string `json:"title"`
+			State          string `json:"state"`
+			Action         string `json:"action"`
+			SourceBranch   string `json:"source_branch"`
+			TargetBranch   string `json:"target_branch"`
+			URL            string `json:"url"`
+			OldRev         string `json:"oldrev"`
+			MergeCommitSHA string `json:"merge_commit_sha"`
+			LastCommit     struct {
+				ID string `json:"id"`
+			} `json:"last_commit"`
+		} `json:"object_attributes"`
+	}
+
+	if err := json.Unmarshal(eventData, &mrEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GitLab merge request event: %w", err)
+	}
+
+	mr := mrEvent.ObjectAttributes
+	if ignoredMergeRequestActions[mr.Action] {
+		return nil, nil
+	}
+
+	action, ok := gitlabMergeRequestActions[mr.Action]
+	switch {
+	case ok:
+	case mr.Action == "update" && mr.OldRev != "":
+		action = "synchronize" // New commits were pushed
+	case mr.Action == "update":
+		action = "edited"
+	default:
+		action = mr.Action
+	}
+
+	// GitLab reports "opened" where GitHub reports "open"
+	state := mr.State
+	if state == "opened" {
+		state = "open"
+	}
+
+	title := fmt.Sprintf("MR !%d %s: %s [%s -> %s]", mr.IID, action, mr.Title, mr.SourceBranch, mr.TargetBranch)
+
+	metadata := map[string]interface{}{
+		"repo":           mrEvent.Project.repo(),
+		"repository_url": mrEvent.Project.WebURL,
+		"action":         action,
+		"author":         mrEvent.User.Username,
+		"state":          state,
+		"pr_url":         mr.URL,
+		"number":         mr.IID,
+		"merged":         mr.State == "merged",
+		"head_branch":    mr.SourceBranch,
+		"base_branch":    mr.TargetBranch,
+		"commit_sha":     mr.LastCommit.ID,
+	}
+	// Fast-forward merges create no merge commit
+	if mr.State == "merged" && mr.MergeCommitSHA != "" {
+		metadata["merge_commit_sha"] = mr.MergeCommitSHA
+	}
+
+	return []models.DashboardEvent{{
+		EventType: "gitlab.mr",
+		Title:     title,
+		Metadata:  metadata,
+		CreatedAt: timestamp,
+	}}, nil
+}
+
+// TransformGitLabPipeline transforms a GitLab pipeline event into the same
+// github.ci-style metadata, emitting an event only once the pipeline has finished
+func TransformGitLabPipeline(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var pipelineEvent struct {
+		ObjectAttributes struct {
+			ID       int64  `json:"id"`
+			IID      int    `json:"iid"`
+			Name     string `json:"name"`
+			Ref      string `json:"ref"`
+			Tag      bool   `json:"tag"`
+			SHA      string `json:"sha"`
+			Source   string `json:"source"`
+			Status   string `json:"status"`
+			Duration int64  `json:"duration"` // Seconds
+			URL      string `json:"url"`
+		} `json:"object_attributes"`
+		User struct {
+			Username string `json:"username"`
+		} `json:"user"`
+		Project gitlabProject `json:"project"`
+	}
+
+	if err := json.Unmarshal(eventData, &pipelineEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal GitLab pipeline event: %w", err)
+	}
+
+	pipeline := pipelineEvent.ObjectAttributes
+	conclusion, finished := gitlabPipelineConclusions[pipeline.Status]
+	if !finished {
+		return nil, nil
+	}
+
+	name := pipeline.Name
+	if name == "" {
+		name = fmt.Sprintf("#%d", pipeline.ID)
+	}
+	repo := pipelineEvent.Project.repo()
+	title := fmt.Sprintf("Pipeline %s %s on %s/%s", name, ciOutcome(conclusion), repo, pipeline.Ref)
+
+	return []models.DashboardEvent{{
+		EventType: "gitlab.ci",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"kind":             "pipeline",
+			"repo":             repo,
+			"repository_url":   pipelineEvent.Project.WebURL,
+			"workflow":         name,
+			"status":           conclusion,
+			"conclusion":       conclusion,
+			"duration_seconds": pipeline.Duration,
+			"branch":           pipeline.Ref,
+			"commit_sha":       pipeline.SHA,
+			"run_url":          pipeline.URL,
+			"run_id":           pipeline.ID,
+			"run_number":       pipeline.IID,
+			"trigger":          pipeline.Source,
+			"actor":            pipelineEvent.User.Username,
+		},
+		CreatedAt: timestamp,
+	}}, nil
+}
+
+// TransformGitLabRelease transforms a GitLab release event
+func TransformGitLabRelease(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var releaseEvent struct {
+		Action  string        `json:"action"`
+		Name    string        `json:"name"`
+		Tag     string        `json:"tag"`
+		URL     string        `json:"url"`
+		Project gitlabProject `json:"project"`
+		Commit  struct {
+			ID     string `json:"id"`
+			Author struct {
+				Name string `json:"name"`
+			} `json:"author"`
+		} `json:"commit"`
+	}
+
+	if err := json.Unmarshal(eventData, &releaseEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal GitLab release event: %w", err)
+	}
+
+	// GitHub names these created, edited and deleted
+	action := releaseEvent.Action
+	switch action {
+	case "create":
+		action = "created"
+	case "update":
+		action = "edited"
+	case "delete":
+		action = "deleted"
+	}
+
+	title := releaseEvent.Name
+	if title == "" {
+		title = releaseEvent.Tag
+	}
+
+	return models.DashboardEvent{
+		EventType: "gitlab.release",
+		Title:     fmt.Sprintf("Release %s: %s", releaseEvent.Tag, title),
+		Metadata: map[string]interface{}{
+			"repo":           releaseEvent.Project.repo(),
+			"repository_url": releaseEvent.Project.WebURL,
+			"action":         action,
+			"tag":            releaseEvent.Tag,
+			"author":         releaseEvent.Commit.Author.Name,
+			"commit_sha":     releaseEvent.Commit.ID,
+			"release_url":    releaseEvent.URL,
+			"draft":          false,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
@@ -0,0 +1,241 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const gitlabTestProject = `"project": {
+	"name": "Heimdall",
+	"path_with_namespace": "platform/tools/heimdall",
+	"web_url": "https://gitlab.com/platform/tools/heimdall"
+}`
+
+func TestTransformGitLabPush(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	input := json.RawMessage(`{
+		"object_kind": "push",
+		"ref": "refs/heads/main",
+		"after": "c2",
+		"checkout_sha": "c2",
+		"user_name": "Roe",
+		"user_username": "roe",
+		"total_commits_count": 2,
+		` + gitlabTestProject + `,
+		"commits": [
+			{"id": "c1", "message": "First change\n\nDetails", "url": "https://gitlab.com/platform/tools/heimdall/-/commit/c1", "author": {"name": "Roe"}},
+			{"id": "c2", "message": "Second change", "author": {"name": "Sam"}}
+		]
+	}`)
+
+	events, err := TransformGitLabPush(input, testTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected one event per commit, got %d", len(events))
+	}
+
+	first := events[0]
+	if first.EventType != "gitlab.push" {
+		t.Errorf("expected gitlab.push, got %s", first.EventType)
+	}
+	if first.Title != "Commit to heimdall/main: First change" {
+		t.Errorf("unexpected title: %s", first.Title)
+	}
+
+	expected := map[string]interface{}{
+		"repo":           "heimdall",
+		"branch":         "refs/heads/main",
+		"author":         "Roe",
+		"commit_sha":     "c1",
+		"commit_url":     "https://gitlab.com/platform/tools/heimdall/-/commit/c1",
+		"repository_url": "https://gitlab.com/platform/tools/heimdall",
+		"commit_count":   2,
+		"pusher":         "roe",
+	}
+	for key, want := range expected {
+		if got := first.Metadata[key]; got != want {
+			t.Errorf("metadata[%q] = %v, want %v", key, got, want)
+		}
+	}
+	if events[1].Metadata["author"] != "Sam" {
+		t.Errorf("expected per-commit author, got %v", events[1].Metadata["author"])
+	}
+}
+
+func TestTransformGitLabPush_BranchDeleted(t *testing.T) {
+	input := json.RawMessage(`{
+		"ref": "refs/heads/feature/x",
+		"after": "0000000000000000000000000000000000000000",
+		"user_name": "Roe",
+		` + gitlabTestProject + `,
+		"commits": []
+	}`)
+
+	events, err := TransformGitLabPush(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || events[0].Title != "Branch feature/x deleted from heimdall" {
+		t.Errorf("unexpected events: %+v", events)
+	}
+}
+
+func TestTransformGitLabTagPush(t *testing.T) {
+	input := json.RawMessage(`{
+		"object_kind": "tag_push",
+		"ref": "refs/tags/v1.2.0",
+		"after": "abc123",
+		"checkout_sha": "abc123",
+		"user_name": "Roe",
+		` + gitlabTestProject + `
+	}`)
+
+	event, err := TransformGitLabTagPush(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "gitlab.tag" || event.Title != "Tag v1.2.0 created in heimdall" {
+		t.Errorf("unexpected event: %s %s", event.EventType, event.Title)
+	}
+	if event.Metadata["tag_url"] != "https://gitlab.com/platform/tools/heimdall/-/tags/v1.2.0" {
+		t.Errorf("unexpected tag_url: %v", event.Metadata["tag_url"])
+	}
+}
+
+func TestTransformGitLabMergeRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		action         string
+		state          string
+		oldrev         string
+		mergeCommitSHA string
+		expectedAction string
+		expectedMerged bool
+		expectIgnored  bool
+	}{
+		{name: "opened", action: "open", state: "opened", expectedAction: "opened"},
+		{name: "merged", action: "merge", state: "merged", mergeCommitSHA: "0a1b2c", expectedAction: "closed", expectedMerged: true},
+		{name: "new commits", action: "update", state: "opened", oldrev: "abc", expectedAction: "synchronize"},
+		{name: "edited", action: "update", state: "opened", expectedAction: "edited"},
+		{name: "approval ignored", action: "approved", state: "opened", expectIgnored: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"object_kind": "merge_request",
+				"user": {"username": "roe"},
+				` + gitlabTestProject + `,
+				"object_attributes": {
+					"iid": 7,
+					"title": "Add GitLab support",
+					"state": "` + tt.state + `",
+					"action": "` + tt.action + `",
+					"oldrev": "` + tt.oldrev + `",
+					"source_branch": "feature/gitlab",
+					"target_branch": "main",
+					"url": "https://gitlab.com/platform/tools/heimdall/-/merge_requests/7",
+					"merge_commit_sha": "` + tt.mergeCommitSHA + `",
+					"last_commit": {"id": "def456"}
+				}
+			}`)
+
+			events, err := TransformGitLabMergeRequest(input, time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectIgnored {
+				if len(events) != 0 {
+					t.Errorf("expected no events, got %d", len(events))
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+
+			event := events[0]
+			if event.EventType != "gitlab.mr" {
+				t.Errorf("expected gitlab.mr, got %s", event.EventType)
+			}
+			if event.Metadata["action"] != tt.expectedAction || event.Metadata["merged"] != tt.expectedMerged {
+				t.Errorf("unexpected action/merged: %v/%v", event.Metadata["action"], event.Metadata["merged"])
+			}
+			if event.Metadata["number"] != 7 || event.Metadata["head_branch"] != "feature/gitlab" || event.Metadata["base_branch"] != "main" {
+				t.Errorf("unexpected metadata: %v", event.Metadata)
+			}
+			if mergeCommit, ok := event.Metadata["merge_commit_sha"]; ok != tt.expectedMerged || (ok && mergeCommit != tt.mergeCommitSHA) {
+				t.Errorf("unexpected merge_commit_sha: %v", event.Metadata["merge_commit_sha"])
+			}
+		})
+	}
+}
+
+func TestTransformGitLabPipeline(t *testing.T) {
+	input := json.RawMessage(`{
+		"object_kind": "pipeline",
+		"object_attributes": {
+			"id": 31,
+			"iid": 4,
+			"ref": "main",
+			"sha": "abc123",
+			"source": "push",
+			"status": "failed",
+			"duration": 245,
+			"url": "https://gitlab.com/platform/tools/heimdall/-/pipelines/31"
+		},
+		"user": {"username": "roe"},
+		` + gitlabTestProject + `
+	}`)
+
+	events, err := TransformGitLabPipeline(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.EventType != "gitlab.ci" {
+		t.Errorf("expected gitlab.ci, got %s", event.EventType)
+	}
+	if event.Title != "Pipeline #31 failed on heimdall/main" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+	if event.Metadata["conclusion"] != "failure" || event.Metadata["duration_seconds"] != int64(245) {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+
+	running := json.RawMessage(`{"object_attributes": {"id": 31, "status": "running"}}`)
+	if events, err := TransformGitLabPipeline(running, time.Now()); err != nil || len(events) != 0 {
+		t.Errorf("expected no events for a running pipeline, got %d (%v)", len(events), err)
+	}
+}
+
+func TestTransformGitLabRelease(t *testing.T) {
+	input := json.RawMessage(`{
+		"object_kind": "release",
+		"action": "create",
+		"name": "Heimdall 1.2",
+		"tag": "v1.2.0",
+		"url": "https://gitlab.com/platform/tools/heimdall/-/releases/v1.2.0",
+		` + gitlabTestProject + `,
+		"commit": {"id": "abc123", "author": {"name": "Roe"}}
+	}`)
+
+	event, err := TransformGitLabRelease(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "gitlab.release" || event.Title != "Release v1.2.0: Heimdall 1.2" {
+		t.Errorf("unexpected event: %s %s", event.EventType, event.Title)
+	}
+	if event.Metadata["action"] != "created" || event.Metadata["repo"] != "heimdall" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+}
@@ -0,0 +1,79 @@
+package transformers
+
+import (
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// trackedIssue is a planning issue normalized across issue trackers (Linear, Jira)
+type trackedIssue struct {
+	Provider       string
+	Key            string // Human-readable identifier, e.g. ENG-123
+	Title          string
+	URL            string
+	Status         string // Workflow state name as configured in the tracker
+	StatusCategory string // todo, in_progress, done or canceled
+	PreviousStatus string
+	Assignee       string
+	Priority       string
+	Project        string
+	Team           string
+	IssueType      string
+	Actor          string
+}
+
+// issueActionVerbs phrases issue actions for titles; transitions and
+// assignments are phrased with the new status and assignee instead
+var issueActionVerbs = map[string]string{
+	"created":       "created",
+	"updated":       "updated",
+	"removed":       "deleted",
+	"reprioritized": "reprioritized",
+}
+
+// trackedIssueEvent builds the issues.* event shared by the issue tracker transformers
+func trackedIssueEvent(eventType, action string, issue trackedIssue, timestamp time.Time) models.DashboardEvent {
+	var verb string
+	switch action {
+	case "transitioned":
+		verb = "moved to " + issue.Status
+	case "assigned":
+		verb = "assigned to " + issue.Assignee
+		if issue.Assignee == "" {
+			verb = "unassigned"
+		}
+	default:
+		verb = issueActionVerbs[action]
+		if verb == "" {
+			verb = action
+		}
+	}
+
+	metadata := map[string]interface{}{
+		"provider":        issue.Provider,
+		"action":          action,
+		"key":             issue.Key,
+		"issue_title":     issue.Title,
+		"issue_url":       issue.URL,
+		"status":          issue.Status,
+		"status_category": issue.StatusCategory,
+		"assignee":        issue.Assignee,
+		"priority":        issue.Priority,
+		"project":         issue.Project,
+		"team":            issue.Team,
+		"issue_type":      issue.IssueType,
+		"author":          issue.Actor,
+	}
+	if action == "transitioned" && issue.PreviousStatus != "" {
+		metadata["previous_status"] = issue.PreviousStatus
+	}
+
+	return models.DashboardEvent{
+		EventType: eventType,
+		Title:     fmt.Sprintf("%s %s: %s", issue.Key, verb, issue.Title),
+		Metadata:  metadata,
+		CreatedAt: timestamp,
+	}
+}
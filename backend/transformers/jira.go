@@ -0,0 +1,139 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// jiraStatusCategories maps Jira status category keys to status categories
+var jiraStatusCategories = map[string]string{
+	"new":           "todo",
+	"indeterminate": "in_progress",
+	"done":          "done",
+}
+
+// jiraUser is a user on a Jira webhook payload
+type jiraUser struct {
+	DisplayName string `json:"displayName"`
+}
+
+// TransformJiraIssue transforms a Jira jira:issue_created or jira:issue_updated
+// webhook into an issues.jira event. An update whose changelog moves the status
+// is a transition. Other updates are only recorded when they reassign the issue
+// or change its priority; comments and field edits are skipped.
+func TransformJiraIssue(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var jiraEvent struct {
+		WebhookEvent string   `json:"webhookEvent"`
+		User         jiraUser `json:"user"`
+		Issue        struct {
+			ID     string `json:"id"`
+			Key    string `json:"key"`
+			Self   string `json:"self"`
+			Fields struct {
+				Summary string `json:"summary"`
+				Status  struct {
+					Name           string `json:"name"`
+					StatusCategory struct {
+						Key string `json:"key"`
+					} `json:"statusCategory"`
+				} `json:"status"`
+				Assignee *jiraUser `json:"assignee"`
+				Reporter *jiraUser `json:"reporter"`
+				Priority *struct {
+					Name string `json:"name"`
+				} `json:"priority"`
+				Project struct {
+					Key  string `json:"key"`
+					Name string `json:"name"`
+				} `json:"project"`
+				IssueType struct {
+					Name string `json:"name"`
+				} `json:"issuetype"`
+			} `json:"fields"`
+		} `json:"issue"`
+		Changelog struct {
+			Items []struct {
+				Field      string `json:"field"`
+				FromString string `json:"fromString"`
+				ToString   string `json:"toString"`
+			} `json:"items"`
+		} `json:"changelog"`
+	}
+
+	if err := json.Unmarshal(eventData, &jiraEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Jira issue event: %w", err)
+	}
+
+	fields := jiraEvent.Issue.Fields
+	issue := trackedIssue{
+		Provider:       "jira",
+		Key:            jiraEvent.Issue.Key,
+		Title:          fields.Summary,
+		URL:            jiraBrowseURL(jiraEvent.Issue.Self, jiraEvent.Issue.Key),
+		Status:         fields.Status.Name,
+		StatusCategory: jiraStatusCategories[fields.Status.StatusCategory.Key],
+		Project:        fields.Project.Name,
+		IssueType:      fields.IssueType.Name,
+		Actor:          jiraEvent.User.DisplayName,
+	}
+	if fields.Assignee != nil {
+		issue.Assignee = fields.Assignee.DisplayName
+	}
+	if fields.Priority != nil {
+		issue.Priority = fields.Priority.Name
+	}
+	if issue.Actor == "" && fields.Reporter != nil {
+		issue.Actor = fields.Reporter.DisplayName
+	}
+
+	var action string
+	switch jiraEvent.WebhookEvent {
+	case "jira:issue_created":
+		action = "created"
+	case "jira:issue_updated":
+		// A status change wins over other changes made in the same update
+		var assigned, reprioritized bool
+		for _, item := range jiraEvent.Changelog.Items {
+			switch item.Field {
+			case "status":
+				action = "transitioned"
+				issue.PreviousStatus = item.FromString
+			case "assignee":
+				assigned = true
+			case "priority":
+				reprioritized = true
+			}
+		}
+		if action == "" {
+			switch {
+			case assigned:
+				action = "assigned"
+			case reprioritized:
+				action = "reprioritized"
+			default:
+				return nil, nil
+			}
+		}
+	default:
+		return nil, nil
+	}
+
+	event := trackedIssueEvent("issues.jira", action, issue, timestamp)
+	event.Metadata["issue_id"] = jiraEvent.Issue.ID
+	event.Metadata["project_key"] = fields.Project.Key
+	return []models.DashboardEvent{event}, nil
+}
+
+// jiraBrowseURL turns the REST URL of an issue into the URL people open in a
+// browser, e.g. https://acme.atlassian.net/browse/ENG-1
+func jiraBrowseURL(self, key string) string {
+	i := strings.Index(self, "/rest/")
+	if i < 0 || key == "" {
+		return ""
+	}
+	return self[:i] + "/browse/" + key
+}
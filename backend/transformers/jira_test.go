@@ -0,0 +1,94 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformJiraIssue(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		webhookEvent   string
+		changelog      string
+		expectedAction string
+		expectedTitle  string
+	}{
+		{name: "created", webhookEvent: "jira:issue_created", expectedAction: "created", expectedTitle: "OPS-7 created: Rotate database credentials"},
+		{name: "transition", webhookEvent: "jira:issue_updated", changelog: `{"field":"assignee","fromString":null,"toString":"Ada"},{"field":"status","fromString":"To Do","toString":"Done"}`, expectedAction: "transitioned", expectedTitle: "OPS-7 moved to Done: Rotate database credentials"},
+		{name: "assignment", webhookEvent: "jira:issue_updated", changelog: `{"field":"assignee","fromString":null,"toString":"Ada"}`, expectedAction: "assigned", expectedTitle: "OPS-7 assigned to Ada: Rotate database credentials"},
+		{name: "priority change", webhookEvent: "jira:issue_updated", changelog: `{"field":"priority","fromString":"Medium","toString":"High"}`, expectedAction: "reprioritized", expectedTitle: "OPS-7 reprioritized: Rotate database credentials"},
+		{name: "description edit skipped", webhookEvent: "jira:issue_updated", changelog: `{"field":"description","fromString":"a","toString":"b"}`},
+		{name: "comment skipped", webhookEvent: "jira:issue_updated"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"timestamp": 1705314600000,
+				"webhookEvent": "` + tt.webhookEvent + `",
+				"user": {"displayName": "Grace"},
+				"issue": {
+					"id": "10042",
+					"key": "OPS-7",
+					"self": "https://acme.atlassian.net/rest/api/2/issue/10042",
+					"fields": {
+						"summary": "Rotate database credentials",
+						"status": {"name": "Done", "statusCategory": {"key": "done"}},
+						"assignee": {"displayName": "Ada"},
+						"priority": {"name": "High"},
+						"project": {"key": "OPS", "name": "Operations"},
+						"issuetype": {"name": "Task"}
+					}
+				},
+				"changelog": {"id": "20001", "items": [` + tt.changelog + `]}
+			}`)
+
+			events, err := TransformJiraIssue(input, testTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedAction == "" {
+				if len(events) != 0 {
+					t.Fatalf("expected update to be skipped, got %d events", len(events))
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+
+			event := events[0]
+			if event.EventType != "issues.jira" {
+				t.Errorf("expected event type issues.jira, got %s", event.EventType)
+			}
+			if event.Title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, event.Title)
+			}
+			expected := map[string]interface{}{
+				"provider":        "jira",
+				"action":          tt.expectedAction,
+				"key":             "OPS-7",
+				"status":          "Done",
+				"status_category": "done",
+				"assignee":        "Ada",
+				"priority":        "High",
+				"project":         "Operations",
+				"project_key":     "OPS",
+				"issue_type":      "Task",
+				"author":          "Grace",
+				"issue_url":       "https://acme.atlassian.net/browse/OPS-7",
+			}
+			for key, value := range expected {
+				if event.Metadata[key] != value {
+					t.Errorf("expected %s %v, got %v", key, value, event.Metadata[key])
+				}
+			}
+			if tt.expectedAction == "transitioned" && event.Metadata["previous_status"] != "To Do" {
+				t.Errorf("expected previous_status To Do, got %v", event.Metadata["previous_status"])
+			}
+		})
+	}
+}
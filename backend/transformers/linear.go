@@ -0,0 +1,188 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// ignoredLinearCommentActions are comment actions that do not represent new work
+var ignoredLinearCommentActions = map[string]bool{
+	"update": true,
+	"remove": true,
+}
+
+// linearStatusCategories maps Linear workflow state types to status categories
+var linearStatusCategories = map[string]string{
+	"triage":    "todo",
+	"backlog":   "todo",
+	"unstarted": "todo",
+	"started":   "in_progress",
+	"completed": "done",
+	"canceled":  "canceled",
+}
+
+// linearPriorities names Linear's numeric priorities, for payloads without priorityLabel
+var linearPriorities = map[int]string{
+	0: "No priority",
+	1: "Urgent",
+	2: "High",
+	3: "Medium",
+	4: "Low",
+}
+
+// linearActor is the user or integration that triggered a Linear webhook
+type linearActor struct {
+	Name string `json:"name"`
+}
+
+// TransformLinearIssue transforms a Linear Issue webhook into an issues.linear event.
+// Updates are only recorded when they move the issue to another workflow state,
+// reassign it or change its priority; edits to titles, labels or estimates are skipped.
+func TransformLinearIssue(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var issueEvent struct {
+		Action string      `json:"action"`
+		Actor  linearActor `json:"actor"`
+		URL    string      `json:"url"`
+		Data   struct {
+			ID            string `json:"id"`
+			Identifier    string `json:"identifier"`
+			Title         string `json:"title"`
+			URL           string `json:"url"`
+			Priority      int    `json:"priority"`
+			PriorityLabel string `json:"priorityLabel"`
+			State         struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"state"`
+			Assignee *linearActor `json:"assignee"`
+			Creator  *linearActor `json:"creator"`
+			Team     struct {
+				Key  string `json:"key"`
+				Name string `json:"name"`
+			} `json:"team"`
+			Project *struct {
+				Name string `json:"name"`
+			} `json:"project"`
+		} `json:"data"`
+		UpdatedFrom map[string]json.RawMessage `json:"updatedFrom"`
+	}
+
+	if err := json.Unmarshal(eventData, &issueEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal Linear issue event: %w", err)
+	}
+
+	var action string
+	switch issueEvent.Action {
+	case "create":
+		action = "created"
+	case "remove":
+		action = "removed"
+	case "update":
+		// updatedFrom holds the previous value of every changed field
+		if _, ok := issueEvent.UpdatedFrom["stateId"]; ok {
+			action = "transitioned"
+		} else if _, ok := issueEvent.UpdatedFrom["assigneeId"]; ok {
+			action = "assigned"
+		} else if _, ok := issueEvent.UpdatedFrom["priority"]; ok {
+			action = "reprioritized"
+		} else {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+
+	data := issueEvent.Data
+	issue := trackedIssue{
+		Provider:       "linear",
+		Key:            data.Identifier,
+		Title:          data.Title,
+		URL:            data.URL,
+		Status:         data.State.Name,
+		StatusCategory: linearStatusCategories[data.State.Type],
+		Priority:       data.PriorityLabel,
+		Team:           data.Team.Name,
+		Actor:          issueEvent.Actor.Name,
+	}
+	if issue.URL == "" {
+		issue.URL = issueEvent.URL
+	}
+	if issue.Priority == "" {
+		issue.Priority = linearPriorities[data.Priority]
+	}
+	if data.Assignee != nil {
+		issue.Assignee = data.Assignee.Name
+	}
+	if data.Project != nil {
+		issue.Project = data.Project.Name
+	}
+	if issue.Actor == "" && data.Creator != nil {
+		issue.Actor = data.Creator.Name
+	}
+
+	event := trackedIssueEvent("issues.linear", action, issue, timestamp)
+	event.Metadata["issue_id"] = data.ID
+	event.Metadata["team_key"] = data.Team.Key
+	return []models.DashboardEvent{event}, nil
+}
+
+// TransformLinearComment transforms a Linear Comment webhook into an issues.linear_comment event
+func TransformLinearComment(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var commentEvent struct {
+		Action string      `json:"action"`
+		Actor  linearActor `json:"actor"`
+		URL    string      `json:"url"`
+		Data   struct {
+			ID    string       `json:"id"`
+			Body  string       `json:"body"`
+			URL   string       `json:"url"`
+			User  *linearActor `json:"user"`
+			Issue struct {
+				ID         string `json:"id"`
+				Identifier string `json:"identifier"`
+				Title      string `json:"title"`
+				URL        string `json:"url"`
+			} `json:"issue"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(eventData, &commentEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Linear comment event: %w", err)
+	}
+
+	data := commentEvent.Data
+	author := commentEvent.Actor.Name
+	if data.User != nil && data.User.Name != "" {
+		author = data.User.Name
+	}
+	// Older comment payloads omit the issue identifier; fall back to its title alone
+	subject := data.Issue.Title
+	if data.Issue.Identifier != "" {
+		subject = data.Issue.Identifier + ": " + data.Issue.Title
+	}
+	commentURL := data.URL
+	if commentURL == "" {
+		commentURL = commentEvent.URL
+	}
+
+	return models.DashboardEvent{
+		EventType: "issues.linear_comment",
+		Title:     fmt.Sprintf("Comment by %s on %s", author, subject),
+		Metadata: map[string]interface{}{
+			"provider":    "linear",
+			"action":      "commented",
+			"author":      author,
+			"comment_id":  data.ID,
+			"comment_url": commentURL,
+			"issue_id":    data.Issue.ID,
+			"key":         data.Issue.Identifier,
+			"issue_title": data.Issue.Title,
+			"issue_url":   data.Issue.URL,
+			"body":        bodyExcerpt(data.Body),
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
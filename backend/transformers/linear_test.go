@@ -0,0 +1,126 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformLinearIssue(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		action         string
+		updatedFrom    string
+		expectedAction string
+		expectedTitle  string
+	}{
+		{name: "created", action: "create", expectedAction: "created", expectedTitle: "ENG-42 created: Fix login redirect"},
+		{name: "state changed", action: "update", updatedFrom: `{"stateId":"s0","updatedAt":"x"}`, expectedAction: "transitioned", expectedTitle: "ENG-42 moved to In Progress: Fix login redirect"},
+		{name: "reassigned", action: "update", updatedFrom: `{"assigneeId":null}`, expectedAction: "assigned", expectedTitle: "ENG-42 assigned to Ada: Fix login redirect"},
+		{name: "priority changed", action: "update", updatedFrom: `{"priority":3}`, expectedAction: "reprioritized", expectedTitle: "ENG-42 reprioritized: Fix login redirect"},
+		{name: "removed", action: "remove", expectedAction: "removed", expectedTitle: "ENG-42 deleted: Fix login redirect"},
+		{name: "title edit skipped", action: "update", updatedFrom: `{"title":"Fix login"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedFrom := tt.updatedFrom
+			if updatedFrom == "" {
+				updatedFrom = "null"
+			}
+			input := json.RawMessage(`{
+				"action": "` + tt.action + `",
+				"type": "Issue",
+				"actor": {"name": "Grace"},
+				"url": "https://linear.app/acme/issue/ENG-42/fix-login-redirect",
+				"data": {
+					"id": "iss-1",
+					"identifier": "ENG-42",
+					"title": "Fix login redirect",
+					"priority": 2,
+					"state": {"name": "In Progress", "type": "started"},
+					"assignee": {"name": "Ada"},
+					"team": {"key": "ENG", "name": "Engineering"},
+					"project": {"name": "Auth revamp"}
+				},
+				"updatedFrom": ` + updatedFrom + `
+			}`)
+
+			events, err := TransformLinearIssue(input, testTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectedAction == "" {
+				if len(events) != 0 {
+					t.Fatalf("expected update to be skipped, got %d events", len(events))
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(events))
+			}
+
+			event := events[0]
+			if event.EventType != "issues.linear" {
+				t.Errorf("expected event type issues.linear, got %s", event.EventType)
+			}
+			if event.Title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, event.Title)
+			}
+			expected := map[string]interface{}{
+				"provider":        "linear",
+				"action":          tt.expectedAction,
+				"key":             "ENG-42",
+				"status":          "In Progress",
+				"status_category": "in_progress",
+				"assignee":        "Ada",
+				"priority":        "High",
+				"project":         "Auth revamp",
+				"team":            "Engineering",
+				"author":          "Grace",
+				"issue_url":       "https://linear.app/acme/issue/ENG-42/fix-login-redirect",
+			}
+			for key, value := range expected {
+				if event.Metadata[key] != value {
+					t.Errorf("expected %s %v, got %v", key, value, event.Metadata[key])
+				}
+			}
+		})
+	}
+}
+
+func TestTransformLinearComment(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "create",
+		"type": "Comment",
+		"url": "https://linear.app/acme/issue/ENG-42#comment-c1",
+		"data": {
+			"id": "c1",
+			"body": "Reproduced on\n\nSafari too",
+			"user": {"name": "Ada"},
+			"issue": {"id": "iss-1", "identifier": "ENG-42", "title": "Fix login redirect"}
+		}
+	}`)
+
+	event, err := TransformLinearComment(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "issues.linear_comment" {
+		t.Errorf("expected event type issues.linear_comment, got %s", event.EventType)
+	}
+	if expected := "Comment by Ada on ENG-42: Fix login redirect"; event.Title != expected {
+		t.Errorf("expected title %q, got %q", expected, event.Title)
+	}
+	if event.Metadata["body"] != "Reproduced on Safari too" || event.Metadata["comment_url"] != "https://linear.app/acme/issue/ENG-42#comment-c1" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+
+	// Comment edits and deletions are skipped by the registry
+	events, err := NewRegistry().Transform("linear.comment", json.RawMessage(`{"action":"update"}`), time.Now())
+	if err != nil || len(events) != 0 {
+		t.Errorf("expected comment update to be skipped, got %d events (err %v)", len(events), err)
+	}
+}
@@ -0,0 +1,393 @@
+package transformers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// MappingConfig declares webhook sources whose payloads are turned into events by
+// JSON-path mappings instead of Go transformers. It is loaded from a JSON file:
+//
+//	{
+//	  "sources": [{
+//	    "name": "nightly-backup",
+//	    "auth": {"scheme": "token", "header": "X-Backup-Token", "secret_env": "BACKUP_WEBHOOK_TOKEN"},
+//	    "delivery_id": {"path": "$.run_id"},
+//	    "timestamp": {"path": "$.finished_at"},
+//	    "rules": [{
+//	      "match": {"path": "$.status", "equals": "failed"},
+//	      "event_type": "cron.failed",
+//	      "title": "Backup {{$.job.name}} failed",
+//	      "metadata": {"job": "$.job.name", "status": "$.status", "runner": "backup-bot"}
+//	    }]
+//	  }]
+//	}
+type MappingConfig struct {
+	Sources []MappingSource `json:"sources"`
+}
+
+// MappingSource is one custom webhook endpoint (/api/webhook/custom/{name})
+type MappingSource struct {
+	Name       string           `json:"name"`
+	Auth       *MappingAuth     `json:"auth,omitempty"`
+	DeliveryID *MappingSelector `json:"delivery_id,omitempty"` // Used for deduplication
+	Timestamp  *MappingSelector `json:"timestamp,omitempty"`   // RFC3339 or Unix seconds/milliseconds
+	Rules      []MappingRule    `json:"rules"`                 // First matching rule wins
+}
+
+// MappingAuth describes how deliveries to a custom source are authenticated.
+// The secret itself is read from the environment variable named by SecretEnv.
+type MappingAuth struct {
+	Scheme    string `json:"scheme"` // "token" or "hmac-sha256"
+	Header    string `json:"header"`
+	SecretEnv string `json:"secret_env"`
+}
+
+// MappingSelector reads a value from a request header or a JSON path in the body
+type MappingSelector struct {
+	Header string `json:"header,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// MappingMatch selects a rule when the selected value equals Equals, or is present
+// and non-empty when Equals is unset
+type MappingMatch struct {
+	MappingSelector
+	Equals string `json:"equals,omitempty"`
+}
+
+// MappingRule turns a matched payload into a dashboard event.
+// Title placeholders ({{$.path}}) and metadata values starting with "$" are JSON
+// paths into the payload; other metadata values are stored as literals.
+type MappingRule struct {
+	Match     *MappingMatch     `json:"match,omitempty"` // Nil matches every delivery
+	EventType string            `json:"event_type"`
+	Title     string            `json:"title"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+}
+
+// Supported MappingAuth schemes
+const (
+	MappingAuthToken      = "token"
+	MappingAuthHMACSHA256 = "hmac-sha256"
+)
+
+var (
+	mappingSourceName   = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+	mappingEventType    = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)+$`)
+	mappingPlaceholder  = regexp.MustCompile(`{{\s*([^}]*?)\s*}}`)
+	mappingPathSegment  = regexp.MustCompile(`^([^\[\]]*)((?:\[\d+\])*)$`)
+	mappingPathIndexRef = regexp.MustCompile(`\[(\d+)\]`)
+)
+
+// LoadMappingConfig reads and validates a mapping configuration file
+func LoadMappingConfig(path string) (*MappingConfig, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- path comes from trusted configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping config: %w", err)
+	}
+
+	var cfg MappingConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping config %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid mapping config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks names, event types, paths and templates so that mistakes are
+// reported at startup rather than when the first delivery arrives
+func (c *MappingConfig) Validate() error {
+	if len(c.Sources) == 0 {
+		return fmt.Errorf("no sources defined")
+	}
+
+	sourceNames := make(map[string]bool)
+	eventTypes := make(map[string]string)
+	for i := range c.Sources {
+		src := &c.Sources[i]
+		if !mappingSourceName.MatchString(src.Name) {
+			return fmt.Errorf("source %d: name %q must be 1-32 lowercase letters, digits or dashes", i, src.Name)
+		}
+		if sourceNames[src.Name] {
+			return fmt.Errorf("source %s: duplicate name", src.Name)
+		}
+		sourceNames[src.Name] = true
+
+		if err := src.validate(); err != nil {
+			return fmt.Errorf("source %s: %w", src.Name, err)
+		}
+
+		for _, rule := range src.Rules {
+			if other, ok := eventTypes[rule.EventType]; ok {
+				return fmt.Errorf("source %s: event type %s is already defined by source %s", src.Name, rule.EventType, other)
+			}
+			eventTypes[rule.EventType] = src.Name
+		}
+	}
+	return nil
+}
+
+func (s *MappingSource) validate() error {
+	if s.Auth != nil {
+		if s.Auth.Scheme != MappingAuthToken && s.Auth.Scheme != MappingAuthHMACSHA256 {
+			return fmt.Errorf("auth scheme must be %q or %q", MappingAuthToken, MappingAuthHMACSHA256)
+		}
+		if s.Auth.Header == "" || s.Auth.SecretEnv == "" {
+			return fmt.Errorf("auth requires header and secret_env")
+		}
+	}
+
+	for name, sel := range map[string]*MappingSelector{"delivery_id": s.DeliveryID, "timestamp": s.Timestamp} {
+		if sel == nil {
+			continue
+		}
+		if err := sel.validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	if len(s.Rules) == 0 {
+		return fmt.Errorf("no rules defined")
+	}
+	for i, rule := range s.Rules {
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (sel *MappingSelector) validate() error {
+	if (sel.Header == "") == (sel.Path == "") {
+		return fmt.Errorf("exactly one of header or path is required")
+	}
+	if sel.Path != "" {
+		if _, err := parsePath(sel.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MappingRule) validate() error {
+	if !mappingEventType.MatchString(r.EventType) {
+		return fmt.Errorf("event_type %q must be dotted lowercase (e.g. cron.failed)", r.EventType)
+	}
+	if strings.TrimSpace(r.Title) == "" {
+		return fmt.Errorf("title is required")
+	}
+	if strings.Count(r.Title, "{{") != strings.Count(r.Title, "}}") {
+		return fmt.Errorf("title has unbalanced placeholders")
+	}
+	for _, m := range mappingPlaceholder.FindAllStringSubmatch(r.Title, -1) {
+		if _, err := parsePath(m[1]); err != nil {
+			return fmt.Errorf("title: %w", err)
+		}
+	}
+	if r.Match != nil {
+		if err := r.Match.validate(); err != nil {
+			return fmt.Errorf("match: %w", err)
+		}
+	}
+	for key, value := range r.Metadata {
+		if key == "" {
+			return fmt.Errorf("metadata keys must not be empty")
+		}
+		if strings.HasPrefix(value, "$") {
+			if _, err := parsePath(value); err != nil {
+				return fmt.Errorf("metadata %s: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// RegisterMappings adds a transformer for every mapping rule. Rules may not
+// replace a built-in or previously registered event type.
+func (r *Registry) RegisterMappings(cfg *MappingConfig) error {
+	for _, src := range cfg.Sources {
+		for _, rule := range src.Rules {
+			if r.HasTransformer(rule.EventType) {
+				return fmt.Errorf("source %s: event type %s already has a transformer", src.Name, rule.EventType)
+			}
+		}
+	}
+
+	for _, src := range cfg.Sources {
+		for _, rule := range src.Rules {
+			r.Register(rule.EventType, NewMappingTransform(rule))
+		}
+	}
+	return nil
+}
+
+// NewMappingTransform returns a TransformFunc that renders a rule against a payload
+func NewMappingTransform(rule MappingRule) TransformFunc {
+	return func(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+		doc, err := DecodeMappingPayload(eventData)
+		if err != nil {
+			return models.DashboardEvent{}, err
+		}
+
+		title := mappingPlaceholder.ReplaceAllStringFunc(rule.Title, func(placeholder string) string {
+			path := mappingPlaceholder.FindStringSubmatch(placeholder)[1]
+			value, _ := LookupPath(doc, path)
+			return stringifyMappingValue(value)
+		})
+
+		metadata := make(map[string]interface{}, len(rule.Metadata))
+		for key, value := range rule.Metadata {
+			if !strings.HasPrefix(value, "$") {
+				metadata[key] = value
+				continue
+			}
+			if v, ok := LookupPath(doc, value); ok {
+				metadata[key] = v
+			}
+		}
+
+		return models.DashboardEvent{
+			EventType: rule.EventType,
+			Title:     title,
+			Metadata:  metadata,
+			CreatedAt: timestamp,
+		}, nil
+	}
+}
+
+// Matches reports whether the delivery satisfies the match condition
+func (m *MappingMatch) Matches(header http.Header, doc interface{}) bool {
+	value, ok := m.Value(header, doc)
+	if m.Equals == "" {
+		return ok && value != ""
+	}
+	return ok && value == m.Equals
+}
+
+// Value returns the selected header or payload value as a string
+func (sel *MappingSelector) Value(header http.Header, doc interface{}) (string, bool) {
+	if sel.Header != "" {
+		value := header.Get(sel.Header)
+		return value, value != ""
+	}
+	value, ok := LookupPath(doc, sel.Path)
+	if !ok {
+		return "", false
+	}
+	return stringifyMappingValue(value), true
+}
+
+// DecodeMappingPayload parses a JSON payload for path lookups. Numbers are kept as
+// json.Number so large IDs survive unchanged.
+func DecodeMappingPayload(data []byte) (interface{}, error) {
+	var doc interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+	return doc, nil
+}
+
+// LookupPath resolves a path such as "$.job.steps[0].name" (the "$." prefix is
+// optional). Missing keys, out-of-range indexes and null values are not found.
+func LookupPath(doc interface{}, path string) (interface{}, bool) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	current := doc
+	for _, step := range steps {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			if step.index >= 0 {
+				return nil, false
+			}
+			value, ok := node[step.key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			if step.index < 0 || step.index >= len(node) {
+				return nil, false
+			}
+			current = node[step.index]
+		default:
+			return nil, false
+		}
+	}
+
+	if current == nil {
+		return nil, false
+	}
+	return current, true
+}
+
+// pathStep is a single object key or array index; index is -1 for keys
+type pathStep struct {
+	key   string
+	index int
+}
+
+func parsePath(path string) ([]pathStep, error) {
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if trimmed == "" {
+		return nil, fmt.Errorf("path %q is empty", path)
+	}
+
+	var steps []pathStep
+	for _, segment := range strings.Split(trimmed, ".") {
+		parts := mappingPathSegment.FindStringSubmatch(segment)
+		if parts == nil || (parts[1] == "" && parts[2] == "") {
+			return nil, fmt.Errorf("invalid path %q", path)
+		}
+		if parts[1] != "" {
+			steps = append(steps, pathStep{key: parts[1], index: -1})
+		}
+		for _, ref := range mappingPathIndexRef.FindAllStringSubmatch(parts[2], -1) {
+			index, err := strconv.Atoi(ref[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid index in path %q", path)
+			}
+			steps = append(steps, pathStep{index: index})
+		}
+	}
+	return steps, nil
+}
+
+// stringifyMappingValue renders a payload value for titles and matching
+func stringifyMappingValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
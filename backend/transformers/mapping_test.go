@@ -0,0 +1,198 @@
+package transformers
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testMappingConfig = `{
+	"sources": [{
+		"name": "nightly-backup",
+		"auth": {"scheme": "token", "header": "X-Backup-Token", "secret_env": "BACKUP_WEBHOOK_TOKEN"},
+		"delivery_id": {"path": "$.run_id"},
+		"timestamp": {"path": "$.finished_at"},
+		"rules": [
+			{
+				"match": {"path": "$.status", "equals": "failed"},
+				"event_type": "cron.failed",
+				"title": "Backup {{$.job.name}} failed after {{ $.steps[1].duration }}s",
+				"metadata": {"job": "$.job.name", "step": "$.steps[1].name", "runner": "backup-bot", "missing": "$.nope"}
+			},
+			{
+				"event_type": "cron.succeeded",
+				"title": "Backup {{$.job.name}} finished"
+			}
+		]
+	}]
+}`
+
+func writeMappingConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sources.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMappingConfig_RegistersRules(t *testing.T) {
+	cfg, err := LoadMappingConfig(writeMappingConfig(t, testMappingConfig))
+	if err != nil {
+		t.Fatalf("LoadMappingConfig: %v", err)
+	}
+
+	registry := NewRegistry()
+	if err := registry.RegisterMappings(cfg); err != nil {
+		t.Fatalf("RegisterMappings: %v", err)
+	}
+
+	testTime := time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)
+	payload := json.RawMessage(`{
+		"run_id": 9007199254740993,
+		"status": "failed",
+		"job": {"name": "postgres"},
+		"steps": [{"name": "dump", "duration": 12}, {"name": "upload", "duration": 340}]
+	}`)
+
+	events, err := registry.Transform("cron.failed", payload, testTime)
+	if err != nil {
+		t.Fatalf("Transform: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	event := events[0]
+
+	if event.EventType != "cron.failed" {
+		t.Errorf("expected event type cron.failed, got %s", event.EventType)
+	}
+	if event.Title != "Backup postgres failed after 340s" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+	if !event.CreatedAt.Equal(testTime) {
+		t.Errorf("expected timestamp %v, got %v", testTime, event.CreatedAt)
+	}
+	if event.Metadata["job"] != "postgres" || event.Metadata["step"] != "upload" {
+		t.Errorf("expected mapped metadata, got %v", event.Metadata)
+	}
+	if event.Metadata["runner"] != "backup-bot" {
+		t.Errorf("expected literal metadata value, got %v", event.Metadata["runner"])
+	}
+	if _, ok := event.Metadata["missing"]; ok {
+		t.Error("expected missing paths to be omitted from metadata")
+	}
+}
+
+func TestMappingConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "no sources",
+			config:  `{"sources": []}`,
+			wantErr: "no sources",
+		},
+		{
+			name:    "invalid name",
+			config:  `{"sources": [{"name": "Bad Name", "rules": [{"event_type": "a.b", "title": "x"}]}]}`,
+			wantErr: "lowercase",
+		},
+		{
+			name:    "event type without namespace",
+			config:  `{"sources": [{"name": "cron", "rules": [{"event_type": "cron", "title": "x"}]}]}`,
+			wantErr: "dotted lowercase",
+		},
+		{
+			name:    "missing title",
+			config:  `{"sources": [{"name": "cron", "rules": [{"event_type": "cron.run"}]}]}`,
+			wantErr: "title is required",
+		},
+		{
+			name:    "bad path in title",
+			config:  `{"sources": [{"name": "cron", "rules": [{"event_type": "cron.run", "title": "{{$.a[x]}}"}]}]}`,
+			wantErr: "invalid path",
+		},
+		{
+			name:    "match with header and path",
+			config:  `{"sources": [{"name": "cron", "rules": [{"event_type": "cron.run", "title": "x", "match": {"header": "X-A", "path": "$.a"}}]}]}`,
+			wantErr: "exactly one of header or path",
+		},
+		{
+			name:    "unknown auth scheme",
+			config:  `{"sources": [{"name": "cron", "auth": {"scheme": "basic", "header": "X", "secret_env": "S"}, "rules": [{"event_type": "cron.run", "title": "x"}]}]}`,
+			wantErr: "auth scheme",
+		},
+		{
+			name: "duplicate event type across sources",
+			config: `{"sources": [
+				{"name": "a", "rules": [{"event_type": "cron.run", "title": "x"}]},
+				{"name": "b", "rules": [{"event_type": "cron.run", "title": "y"}]}
+			]}`,
+			wantErr: "already defined by source a",
+		},
+		{
+			name:    "unknown field",
+			config:  `{"sources": [{"name": "cron", "rulez": []}]}`,
+			wantErr: "unknown field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMappingConfig(writeMappingConfig(t, tt.config))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRegisterMappings_RejectsBuiltInTypes(t *testing.T) {
+	cfg := &MappingConfig{Sources: []MappingSource{{
+		Name:  "shadow",
+		Rules: []MappingRule{{EventType: "github.push", Title: "x"}},
+	}}}
+
+	if err := NewRegistry().RegisterMappings(cfg); err == nil {
+		t.Error("expected an error when a mapping replaces a built-in transformer")
+	}
+}
+
+func TestLookupPath(t *testing.T) {
+	doc, err := DecodeMappingPayload([]byte(`{"a": {"b": [{"c": "deep"}, null]}, "n": 42}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path  string
+		want  string
+		found bool
+	}{
+		{"$.a.b[0].c", "deep", true},
+		{"a.b[0].c", "deep", true},
+		{"$.n", "42", true},
+		{"$.a.b[1]", "", false},
+		{"$.a.b[5].c", "", false},
+		{"$.a.x", "", false},
+		{"$.n.x", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			value, ok := LookupPath(doc, tt.path)
+			if ok != tt.found {
+				t.Fatalf("expected found=%v, got %v (%v)", tt.found, ok, value)
+			}
+			if ok && stringifyMappingValue(value) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, stringifyMappingValue(value))
+			}
+		})
+	}
+}
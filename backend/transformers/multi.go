@@ -0,0 +1,123 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// ignoredPullRequestActions are PR webhook actions that do not represent work
+var ignoredPullRequestActions = map[string]bool{
+	"labeled":                true,
+	"unlabeled":              true,
+	"assigned":               true,
+	"unassigned":             true,
+	"review_requested":       true,
+	"review_request_removed": true,
+	"milestoned":             true,
+	"demilestoned":           true,
+	"locked":                 true,
+	"unlocked":               true,
+	"auto_merge_enabled":     true,
+	"auto_merge_disabled":    true,
+	"enqueued":               true,
+	"dequeued":               true,
+}
+
+// ignoredIssueActions are issue webhook actions that do not represent work
+var ignoredIssueActions = map[string]bool{
+	"labeled":      true,
+	"unlabeled":    true,
+	"assigned":     true,
+	"unassigned":   true,
+	"milestoned":   true,
+	"demilestoned": true,
+	"locked":       true,
+	"unlocked":     true,
+	"pinned":       true,
+	"unpinned":     true,
+}
+
+// SkipActions wraps a single-event transformer so payloads whose "action" is in
+// ignored produce no events
+func SkipActions(fn TransformFunc, ignored map[string]bool) MultiTransformFunc {
+	return func(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+		var envelope struct {
+			Action string `json:"action"`
+		}
+		if err := json.Unmarshal(eventData, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event action: %w", err)
+		}
+		if ignored[envelope.Action] {
+			return nil, nil
+		}
+
+		event, err := fn(eventData, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		return []models.DashboardEvent{event}, nil
+	}
+}
+
+// TransformGitHubPushCommits emits one github.push event per commit so that stats
+// count commits rather than pushes. Pushes without commits (tags, branch deletes,
+// force pushes to an existing commit) fall back to a single TransformGitHubPush event.
+func TransformGitHubPushCommits(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+	var pushEvent struct {
+		Ref        string `json:"ref"`
+		Repository struct {
+			Name    string `json:"name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"repository"`
+		Commits []struct {
+			ID      string `json:"id"`
+			Message string `json:"message"`
+			URL     string `json:"url"`
+			Author  struct {
+				Name string `json:"name"`
+			} `json:"author"`
+		} `json:"commits"`
+		Pusher struct {
+			Name string `json:"name"`
+		} `json:"pusher"`
+	}
+
+	if err := json.Unmarshal(eventData, &pushEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push event: %w", err)
+	}
+
+	if len(pushEvent.Commits) == 0 {
+		event, err := TransformGitHubPush(eventData, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		return []models.DashboardEvent{event}, nil
+	}
+
+	branch := strings.TrimPrefix(pushEvent.Ref, "refs/heads/")
+	events := make([]models.DashboardEvent, 0, len(pushEvent.Commits))
+	for _, commit := range pushEvent.Commits {
+		summary, _, _ := strings.Cut(commit.Message, "\n")
+		events = append(events, models.DashboardEvent{
+			EventType: "github.push",
+			Title:     fmt.Sprintf("Commit to %s/%s: %s", pushEvent.Repository.Name, branch, summary),
+			Metadata: map[string]interface{}{
+				"repo":           pushEvent.Repository.Name,
+				"branch":         pushEvent.Ref,
+				"message":        commit.Message,
+				"author":         commit.Author.Name,
+				"commit_sha":     commit.ID,
+				"commit_url":     commit.URL,
+				"repository_url": pushEvent.Repository.HTMLURL,
+				"commit_count":   len(pushEvent.Commits),
+				"pusher":         pushEvent.Pusher.Name,
+			},
+			CreatedAt: timestamp,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,96 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformGitHubPushCommits(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	input := json.RawMessage(`{
+		"ref": "refs/heads/main",
+		"repository": {"name": "heimdall", "html_url": "https://github.com/roe/heimdall"},
+		"head_commit": {"id": "c3", "message": "Third", "author": {"name": "Roe"}},
+		"commits": [
+			{"id": "c1", "message": "First change\n\nLonger description", "url": "https://github.com/roe/heimdall/commit/c1", "author": {"name": "Roe"}},
+			{"id": "c2", "message": "Second change", "author": {"name": "Sam"}},
+			{"id": "c3", "message": "Third", "author": {"name": "Roe"}}
+		],
+		"pusher": {"name": "roe"}
+	}`)
+
+	events, err := TransformGitHubPushCommits(input, testTime)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected one event per commit, got %d", len(events))
+	}
+
+	first := events[0]
+	if first.EventType != "github.push" {
+		t.Errorf("expected github.push, got %s", first.EventType)
+	}
+	if first.Title != "Commit to heimdall/main: First change" {
+		t.Errorf("unexpected title: %s", first.Title)
+	}
+	if first.Metadata["commit_sha"] != "c1" || first.Metadata["commit_count"] != 3 {
+		t.Errorf("unexpected metadata: %v", first.Metadata)
+	}
+	if events[1].Metadata["author"] != "Sam" {
+		t.Errorf("expected per-commit author, got %v", events[1].Metadata["author"])
+	}
+	if !first.CreatedAt.Equal(testTime) {
+		t.Errorf("expected push timestamp, got %v", first.CreatedAt)
+	}
+}
+
+func TestTransformGitHubPushCommits_NoCommits(t *testing.T) {
+	input := json.RawMessage(`{
+		"ref": "refs/tags/v1.0.0",
+		"repository": {"name": "heimdall"},
+		"head_commit": {"id": "abc", "message": "Release", "author": {"name": "Roe"}},
+		"commits": []
+	}`)
+
+	events, err := TransformGitHubPushCommits(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected a single summary event, got %d", len(events))
+	}
+	if events[0].Metadata["commit_sha"] != "abc" {
+		t.Errorf("expected head commit in summary event, got %v", events[0].Metadata["commit_sha"])
+	}
+}
+
+func TestSkipActions(t *testing.T) {
+	registry := NewRegistry()
+
+	tests := []struct {
+		name      string
+		eventType string
+		input     string
+		want      int
+	}{
+		{"PR labeled is ignored", "github.pr", `{"action": "labeled", "number": 1, "pull_request": {}, "repository": {}}`, 0},
+		{"PR opened is kept", "github.pr", `{"action": "opened", "number": 1, "pull_request": {}, "repository": {}}`, 1},
+		{"issue assigned is ignored", "github.issue", `{"action": "assigned", "issue": {}, "repository": {}}`, 0},
+		{"issue closed is kept", "github.issue", `{"action": "closed", "issue": {}, "repository": {}}`, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := registry.Transform(tt.eventType, json.RawMessage(tt.input), time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(events) != tt.want {
+				t.Errorf("expected %d events, got %d", tt.want, len(events))
+			}
+		})
+	}
+}
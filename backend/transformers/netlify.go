@@ -0,0 +1,120 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// TransformNetlifyDeploy transforms a Netlify deploy notification. Netlify posts
+// the deploy object without naming the notification, so it is derived from the
+// deploy state: in-progress states are "building", "ready" is a successful
+// deploy (or a locked one when auto publishing was stopped on it) and "error"
+// is a failed deploy.
+func TransformNetlifyDeploy(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var deploy struct {
+		ID           string `json:"id"`
+		SiteID       string `json:"site_id"`
+		BuildID      string `json:"build_id"`
+		State        string `json:"state"`
+		Name         string `json:"name"`
+		URL          string `json:"url"`
+		SSLURL       string `json:"ssl_url"`
+		AdminURL     string `json:"admin_url"`
+		DeploySSLURL string `json:"deploy_ssl_url"`
+		ErrorMessage string `json:"error_message"`
+		Branch       string `json:"branch"`
+		CommitRef    string `json:"commit_ref"`
+		CommitURL    string `json:"commit_url"`
+		Title        string `json:"title"`
+		Committer    string `json:"committer"`
+		Context      string `json:"context"`
+		ReviewID     int    `json:"review_id"`
+		Locked       bool   `json:"locked"`
+		DeployTime   int64  `json:"deploy_time"` // Seconds
+	}
+
+	if err := json.Unmarshal(eventData, &deploy); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Netlify event: %w", err)
+	}
+
+	// Map deploy state to standard format, named after Netlify's notification events
+	var status, notification string
+	switch deploy.State {
+	case "ready":
+		status = "SUCCESS"
+		notification = "deploy_created"
+		if deploy.Locked {
+			notification = "deploy_locked"
+		}
+	case "error":
+		status = "FAILED"
+		notification = "deploy_failed"
+	case "new", "enqueued", "building", "uploading", "uploaded", "preparing", "prepared", "processing", "processed":
+		status = "BUILDING"
+		notification = "deploy_building"
+	default:
+		status = "DEPLOY"
+		notification = deploy.State
+	}
+
+	projectName := deploy.Name
+	if projectName == "" {
+		projectName = "Unknown Project"
+	}
+
+	environment := deploy.Context
+	if environment == "" {
+		environment = "production"
+	}
+
+	title := fmt.Sprintf("%s: %s to %s", projectName, status, environment)
+	if notification == "deploy_locked" {
+		title = fmt.Sprintf("%s: deploy locked in %s", projectName, environment)
+	}
+
+	metadata := map[string]interface{}{
+		"project":        projectName,
+		"status":         status,
+		"state":          deploy.State,
+		"locked":         deploy.Locked,
+		"url":            deploy.SSLURL,
+		"deployment_url": deploy.DeploySSLURL,
+		"deployment_id":  deploy.ID,
+		"build_id":       deploy.BuildID,
+		"site_id":        deploy.SiteID,
+		"admin_url":      deploy.AdminURL,
+		"environment":    environment,
+		"branch":         deploy.Branch,
+		"commit_sha":     deploy.CommitRef,
+		"commit_url":     deploy.CommitURL,
+		"commit_message": deploy.Title,
+		"author":         deploy.Committer,
+		"error_message":  deploy.ErrorMessage,
+		"event_type":     notification,
+	}
+	if deploy.ReviewID > 0 {
+		metadata["pr_number"] = deploy.ReviewID
+	}
+	if deploy.DeployTime > 0 {
+		metadata["duration_seconds"] = deploy.DeployTime
+	}
+
+	// Each notification fires once per deploy, so deploy ID plus notification
+	// identifies a delivery even when it is redelivered
+	externalID := ""
+	if deploy.ID != "" {
+		externalID = deploy.ID + ":" + notification
+	}
+
+	return models.DashboardEvent{
+		EventType:  "netlify.deploy",
+		Title:      title,
+		Source:     "netlify",
+		ExternalID: externalID,
+		Metadata:   metadata,
+		CreatedAt:  timestamp,
+	}, nil
+}
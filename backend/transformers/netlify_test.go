@@ -0,0 +1,84 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformNetlifyDeploy(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name               string
+		state              string
+		locked             bool
+		expectedStatus     string
+		expectedTitle      string
+		expectedExternalID string
+	}{
+		{name: "building", state: "building", expectedStatus: "BUILDING", expectedTitle: "heimdall: BUILDING to production", expectedExternalID: "d1:deploy_building"},
+		{name: "enqueued counts as building", state: "enqueued", expectedStatus: "BUILDING", expectedTitle: "heimdall: BUILDING to production", expectedExternalID: "d1:deploy_building"},
+		{name: "ready", state: "ready", expectedStatus: "SUCCESS", expectedTitle: "heimdall: SUCCESS to production", expectedExternalID: "d1:deploy_created"},
+		{name: "error", state: "error", expectedStatus: "FAILED", expectedTitle: "heimdall: FAILED to production", expectedExternalID: "d1:deploy_failed"},
+		{name: "locked", state: "ready", locked: true, expectedStatus: "SUCCESS", expectedTitle: "heimdall: deploy locked in production", expectedExternalID: "d1:deploy_locked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := map[string]interface{}{
+				"id":             "d1",
+				"site_id":        "site_1",
+				"state":          tt.state,
+				"name":           "heimdall",
+				"ssl_url":        "https://heimdall.netlify.app",
+				"deploy_ssl_url": "https://d1--heimdall.netlify.app",
+				"branch":         "main",
+				"commit_ref":     "abc123",
+				"title":          "Fix header",
+				"committer":      "roe",
+				"context":        "production",
+				"locked":         tt.locked,
+			}
+			input, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := TransformNetlifyDeploy(input, testTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != "netlify.deploy" || event.Source != "netlify" {
+				t.Errorf("unexpected event type/source: %s/%s", event.EventType, event.Source)
+			}
+			if event.Title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, event.Title)
+			}
+			if event.Metadata["status"] != tt.expectedStatus {
+				t.Errorf("expected status %s, got %v", tt.expectedStatus, event.Metadata["status"])
+			}
+			if event.ExternalID != tt.expectedExternalID {
+				t.Errorf("expected external ID %s, got %s", tt.expectedExternalID, event.ExternalID)
+			}
+			if event.Metadata["commit_sha"] != "abc123" || event.Metadata["deployment_url"] != "https://d1--heimdall.netlify.app" {
+				t.Errorf("unexpected metadata: %v", event.Metadata)
+			}
+		})
+	}
+}
+
+func TestTransformNetlifyDeploy_DeployPreview(t *testing.T) {
+	input := json.RawMessage(`{"id": "d2", "state": "ready", "name": "heimdall", "context": "deploy-preview", "review_id": 42}`)
+
+	event, err := TransformNetlifyDeploy(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Title != "heimdall: SUCCESS to deploy-preview" {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+	if event.Metadata["pr_number"] != 42 {
+		t.Errorf("expected pr_number 42, got %v", event.Metadata["pr_number"])
+	}
+}
@@ -0,0 +1,118 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// pagerDutyReference is a PagerDuty reference object (service, user, policy, ...)
+type pagerDutyReference struct {
+	ID      string `json:"id"`
+	Summary string `json:"summary"`
+	HTMLURL string `json:"html_url"`
+}
+
+// TransformPagerDutyIncident transforms a PagerDuty V3 incident webhook into an
+// incident.triggered, incident.acknowledged, incident.escalated, incident.resolved or
+// incident.reopened event
+func TransformPagerDutyIncident(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var pagerDutyEvent struct {
+		Event struct {
+			ID         string              `json:"id"`
+			EventType  string              `json:"event_type"`
+			OccurredAt string              `json:"occurred_at"`
+			Agent      *pagerDutyReference `json:"agent"`
+			Data       struct {
+				ID               string               `json:"id"`
+				Number           int                  `json:"number"`
+				Title            string               `json:"title"`
+				Status           string               `json:"status"`
+				Urgency          string               `json:"urgency"`
+				HTMLURL          string               `json:"html_url"`
+				CreatedAt        string               `json:"created_at"`
+				ResolveReason    *string              `json:"resolve_reason"`
+				Service          pagerDutyReference   `json:"service"`
+				EscalationPolicy pagerDutyReference   `json:"escalation_policy"`
+				Assignees        []pagerDutyReference `json:"assignees"`
+				Teams            []pagerDutyReference `json:"teams"`
+				Priority         *pagerDutyReference  `json:"priority"`
+			} `json:"data"`
+		} `json:"event"`
+	}
+
+	if err := json.Unmarshal(eventData, &pagerDutyEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal PagerDuty event: %w", err)
+	}
+
+	event := pagerDutyEvent.Event
+	action, ok := strings.CutPrefix(event.EventType, "incident.")
+	if !ok || action == "" {
+		return models.DashboardEvent{}, fmt.Errorf("unsupported PagerDuty event type: %q", event.EventType)
+	}
+
+	incident := event.Data
+	assignees := make([]string, 0, len(incident.Assignees))
+	for _, assignee := range incident.Assignees {
+		assignees = append(assignees, assignee.Summary)
+	}
+	teams := make([]string, 0, len(incident.Teams))
+	for _, team := range incident.Teams {
+		teams = append(teams, team.Summary)
+	}
+
+	agent := ""
+	if event.Agent != nil {
+		agent = event.Agent.Summary
+	}
+	assignee := ""
+	if len(assignees) > 0 {
+		assignee = assignees[0]
+	}
+
+	// Triggered and escalated incidents name the service they page for; the
+	// other actions name whoever acted, falling back to the service
+	title := fmt.Sprintf("Incident #%d %s on %s: %s", incident.Number, action, incident.Service.Summary, incident.Title)
+	if agent != "" && action != "triggered" && action != "escalated" {
+		title = fmt.Sprintf("Incident #%d %s by %s: %s", incident.Number, action, agent, incident.Title)
+	}
+
+	metadata := map[string]interface{}{
+		"provider":          "pagerduty",
+		"action":            action,
+		"incident_id":       incident.ID,
+		"number":            incident.Number,
+		"incident_title":    incident.Title,
+		"status":            incident.Status,
+		"urgency":           incident.Urgency,
+		"service":           incident.Service.Summary,
+		"service_id":        incident.Service.ID,
+		"escalation_policy": incident.EscalationPolicy.Summary,
+		"assignee":          assignee,
+		"assignees":         assignees,
+		"teams":             teams,
+		"agent":             agent,
+		"url":               incident.HTMLURL,
+		"incident_created":  incident.CreatedAt,
+		"occurred_at":       event.OccurredAt,
+	}
+	if incident.Priority != nil {
+		metadata["priority"] = incident.Priority.Summary
+	}
+	if incident.ResolveReason != nil {
+		metadata["resolve_reason"] = *incident.ResolveReason
+	}
+
+	// Every V3 event has its own ID, stable across redeliveries
+	return models.DashboardEvent{
+		EventType:  "incident." + action,
+		Title:      title,
+		Metadata:   metadata,
+		CreatedAt:  timestamp,
+		Source:     "pagerduty",
+		ExternalID: event.ID,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformPagerDutyIncident(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		eventType     string
+		agent         string
+		expectedType  string
+		expectedTitle string
+	}{
+		{name: "triggered", eventType: "incident.triggered", expectedType: "incident.triggered", expectedTitle: "Incident #42 triggered on checkout-api: High error rate"},
+		{name: "acknowledged", eventType: "incident.acknowledged", agent: `{"id":"U1","summary":"Ada"}`, expectedType: "incident.acknowledged", expectedTitle: "Incident #42 acknowledged by Ada: High error rate"},
+		{name: "escalated", eventType: "incident.escalated", agent: `{"id":"U1","summary":"Ada"}`, expectedType: "incident.escalated", expectedTitle: "Incident #42 escalated on checkout-api: High error rate"},
+		{name: "reopened", eventType: "incident.reopened", agent: `{"id":"U1","summary":"Ada"}`, expectedType: "incident.reopened", expectedTitle: "Incident #42 reopened by Ada: High error rate"},
+		{name: "auto-resolved", eventType: "incident.resolved", expectedType: "incident.resolved", expectedTitle: "Incident #42 resolved on checkout-api: High error rate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := tt.agent
+			if agent == "" {
+				agent = "null"
+			}
+			input := json.RawMessage(`{
+				"event": {
+					"id": "01EVT",
+					"event_type": "` + tt.eventType + `",
+					"resource_type": "incident",
+					"occurred_at": "2024-01-15T10:30:00.000Z",
+					"agent": ` + agent + `,
+					"data": {
+						"id": "PINC1",
+						"type": "incident",
+						"number": 42,
+						"title": "High error rate",
+						"status": "triggered",
+						"urgency": "high",
+						"html_url": "https://acme.pagerduty.com/incidents/PINC1",
+						"created_at": "2024-01-15T10:29:00Z",
+						"service": {"id": "PSVC1", "summary": "checkout-api"},
+						"escalation_policy": {"id": "PEP1", "summary": "Payments on-call"},
+						"assignees": [{"id": "U1", "summary": "Ada"}],
+						"teams": [{"id": "T1", "summary": "Payments"}],
+						"priority": {"id": "P1", "summary": "P1"}
+					}
+				}
+			}`)
+
+			event, err := TransformPagerDutyIncident(input, testTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != tt.expectedType {
+				t.Errorf("expected event type %s, got %s", tt.expectedType, event.EventType)
+			}
+			if event.Title != tt.expectedTitle {
+				t.Errorf("expected title %q, got %q", tt.expectedTitle, event.Title)
+			}
+			if event.Source != "pagerduty" || event.ExternalID != "01EVT" {
+				t.Errorf("unexpected identity: %s/%s", event.Source, event.ExternalID)
+			}
+			expected := map[string]interface{}{
+				"incident_id": "PINC1",
+				"number":      42,
+				"service":     "checkout-api",
+				"urgency":     "high",
+				"assignee":    "Ada",
+				"priority":    "P1",
+				"url":         "https://acme.pagerduty.com/incidents/PINC1",
+			}
+			for key, value := range expected {
+				if event.Metadata[key] != value {
+					t.Errorf("expected %s %v, got %v", key, value, event.Metadata[key])
+				}
+			}
+		})
+	}
+}
+
+func TestTransformPagerDutyIncident_RejectsOtherResources(t *testing.T) {
+	input := json.RawMessage(`{"event": {"id": "01EVT", "event_type": "service.updated"}}`)
+	if _, err := TransformPagerDutyIncident(input, time.Now()); err == nil {
+		t.Error("expected error for a non-incident event")
+	}
+}
@@ -3,6 +3,7 @@ package transformers
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"heimdall-backend/models"
@@ -12,6 +13,7 @@ import (
 func TransformRailwayDeploy(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
 	var railwayEvent struct {
 		Type      string `json:"type"`
+		Status    string `json:"status"` // Deployment status, sent with DEPLOY
 		Timestamp string `json:"timestamp"`
 		Project   struct {
 			ID          string `json:"id"`
@@ -38,18 +40,7 @@ func TransformRailwayDeploy(eventData json.RawMessage, timestamp time.Time) (mod
 		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Railway event: %w", err)
 	}
 
-	// Map Railway type to standard format
-	var status string
-	switch railwayEvent.Type {
-	case "DEPLOY":
-		status = "SUCCESS"
-	case "DEPLOY_STARTED":
-		status = "BUILDING"
-	case "DEPLOY_FAILED":
-		status = "FAILED"
-	default:
-		status = "DEPLOY"
-	}
+	status := railwayStatus(railwayEvent.Type, railwayEvent.Status)
 
 	// Build richer title with project, status, and environment
 	projectName := railwayEvent.Project.Name
@@ -74,20 +65,91 @@ func TransformRailwayDeploy(eventData json.RawMessage, timestamp time.Time) (mod
 		"creator_name":   railwayEvent.Deployment.Creator.Name,
 		"creator_id":     railwayEvent.Deployment.Creator.ID,
 		"event_type":     railwayEvent.Type,
+		"railway_status": railwayEvent.Status,
 		"timestamp":      railwayEvent.Timestamp,
+		"commit_sha":     metaString(railwayEvent.Deployment.Meta, "commitHash"),
+		"commit_message": metaString(railwayEvent.Deployment.Meta, "commitMessage"),
+		"branch":         metaString(railwayEvent.Deployment.Meta, "branch"),
+		"author":         metaString(railwayEvent.Deployment.Meta, "commitAuthor"),
+		"repo":           metaString(railwayEvent.Deployment.Meta, "repo"),
 	}
 
-	// Add deployment meta if available
-	if railwayEvent.Deployment.Meta != nil {
-		for key, value := range railwayEvent.Deployment.Meta {
+	// Keep the remaining deployment meta (image, service manifest, ...) as is
+	for key, value := range railwayEvent.Deployment.Meta {
+		if !railwayGitMetaKeys[key] {
 			metadata[fmt.Sprintf("meta_%s", key)] = value
 		}
 	}
 
+	// Deployment ID plus state identifies a delivery even when it is redelivered.
+	// DEPLOY is sent for every status change, so its status is part of the state.
+	externalID := ""
+	if railwayEvent.Deployment.ID != "" {
+		externalID = railwayEvent.Deployment.ID + ":" + railwayEvent.Type
+		if railwayEvent.Status != "" {
+			externalID += ":" + railwayEvent.Status
+		}
+	}
+
 	return models.DashboardEvent{
-		EventType: "railway.deploy",
-		Title:     title,
-		Metadata:  metadata,
-		CreatedAt: timestamp,
+		EventType:  "railway.deploy",
+		Title:      title,
+		Metadata:   metadata,
+		CreatedAt:  timestamp,
+		Source:     "railway",
+		ExternalID: externalID,
 	}, nil
 }
+
+// railwayGitMetaKeys are the deployment meta keys mapped to commit metadata
+var railwayGitMetaKeys = map[string]bool{
+	"commitHash":    true,
+	"commitMessage": true,
+	"branch":        true,
+	"commitAuthor":  true,
+	"repo":          true,
+}
+
+// railwayStatus maps a Railway webhook type, and the status sent with DEPLOY,
+// to the standard deploy status. A DEPLOY without a status is a finished deploy.
+func railwayStatus(eventType, deployStatus string) string {
+	switch eventType {
+	case "DEPLOY":
+		if deployStatus == "" {
+			return "SUCCESS"
+		}
+		return railwayDeploymentStatus(deployStatus)
+	case "DEPLOY_STARTED":
+		return "BUILDING"
+	case "DEPLOY_FAILED":
+		return "FAILED"
+	case "DEPLOY_CRASHED", "DEPLOY_REMOVED", "DEPLOY_RESTARTED", "DEPLOY_SLEEPING", "DEPLOY_CANCELED":
+		return railwayDeploymentStatus(strings.TrimPrefix(eventType, "DEPLOY_"))
+	default:
+		return "DEPLOY"
+	}
+}
+
+// railwayDeploymentStatus maps a Railway deployment status to the standard one
+func railwayDeploymentStatus(deployStatus string) string {
+	switch strings.ToUpper(deployStatus) {
+	case "SUCCESS":
+		return "SUCCESS"
+	case "QUEUED", "WAITING", "INITIALIZING", "BUILDING", "DEPLOYING":
+		return "BUILDING"
+	case "FAILED":
+		return "FAILED"
+	case "CRASHED":
+		return "CRASHED"
+	case "REMOVED", "REMOVING":
+		return "REMOVED"
+	case "RESTARTED", "RESTARTING":
+		return "RESTARTED"
+	case "SLEEPING":
+		return "SLEEPING"
+	case "CANCELED", "CANCELLED":
+		return "CANCELED"
+	default:
+		return "DEPLOY"
+	}
+}
@@ -150,3 +150,92 @@ func TestTransformRailwayDeploy(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformRailwayDeploy_ExternalID(t *testing.T) {
+	input := `{"type": "DEPLOY_STARTED", "deployment": {"id": "dep_789"}}`
+
+	result, err := TransformRailwayDeploy(json.RawMessage(input), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Source != "railway" || result.ExternalID != "dep_789:DEPLOY_STARTED" {
+		t.Errorf("expected railway/dep_789:DEPLOY_STARTED, got %s/%s", result.Source, result.ExternalID)
+	}
+}
+
+func TestTransformRailwayDeploy_Lifecycle(t *testing.T) {
+	tests := []struct {
+		eventType          string
+		status             string
+		expectedStatus     string
+		expectedExternalID string
+	}{
+		{"DEPLOY", "", "SUCCESS", "dep_1:DEPLOY"},
+		{"DEPLOY", "DEPLOYING", "BUILDING", "dep_1:DEPLOY:DEPLOYING"},
+		{"DEPLOY", "SUCCESS", "SUCCESS", "dep_1:DEPLOY:SUCCESS"},
+		{"DEPLOY", "CRASHED", "CRASHED", "dep_1:DEPLOY:CRASHED"},
+		{"DEPLOY_CRASHED", "", "CRASHED", "dep_1:DEPLOY_CRASHED"},
+		{"DEPLOY_REMOVED", "", "REMOVED", "dep_1:DEPLOY_REMOVED"},
+		{"DEPLOY_RESTARTED", "", "RESTARTED", "dep_1:DEPLOY_RESTARTED"},
+		{"DEPLOY_SLEEPING", "", "SLEEPING", "dep_1:DEPLOY_SLEEPING"},
+		{"DEPLOY_CANCELED", "", "CANCELED", "dep_1:DEPLOY_CANCELED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType+"/"+tt.status, func(t *testing.T) {
+			input := `{"type": "` + tt.eventType + `", "status": "` + tt.status + `", "project": {"name": "api"}, "deployment": {"id": "dep_1"}}`
+
+			result, err := TransformRailwayDeploy(json.RawMessage(input), time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Metadata["status"] != tt.expectedStatus {
+				t.Errorf("expected status %s, got %v", tt.expectedStatus, result.Metadata["status"])
+			}
+			if result.ExternalID != tt.expectedExternalID {
+				t.Errorf("expected external ID %s, got %s", tt.expectedExternalID, result.ExternalID)
+			}
+		})
+	}
+}
+
+func TestTransformRailwayDeploy_GitMeta(t *testing.T) {
+	input := `{
+		"type": "DEPLOY",
+		"project": {"name": "api"},
+		"deployment": {
+			"id": "dep_1",
+			"meta": {
+				"commitHash": "abc123",
+				"commitMessage": "Fix header",
+				"commitAuthor": "roe",
+				"branch": "main",
+				"repo": "acme/api",
+				"image": "ghcr.io/acme/api:latest"
+			}
+		}
+	}`
+
+	result, err := TransformRailwayDeploy(json.RawMessage(input), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"commit_sha":     "abc123",
+		"commit_message": "Fix header",
+		"author":         "roe",
+		"branch":         "main",
+		"repo":           "acme/api",
+		"meta_image":     "ghcr.io/acme/api:latest",
+	}
+	for key, want := range expected {
+		if got := result.Metadata[key]; got != want {
+			t.Errorf("metadata[%q] = %v, want %v", key, got, want)
+		}
+	}
+	if _, ok := result.Metadata["meta_commitHash"]; ok {
+		t.Error("expected commit meta to be structured, not copied as meta_commitHash")
+	}
+}
@@ -12,38 +12,88 @@ import (
 // The timestamp parameter is the event timestamp from the webhook payload
 type TransformFunc func(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error)
 
+// MultiTransformFunc transforms raw event data into zero or more DashboardEvents.
+// Returning no events (and no error) means the payload is valid but not worth showing.
+type MultiTransformFunc func(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error)
+
 // Registry holds all registered event transformers
 type Registry struct {
-	transformers map[string]TransformFunc
+	transformers map[string]MultiTransformFunc
 }
 
 // NewRegistry creates a new transformer registry with default transformers
 func NewRegistry() *Registry {
 	r := &Registry{
-		transformers: make(map[string]TransformFunc),
+		transformers: make(map[string]MultiTransformFunc),
 	}
 
 	// Register default transformers
-	r.Register("github.push", TransformGitHubPush)
-	r.Register("github.pr", TransformGitHubPR)
-	r.Register("github.issue", TransformGitHubIssue)
+	r.RegisterMulti("github.push", TransformGitHubPushCommits)
+	r.RegisterMulti("github.pr", SkipActions(TransformGitHubPR, ignoredPullRequestActions))
+	r.RegisterMulti("github.issue", SkipActions(TransformGitHubIssue, ignoredIssueActions))
 	r.Register("github.release", TransformGitHubRelease)
+	r.RegisterMulti("github.workflow_run", TransformGitHubWorkflowRun)
+	r.RegisterMulti("github.workflow_job", TransformGitHubWorkflowJob)
+	r.RegisterMulti("github.check_suite", TransformGitHubCheckSuite)
+	r.Register("github.dependabot_alert", TransformGitHubDependabotAlert)
+	r.RegisterMulti("github.code_scanning_alert", SkipActions(TransformGitHubCodeScanningAlert, ignoredCodeScanningActions))
+	r.Register("github.secret_scanning_alert", TransformGitHubSecretScanningAlert)
+	r.Register("github.repository_vulnerability_alert", TransformGitHubVulnerabilityAlert)
+	r.Register("github.create", TransformGitHubCreate)
+	r.Register("github.delete", TransformGitHubDelete)
+	r.Register("github.fork", TransformGitHubFork)
+	r.Register("github.star", TransformGitHubStar)
+	r.Register("github.watch", TransformGitHubWatch)
+	r.Register("github.deployment", TransformGitHubDeployment)
+	r.RegisterMulti("github.deployment_status", TransformGitHubDeploymentStatus)
+	r.RegisterMulti("github.package", TransformGitHubPackage)
+	r.RegisterMulti("github.registry_package", TransformGitHubPackage)
+	r.RegisterMulti("github.pull_request_review", SkipActions(TransformGitHubReview, ignoredReviewActions))
+	r.RegisterMulti("github.pull_request_review_comment", SkipActions(TransformGitHubReviewComment, ignoredCommentActions))
+	r.RegisterMulti("github.issue_comment", SkipActions(TransformGitHubIssueComment, ignoredCommentActions))
+	r.RegisterMulti("github.discussion", SkipActions(TransformGitHubDiscussion, ignoredDiscussionActions))
+	r.RegisterMulti("github.discussion_comment", SkipActions(TransformGitHubDiscussionComment, ignoredCommentActions))
+	r.RegisterMulti("gitlab.push", TransformGitLabPush)
+	r.Register("gitlab.tag", TransformGitLabTagPush)
+	r.RegisterMulti("gitlab.mr", TransformGitLabMergeRequest)
+	r.RegisterMulti("gitlab.pipeline", TransformGitLabPipeline)
+	r.Register("gitlab.release", TransformGitLabRelease)
 	r.Register("vercel.deploy", TransformVercelDeploy)
 	r.Register("railway.deploy", TransformRailwayDeploy)
+	r.Register("netlify.deploy", TransformNetlifyDeploy)
+	r.Register("render.deploy", TransformRenderDeploy)
+	r.Register("dockerhub.push", TransformDockerHubPush)
+	r.RegisterMulti("sentry.issue", SkipActions(TransformSentryIssue, ignoredSentryIssueActions))
+	r.Register("sentry.event_alert", TransformSentryEventAlert)
+	r.RegisterMulti("linear.issue", TransformLinearIssue)
+	r.RegisterMulti("linear.comment", SkipActions(TransformLinearComment, ignoredLinearCommentActions))
+	r.RegisterMulti("jira.issue", TransformJiraIssue)
+	r.Register("pagerduty.incident", TransformPagerDutyIncident)
 
 	return r
 }
 
-// Register adds a new transformer to the registry
+// Register adds a new single-event transformer to the registry
 func (r *Registry) Register(eventType string, fn TransformFunc) {
+	r.transformers[eventType] = func(eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
+		event, err := fn(eventData, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		return []models.DashboardEvent{event}, nil
+	}
+}
+
+// RegisterMulti adds a transformer that may produce any number of events
+func (r *Registry) RegisterMulti(eventType string, fn MultiTransformFunc) {
 	r.transformers[eventType] = fn
 }
 
 // Transform transforms event data using the appropriate transformer
-func (r *Registry) Transform(eventType string, eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+func (r *Registry) Transform(eventType string, eventData json.RawMessage, timestamp time.Time) ([]models.DashboardEvent, error) {
 	fn, ok := r.transformers[eventType]
 	if !ok {
-		return models.DashboardEvent{}, fmt.Errorf("unknown event type: %s", eventType)
+		return nil, fmt.Errorf("unknown event type: %s", eventType)
 	}
 
 	return fn(eventData, timestamp)
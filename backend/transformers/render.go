@@ -0,0 +1,74 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// TransformRenderDeploy transforms a Render deploy_started or deploy_ended webhook
+func TransformRenderDeploy(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var renderEvent struct {
+		Type      string `json:"type"`
+		Timestamp string `json:"timestamp"`
+		Data      struct {
+			ID          string `json:"id"`
+			ServiceID   string `json:"serviceId"`
+			ServiceName string `json:"serviceName"`
+			Status      string `json:"status"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(eventData, &renderEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Render event: %w", err)
+	}
+
+	// Map Render type and outcome to standard format. A deploy_ended without an
+	// outcome, or one that was cancelled, stays a generic DEPLOY.
+	var status string
+	switch renderEvent.Type {
+	case "deploy_started":
+		status = "BUILDING"
+	case "deploy_ended":
+		switch renderEvent.Data.Status {
+		case "succeeded", "live":
+			status = "SUCCESS"
+		case "failed", "build_failed", "update_failed", "pre_deploy_failed":
+			status = "FAILED"
+		default:
+			status = "DEPLOY"
+		}
+	default:
+		status = "DEPLOY"
+	}
+
+	projectName := renderEvent.Data.ServiceName
+	if projectName == "" {
+		projectName = "Unknown Project"
+	}
+
+	title := fmt.Sprintf("%s: %s on Render", projectName, status)
+
+	metadata := map[string]interface{}{
+		"project":       projectName,
+		"service_id":    renderEvent.Data.ServiceID,
+		"status":        status,
+		"render_status": renderEvent.Data.Status,
+		"event_id":      renderEvent.Data.ID,
+		"event_type":    renderEvent.Type,
+		"timestamp":     renderEvent.Timestamp,
+	}
+
+	// Render gives each service event its own ID, so it identifies a delivery
+	// even when it is redelivered
+	return models.DashboardEvent{
+		EventType:  "render.deploy",
+		Title:      title,
+		Metadata:   metadata,
+		CreatedAt:  timestamp,
+		Source:     "render",
+		ExternalID: renderEvent.Data.ID,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformRenderDeploy(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		eventType      string
+		status         string
+		expectedStatus string
+	}{
+		{name: "deploy started", eventType: "deploy_started", expectedStatus: "BUILDING"},
+		{name: "deploy succeeded", eventType: "deploy_ended", status: "succeeded", expectedStatus: "SUCCESS"},
+		{name: "deploy failed", eventType: "deploy_ended", status: "failed", expectedStatus: "FAILED"},
+		{name: "build failed", eventType: "deploy_ended", status: "build_failed", expectedStatus: "FAILED"},
+		{name: "deploy ended without outcome", eventType: "deploy_ended", expectedStatus: "DEPLOY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"type": "` + tt.eventType + `",
+				"timestamp": "2024-01-15T10:30:00Z",
+				"data": {
+					"id": "evj-1",
+					"serviceId": "srv-1",
+					"serviceName": "heimdall-api",
+					"status": "` + tt.status + `"
+				}
+			}`)
+
+			event, err := TransformRenderDeploy(input, testTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != "render.deploy" || event.Source != "render" {
+				t.Errorf("unexpected event type/source: %s/%s", event.EventType, event.Source)
+			}
+			if event.Metadata["status"] != tt.expectedStatus {
+				t.Errorf("expected status %s, got %v", tt.expectedStatus, event.Metadata["status"])
+			}
+			expectedTitle := "heimdall-api: " + tt.expectedStatus + " on Render"
+			if event.Title != expectedTitle {
+				t.Errorf("expected title %q, got %q", expectedTitle, event.Title)
+			}
+			if event.ExternalID != "evj-1" || event.Metadata["service_id"] != "srv-1" {
+				t.Errorf("unexpected identity: %s %v", event.ExternalID, event.Metadata["service_id"])
+			}
+		})
+	}
+}
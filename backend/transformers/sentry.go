@@ -0,0 +1,176 @@
+package transformers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"heimdall-backend/models"
+)
+
+// ignoredSentryIssueActions are issue webhook actions that do not change the issue's state
+var ignoredSentryIssueActions = map[string]bool{
+	"assigned": true,
+}
+
+// sentryIssueTransitions phrases Sentry issue actions for titles
+var sentryIssueTransitions = map[string]string{
+	"created":    "New issue",
+	"resolved":   "Resolved",
+	"unresolved": "Reopened",
+	"regressed":  "Regression",
+	"archived":   "Archived",
+	"ignored":    "Ignored",
+}
+
+// TransformSentryIssue transforms a Sentry issue webhook (created, resolved,
+// unresolved, archived). An issue reopened because the error came back is
+// reported as "regressed".
+func TransformSentryIssue(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var issueEvent struct {
+		Action string `json:"action"`
+		Data   struct {
+			Issue struct {
+				ID        string          `json:"id"`
+				ShortID   string          `json:"shortId"`
+				Title     string          `json:"title"`
+				Culprit   string          `json:"culprit"`
+				Level     string          `json:"level"`
+				Status    string          `json:"status"`
+				Substatus string          `json:"substatus"`
+				Count     json.RawMessage `json:"count"` // Sentry sends the count as a string
+				UserCount int             `json:"userCount"`
+				FirstSeen string          `json:"firstSeen"`
+				LastSeen  string          `json:"lastSeen"`
+				Permalink string          `json:"permalink"`
+				WebURL    string          `json:"web_url"`
+				Platform  string          `json:"platform"`
+				Project   struct {
+					Name string `json:"name"`
+					Slug string `json:"slug"`
+				} `json:"project"`
+			} `json:"issue"`
+		} `json:"data"`
+		Actor struct {
+			Name string `json:"name"`
+		} `json:"actor"`
+	}
+
+	if err := json.Unmarshal(eventData, &issueEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Sentry issue event: %w", err)
+	}
+
+	issue := issueEvent.Data.Issue
+	action := issueEvent.Action
+	if action == "unresolved" && issue.Substatus == "regressed" {
+		action = "regressed"
+	}
+
+	transition := sentryIssueTransitions[action]
+	if transition == "" {
+		transition = action
+	}
+	project := sentryProjectName(issue.Project.Slug, issue.Project.Name)
+	title := fmt.Sprintf("%s in %s: %s", transition, project, issue.Title)
+
+	url := issue.WebURL
+	if url == "" {
+		url = issue.Permalink
+	}
+
+	return models.DashboardEvent{
+		EventType: "error.issue",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"project":     project,
+			"action":      action,
+			"issue_id":    issue.ID,
+			"short_id":    issue.ShortID,
+			"error":       issue.Title,
+			"level":       issue.Level,
+			"status":      issue.Status,
+			"culprit":     issue.Culprit,
+			"event_count": sentryValue(issue.Count),
+			"user_count":  issue.UserCount,
+			"first_seen":  issue.FirstSeen,
+			"last_seen":   issue.LastSeen,
+			"url":         url,
+			"platform":    issue.Platform,
+			"actor":       issueEvent.Actor.Name,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// TransformSentryEventAlert transforms a Sentry alert-rule webhook, sent when an
+// issue alert rule fires for an error event
+func TransformSentryEventAlert(eventData json.RawMessage, timestamp time.Time) (models.DashboardEvent, error) {
+	var alertEvent struct {
+		Data struct {
+			Event struct {
+				EventID  string          `json:"event_id"`
+				IssueID  string          `json:"issue_id"`
+				Title    string          `json:"title"`
+				Culprit  string          `json:"culprit"`
+				Level    string          `json:"level"`
+				Datetime string          `json:"datetime"`
+				WebURL   string          `json:"web_url"`
+				Platform string          `json:"platform"`
+				Project  json.RawMessage `json:"project"`
+				Release  string          `json:"release"`
+			} `json:"event"`
+			TriggeredRule string `json:"triggered_rule"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(eventData, &alertEvent); err != nil {
+		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Sentry alert event: %w", err)
+	}
+
+	event := alertEvent.Data.Event
+	title := fmt.Sprintf("Alert %q triggered: %s", alertEvent.Data.TriggeredRule, event.Title)
+
+	return models.DashboardEvent{
+		EventType: "error.alert",
+		Title:     title,
+		Metadata: map[string]interface{}{
+			"project_id": sentryValue(event.Project),
+			"rule":       alertEvent.Data.TriggeredRule,
+			"issue_id":   event.IssueID,
+			"event_id":   event.EventID,
+			"error":      event.Title,
+			"level":      event.Level,
+			"culprit":    event.Culprit,
+			"release":    event.Release,
+			"last_seen":  event.Datetime,
+			"url":        event.WebURL,
+			"platform":   event.Platform,
+		},
+		CreatedAt: timestamp,
+	}, nil
+}
+
+// sentryProjectName prefers the project slug, which is what Sentry shows in URLs
+func sentryProjectName(slug, name string) string {
+	if slug != "" {
+		return slug
+	}
+	return name
+}
+
+// sentryValue reads a number Sentry sends either bare or quoted (issue counts,
+// project IDs). Integers come back as int64; anything else as its string form.
+func sentryValue(raw json.RawMessage) interface{} {
+	if len(raw) == 0 || string(raw) == "null" {
+		return int64(0)
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		s = string(raw)
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n
+	}
+	return s
+}
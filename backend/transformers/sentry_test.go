@@ -0,0 +1,137 @@
+package transformers
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTransformSentryIssue(t *testing.T) {
+	testTime := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		action         string
+		substatus      string
+		expectedTitle  string
+		expectedAction string
+	}{
+		{
+			name:           "new issue",
+			action:         "created",
+			expectedTitle:  "New issue in heimdall-api: TypeError: x is undefined",
+			expectedAction: "created",
+		},
+		{
+			name:           "regression",
+			action:         "unresolved",
+			substatus:      "regressed",
+			expectedTitle:  "Regression in heimdall-api: TypeError: x is undefined",
+			expectedAction: "regressed",
+		},
+		{
+			name:           "resolved",
+			action:         "resolved",
+			expectedTitle:  "Resolved in heimdall-api: TypeError: x is undefined",
+			expectedAction: "resolved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := json.RawMessage(`{
+				"action": "` + tt.action + `",
+				"data": {
+					"issue": {
+						"id": "1170820242",
+						"shortId": "HEIMDALL-API-1",
+						"title": "TypeError: x is undefined",
+						"culprit": "handlers/events.go in ServeHTTP",
+						"level": "error",
+						"status": "unresolved",
+						"substatus": "` + tt.substatus + `",
+						"count": "42",
+						"userCount": 7,
+						"firstSeen": "2024-01-14T08:00:00Z",
+						"lastSeen": "2024-01-15T10:29:00Z",
+						"web_url": "https://sentry.io/organizations/roe/issues/1170820242/",
+						"project": {"name": "Heimdall API", "slug": "heimdall-api"}
+					}
+				}
+			}`)
+
+			event, err := TransformSentryIssue(input, testTime)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event.EventType != "error.issue" {
+				t.Errorf("expected error.issue, got %s", event.EventType)
+			}
+			if event.Title != tt.expectedTitle {
+				t.Errorf("unexpected title: %s", event.Title)
+			}
+
+			expected := map[string]interface{}{
+				"action":      tt.expectedAction,
+				"project":     "heimdall-api",
+				"level":       "error",
+				"culprit":     "handlers/events.go in ServeHTTP",
+				"event_count": int64(42),
+				"first_seen":  "2024-01-14T08:00:00Z",
+				"last_seen":   "2024-01-15T10:29:00Z",
+				"url":         "https://sentry.io/organizations/roe/issues/1170820242/",
+			}
+			for key, want := range expected {
+				if got := event.Metadata[key]; got != want {
+					t.Errorf("metadata[%q] = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTransformSentryIssue_AssignedIgnored(t *testing.T) {
+	registry := NewRegistry()
+	events, err := registry.Transform("sentry.issue",
+		json.RawMessage(`{"action": "assigned", "data": {"issue": {"id": "1"}}}`), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("expected no events, got %d", len(events))
+	}
+}
+
+func TestTransformSentryEventAlert(t *testing.T) {
+	input := json.RawMessage(`{
+		"action": "triggered",
+		"data": {
+			"event": {
+				"event_id": "abc",
+				"issue_id": "1170820242",
+				"title": "TypeError: x is undefined",
+				"culprit": "handlers/events.go in ServeHTTP",
+				"level": "error",
+				"datetime": "2024-01-15T10:29:00Z",
+				"web_url": "https://sentry.io/organizations/roe/issues/1170820242/events/abc/",
+				"project": 1,
+				"release": "1.0.4"
+			},
+			"triggered_rule": "Errors in production"
+		}
+	}`)
+
+	event, err := TransformSentryEventAlert(input, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.EventType != "error.alert" {
+		t.Errorf("expected error.alert, got %s", event.EventType)
+	}
+	if event.Title != `Alert "Errors in production" triggered: TypeError: x is undefined` {
+		t.Errorf("unexpected title: %s", event.Title)
+	}
+	if event.Metadata["project_id"] != int64(1) || event.Metadata["release"] != "1.0.4" {
+		t.Errorf("unexpected metadata: %v", event.Metadata)
+	}
+}
@@ -43,15 +43,20 @@ func TransformVercelDeploy(eventData json.RawMessage, timestamp time.Time) (mode
 		return models.DashboardEvent{}, fmt.Errorf("failed to unmarshal Vercel event: %w", err)
 	}
 
-	// Determine status from event type
+	// Determine status from event type. A re-requested check puts the deployment
+	// back in progress until the checks pass again.
 	var status string
 	switch deployEvent.Type {
-	case "deployment.created":
+	case "deployment.created", "deployment.check-rerequested":
 		status = "BUILDING"
-	case "deployment.succeeded":
+	case "deployment.succeeded", "deployment.ready":
 		status = "SUCCESS"
+	case "deployment.promoted":
+		status = "PROMOTED"
 	case "deployment.error":
 		status = "FAILED"
+	case "deployment.canceled":
+		status = "CANCELED"
 	default:
 		status = "DEPLOY"
 	}
@@ -76,9 +81,21 @@ func TransformVercelDeploy(eventData json.RawMessage, timestamp time.Time) (mode
 		deployURL = "https://" + deployEvent.Payload.Deployment.URL
 	}
 
+	git := vercelGitMeta(deployEvent.Payload.Deployment.Meta)
+
+	// Deployment ID plus status identifies a delivery even when it is redelivered.
+	// The status rather than the event type is used because deployment.succeeded
+	// and deployment.ready both report the same success.
+	externalID := ""
+	if deployEvent.Payload.Deployment.ID != "" {
+		externalID = deployEvent.Payload.Deployment.ID + ":" + status
+	}
+
 	return models.DashboardEvent{
-		EventType: "vercel.deploy",
-		Title:     title,
+		EventType:  "vercel.deploy",
+		Title:      title,
+		Source:     "vercel",
+		ExternalID: externalID,
 		Metadata: map[string]interface{}{
 			"project":        projectName,
 			"status":         status,
@@ -90,7 +107,60 @@ func TransformVercelDeploy(eventData json.RawMessage, timestamp time.Time) (mode
 			"plan":           deployEvent.Payload.Plan,
 			"regions":        deployEvent.Payload.Regions,
 			"event_type":     deployEvent.Type,
+			"commit_sha":     git.sha,
+			"commit_url":     git.url,
+			"commit_message": git.message,
+			"branch":         git.branch,
+			"author":         git.author,
+			"repo":           git.repo,
 		},
 		CreatedAt: timestamp,
 	}, nil
 }
+
+// gitMeta is the commit a deployment was built from
+type gitMeta struct {
+	sha     string
+	branch  string
+	message string
+	author  string
+	repo    string
+	url     string
+}
+
+// vercelGitProviders are the prefixes Vercel puts on deployment meta keys, one
+// per connected git provider ("githubCommitSha", "gitlabCommitSha", ...)
+var vercelGitProviders = []string{"github", "gitlab", "bitbucket"}
+
+// vercelGitMeta reads the commit from deployment meta
+func vercelGitMeta(meta map[string]interface{}) gitMeta {
+	for _, provider := range vercelGitProviders {
+		sha := metaString(meta, provider+"CommitSha")
+		if sha == "" {
+			continue
+		}
+
+		git := gitMeta{
+			sha:     sha,
+			branch:  metaString(meta, provider+"CommitRef"),
+			message: metaString(meta, provider+"CommitMessage"),
+			author:  metaString(meta, provider+"CommitAuthorName", provider+"CommitAuthorLogin"),
+			repo:    metaString(meta, provider+"CommitRepo", provider+"Repo"),
+		}
+		if org := metaString(meta, "githubCommitOrg"); provider == "github" && org != "" && git.repo != "" {
+			git.url = fmt.Sprintf("https://github.com/%s/%s/commit/%s", org, git.repo, sha)
+		}
+		return git
+	}
+	return gitMeta{}
+}
+
+// metaString returns the first of keys that holds a non-empty string
+func metaString(meta map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := meta[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -130,3 +130,108 @@ func TestTransformVercelDeploy(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformVercelDeploy_ExternalID(t *testing.T) {
+	// deployment.succeeded and deployment.ready report one success and must deduplicate
+	for _, eventType := range []string{"deployment.succeeded", "deployment.ready"} {
+		input := `{"type": "` + eventType + `", "payload": {"deployment": {"id": "dpl_123", "name": "app"}}}`
+
+		result, err := TransformVercelDeploy(json.RawMessage(input), time.Now())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Source != "vercel" || result.ExternalID != "dpl_123:SUCCESS" {
+			t.Errorf("%s: expected vercel/dpl_123:SUCCESS, got %s/%s", eventType, result.Source, result.ExternalID)
+		}
+	}
+}
+
+func TestTransformVercelDeploy_Lifecycle(t *testing.T) {
+	tests := []struct {
+		eventType      string
+		expectedStatus string
+	}{
+		{"deployment.created", "BUILDING"},
+		{"deployment.check-rerequested", "BUILDING"},
+		{"deployment.succeeded", "SUCCESS"},
+		{"deployment.ready", "SUCCESS"},
+		{"deployment.promoted", "PROMOTED"},
+		{"deployment.error", "FAILED"},
+		{"deployment.canceled", "CANCELED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			input := `{"type": "` + tt.eventType + `", "payload": {"deployment": {"id": "dpl_1", "name": "app"}, "target": "production"}}`
+
+			result, err := TransformVercelDeploy(json.RawMessage(input), time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Metadata["status"] != tt.expectedStatus {
+				t.Errorf("expected status %s, got %v", tt.expectedStatus, result.Metadata["status"])
+			}
+		})
+	}
+}
+
+func TestTransformVercelDeploy_GitMeta(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     string
+		expected map[string]interface{}
+	}{
+		{
+			name: "github",
+			meta: `{
+				"githubCommitSha": "abc123",
+				"githubCommitRef": "main",
+				"githubCommitMessage": "Fix header",
+				"githubCommitAuthorName": "Roe",
+				"githubCommitAuthorLogin": "roe",
+				"githubCommitOrg": "acme",
+				"githubCommitRepo": "heimdall"
+			}`,
+			expected: map[string]interface{}{
+				"commit_sha":     "abc123",
+				"branch":         "main",
+				"commit_message": "Fix header",
+				"author":         "Roe",
+				"repo":           "heimdall",
+				"commit_url":     "https://github.com/acme/heimdall/commit/abc123",
+			},
+		},
+		{
+			name: "gitlab",
+			meta: `{"gitlabCommitSha": "def456", "gitlabCommitRef": "develop", "gitlabCommitAuthorLogin": "sam"}`,
+			expected: map[string]interface{}{
+				"commit_sha": "def456",
+				"branch":     "develop",
+				"author":     "sam",
+				"commit_url": "",
+			},
+		},
+		{
+			name:     "cli deploy without git",
+			meta:     `{}`,
+			expected: map[string]interface{}{"commit_sha": "", "branch": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := `{"type": "deployment.succeeded", "payload": {"deployment": {"id": "dpl_1", "name": "app", "meta": ` + tt.meta + `}}}`
+
+			result, err := TransformVercelDeploy(json.RawMessage(input), time.Now())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for key, want := range tt.expected {
+				if got := result.Metadata[key]; got != want {
+					t.Errorf("metadata[%q] = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
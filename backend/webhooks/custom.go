@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"heimdall-backend/transformers"
+)
+
+// CustomSource handles deliveries for a source declared in the mapping config.
+// Rules are tried in order and the first match decides the event type.
+type CustomSource struct {
+	config transformers.MappingSource
+	secret string
+}
+
+// NewCustomSource creates a source from its mapping config, resolving the auth
+// secret from the environment. A configured but unset secret is an error so a
+// typo cannot silently disable verification.
+func NewCustomSource(config transformers.MappingSource) (*CustomSource, error) {
+	s := &CustomSource{config: config}
+	if config.Auth != nil {
+		s.secret = os.Getenv(config.Auth.SecretEnv)
+		if s.secret == "" {
+			return nil, fmt.Errorf("source %s: environment variable %s is not set", config.Name, config.Auth.SecretEnv)
+		}
+	}
+	return s, nil
+}
+
+// Name returns the source name, prefixed so it cannot collide with built-in providers
+func (s *CustomSource) Name() string {
+	return "custom:" + s.config.Name
+}
+
+// Slug returns the configured source name used in its route
+func (s *CustomSource) Slug() string {
+	return s.config.Name
+}
+
+// Authenticated reports whether deliveries are verified
+func (s *CustomSource) Authenticated() bool {
+	return s.config.Auth != nil
+}
+
+// Verify checks the configured token or HMAC header. A source without auth
+// rejects every delivery unless it is wrapped with Unsigned.
+func (s *CustomSource) Verify(r *http.Request, body []byte) error {
+	auth := s.config.Auth
+	if auth == nil {
+		return ErrNoSecret
+	}
+
+	header := r.Header.Get(auth.Header)
+	switch auth.Scheme {
+	case transformers.MappingAuthHMACSHA256:
+		return VerifyHMACSHA256(s.secret, body, header)
+	default:
+		return VerifyToken(s.secret, header)
+	}
+}
+
+// Parse selects the first matching rule and extracts the delivery ID and timestamp
+func (s *CustomSource) Parse(r *http.Request, body []byte) (Delivery, error) {
+	doc, err := transformers.DecodeMappingPayload(body)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse %s payload: %w", s.config.Name, err)
+	}
+
+	for _, rule := range s.config.Rules {
+		if rule.Match != nil && !rule.Match.Matches(r.Header, doc) {
+			continue
+		}
+
+		delivery := Delivery{EventType: rule.EventType}
+		if s.config.DeliveryID != nil {
+			delivery.ID, _ = s.config.DeliveryID.Value(r.Header, doc)
+		}
+		if s.config.Timestamp != nil {
+			if value, ok := s.config.Timestamp.Value(r.Header, doc); ok {
+				delivery.Timestamp = parseFlexibleTime(value)
+			}
+		}
+		return delivery, nil
+	}
+
+	return Delivery{}, fmt.Errorf("%w: no %s rule matched", ErrIgnoredEvent, s.config.Name)
+}
+
+// parseFlexibleTime accepts RFC3339 or a Unix timestamp in seconds or milliseconds,
+// returning the zero time for anything else
+func parseFlexibleTime(value string) time.Time {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC()
+	}
+	n, err := strconv.ParseInt(strings.Split(value, ".")[0], 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	// Values past the year 2286 in seconds are milliseconds
+	if n > 1e10 {
+		return time.UnixMilli(n).UTC()
+	}
+	return time.Unix(n, 0).UTC()
+}
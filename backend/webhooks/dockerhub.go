@@ -0,0 +1,49 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// DockerHubSource handles Docker Hub repository webhooks, which are sent on
+// every image push. Docker Hub does not sign its webhooks, so an optional
+// shared token can be appended to the webhook URL as ?token=... instead.
+type DockerHubSource struct {
+	Token string
+}
+
+// Name returns the provider name
+func (s *DockerHubSource) Name() string {
+	return "dockerhub"
+}
+
+// Verify checks the token query parameter
+func (s *DockerHubSource) Verify(r *http.Request, _ []byte) error {
+	if s.Token == "" {
+		return ErrNoSecret
+	}
+	return VerifyToken(s.Token, r.URL.Query().Get("token"))
+}
+
+// Parse treats every Docker Hub delivery as an image push
+func (s *DockerHubSource) Parse(_ *http.Request, body []byte) (Delivery, error) {
+	var envelope struct {
+		PushData *struct {
+			PushedAt int64 `json:"pushed_at"` // Unix seconds
+		} `json:"push_data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse Docker Hub payload: %w", err)
+	}
+	if envelope.PushData == nil {
+		return Delivery{}, fmt.Errorf("payload is not a Docker Hub push")
+	}
+
+	delivery := Delivery{EventType: "dockerhub.push"}
+	if envelope.PushData.PushedAt > 0 {
+		delivery.Timestamp = time.Unix(envelope.PushData.PushedAt, 0).UTC()
+	}
+	return delivery, nil
+}
@@ -0,0 +1,76 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// githubEventTypes maps X-GitHub-Event values to registry event types
+var githubEventTypes = map[string]string{
+	"push":                           "github.push",
+	"pull_request":                   "github.pr",
+	"issues":                         "github.issue",
+	"release":                        "github.release",
+	"workflow_run":                   "github.workflow_run",
+	"workflow_job":                   "github.workflow_job",
+	"check_suite":                    "github.check_suite",
+	"dependabot_alert":               "github.dependabot_alert",
+	"code_scanning_alert":            "github.code_scanning_alert",
+	"secret_scanning_alert":          "github.secret_scanning_alert",
+	"repository_vulnerability_alert": "github.repository_vulnerability_alert",
+	"pull_request_review":            "github.pull_request_review",
+	"pull_request_review_comment":    "github.pull_request_review_comment",
+	"issue_comment":                  "github.issue_comment",
+	"discussion":                     "github.discussion",
+	"discussion_comment":             "github.discussion_comment",
+	"create":                         "github.create",
+	"delete":                         "github.delete",
+	"fork":                           "github.fork",
+	"star":                           "github.star",
+	"watch":                          "github.watch",
+	"deployment":                     "github.deployment",
+	"deployment_status":              "github.deployment_status",
+	"package":                        "github.package",
+	"registry_package":               "github.registry_package",
+}
+
+// GitHubSource handles native GitHub webhook deliveries
+type GitHubSource struct {
+	Secret string
+}
+
+// Name returns the provider name
+func (s *GitHubSource) Name() string {
+	return "github"
+}
+
+// Verify checks X-Hub-Signature-256
+func (s *GitHubSource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	return VerifyGitHubSignature(s.Secret, body, r.Header.Get("X-Hub-Signature-256"))
+}
+
+// Parse resolves the event type from the X-GitHub-Event header
+func (s *GitHubSource) Parse(r *http.Request, _ []byte) (Delivery, error) {
+	event := r.Header.Get("X-GitHub-Event")
+	if event == "" {
+		return Delivery{}, fmt.Errorf("missing X-GitHub-Event header")
+	}
+
+	// GitHub sends a ping when a webhook is first configured
+	if event == "ping" {
+		return Delivery{}, ErrIgnoredEvent
+	}
+
+	eventType, ok := githubEventTypes[event]
+	if !ok {
+		return Delivery{}, fmt.Errorf("%w: unsupported GitHub event %q", ErrIgnoredEvent, event)
+	}
+
+	return Delivery{
+		EventType: eventType,
+		ID:        r.Header.Get("X-GitHub-Delivery"),
+	}, nil
+}
@@ -0,0 +1,52 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// gitlabEventTypes maps X-Gitlab-Event values to registry event types
+var gitlabEventTypes = map[string]string{
+	"Push Hook":          "gitlab.push",
+	"Tag Push Hook":      "gitlab.tag",
+	"Merge Request Hook": "gitlab.mr",
+	"Pipeline Hook":      "gitlab.pipeline",
+	"Release Hook":       "gitlab.release",
+}
+
+// GitLabSource handles native GitLab webhook deliveries
+type GitLabSource struct {
+	Token string // Secret token configured on the GitLab webhook
+}
+
+// Name returns the provider name
+func (s *GitLabSource) Name() string {
+	return "gitlab"
+}
+
+// Verify checks X-Gitlab-Token. GitLab sends the
+// secret itself rather than a signature.
+func (s *GitLabSource) Verify(r *http.Request, _ []byte) error {
+	if s.Token == "" {
+		return ErrNoSecret
+	}
+	return VerifyToken(s.Token, r.Header.Get("X-Gitlab-Token"))
+}
+
+// Parse resolves the event type from the X-Gitlab-Event header
+func (s *GitLabSource) Parse(r *http.Request, _ []byte) (Delivery, error) {
+	event := r.Header.Get("X-Gitlab-Event")
+	if event == "" {
+		return Delivery{}, fmt.Errorf("missing X-Gitlab-Event header")
+	}
+
+	eventType, ok := gitlabEventTypes[event]
+	if !ok {
+		return Delivery{}, fmt.Errorf("%w: unsupported GitLab event %q", ErrIgnoredEvent, event)
+	}
+
+	return Delivery{
+		EventType: eventType,
+		ID:        r.Header.Get("X-Gitlab-Event-UUID"),
+	}, nil
+}
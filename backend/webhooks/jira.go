@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// jiraEventTypes maps Jira webhookEvent values to registry event types.
+// Transitions arrive as jira:issue_updated with a status changelog entry.
+var jiraEventTypes = map[string]string{
+	"jira:issue_created": "jira.issue",
+	"jira:issue_updated": "jira.issue",
+}
+
+// JiraSource handles Jira Cloud webhook deliveries
+type JiraSource struct {
+	Secret string // Secret of the Jira admin webhook
+}
+
+// Name returns the provider name
+func (s *JiraSource) Name() string {
+	return "jira"
+}
+
+// Verify checks X-Hub-Signature ("sha256=<hex>")
+func (s *JiraSource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	return VerifyHMACSHA256(s.Secret, body, r.Header.Get("X-Hub-Signature"))
+}
+
+// Parse resolves the event type from the payload's "webhookEvent" field
+func (s *JiraSource) Parse(r *http.Request, body []byte) (Delivery, error) {
+	var envelope struct {
+		WebhookEvent string `json:"webhookEvent"`
+		Timestamp    int64  `json:"timestamp"` // Unix milliseconds
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse Jira payload: %w", err)
+	}
+
+	eventType, ok := jiraEventTypes[envelope.WebhookEvent]
+	if !ok {
+		return Delivery{}, fmt.Errorf("%w: unsupported Jira event %q", ErrIgnoredEvent, envelope.WebhookEvent)
+	}
+
+	delivery := Delivery{
+		EventType: eventType,
+		ID:        r.Header.Get("X-Atlassian-Webhook-Identifier"),
+	}
+	if envelope.Timestamp > 0 {
+		delivery.Timestamp = time.UnixMilli(envelope.Timestamp).UTC()
+	}
+	return delivery, nil
+}
@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// jwtLeeway tolerates small clock differences between the signer and this service
+const jwtLeeway = time.Minute
+
+// jwtClaims holds the registered claims checked by verifyHS256JWT plus the
+// body hash claims used by QStash and Netlify
+type jwtClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp"`
+	NotBefore int64  `json:"nbf"`
+	IssuedAt  int64  `json:"iat"`
+	Body      string `json:"body"`   // QStash: base64url SHA-256 of the body
+	SHA256    string `json:"sha256"` // Netlify: hex SHA-256 of the body
+}
+
+// verifyHS256JWT validates an HS256-signed compact JWT and its time-based claims
+func verifyHS256JWT(token string, key []byte, now time.Time) (jwtClaims, error) {
+	var claims jwtClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, fmt.Errorf("%w: malformed JWT", ErrInvalidSignature)
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return claims, fmt.Errorf("%w: malformed JWT header", ErrInvalidSignature)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil || header.Alg != "HS256" {
+		return claims, fmt.Errorf("%w: unsupported JWT algorithm", ErrInvalidSignature)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, fmt.Errorf("%w: malformed JWT signature", ErrInvalidSignature)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(mac.Sum(nil), signature) {
+		return claims, ErrInvalidSignature
+	}
+
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, fmt.Errorf("%w: malformed JWT payload", ErrInvalidSignature)
+	}
+	if err := json.Unmarshal(payloadJSON, &claims); err != nil {
+		return claims, fmt.Errorf("%w: malformed JWT claims", ErrInvalidSignature)
+	}
+
+	if claims.ExpiresAt > 0 && now.After(time.Unix(claims.ExpiresAt, 0).Add(jwtLeeway)) {
+		return claims, fmt.Errorf("%w: token expired", ErrInvalidSignature)
+	}
+	if claims.NotBefore > 0 && now.Before(time.Unix(claims.NotBefore, 0).Add(-jwtLeeway)) {
+		return claims, fmt.Errorf("%w: token not yet valid", ErrInvalidSignature)
+	}
+
+	return claims, nil
+}
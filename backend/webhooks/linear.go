@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// linearTimestampTolerance is how far webhookTimestamp may be from now, as
+// recommended by Linear to reject replayed deliveries
+const linearTimestampTolerance = time.Minute
+
+// linearEventTypes maps Linear-Event values to registry event types
+var linearEventTypes = map[string]string{
+	"Issue":   "linear.issue",
+	"Comment": "linear.comment",
+}
+
+// LinearSource handles Linear webhook deliveries
+type LinearSource struct {
+	Secret string // Signing secret of the Linear webhook
+}
+
+// Name returns the provider name
+func (s *LinearSource) Name() string {
+	return "linear"
+}
+
+// Verify checks Linear-Signature and the signed webhookTimestamp
+func (s *LinearSource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	if err := VerifyHMACSHA256(s.Secret, body, r.Header.Get("Linear-Signature")); err != nil {
+		return err
+	}
+
+	var envelope struct {
+		WebhookTimestamp int64 `json:"webhookTimestamp"` // Unix milliseconds
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.WebhookTimestamp == 0 {
+		return fmt.Errorf("%w: missing webhookTimestamp", ErrInvalidSignature)
+	}
+	age := time.Since(time.UnixMilli(envelope.WebhookTimestamp))
+	if age > linearTimestampTolerance || age < -linearTimestampTolerance {
+		return fmt.Errorf("%w: webhookTimestamp outside tolerance", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// Parse resolves the event type from the Linear-Event header
+func (s *LinearSource) Parse(r *http.Request, body []byte) (Delivery, error) {
+	event := r.Header.Get("Linear-Event")
+	if event == "" {
+		return Delivery{}, fmt.Errorf("missing Linear-Event header")
+	}
+
+	eventType, ok := linearEventTypes[event]
+	if !ok {
+		return Delivery{}, fmt.Errorf("%w: unsupported Linear event %q", ErrIgnoredEvent, event)
+	}
+
+	delivery := Delivery{
+		EventType: eventType,
+		ID:        r.Header.Get("Linear-Delivery"),
+	}
+	var envelope struct {
+		WebhookTimestamp int64 `json:"webhookTimestamp"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && envelope.WebhookTimestamp > 0 {
+		delivery.Timestamp = time.UnixMilli(envelope.WebhookTimestamp).UTC()
+	}
+	return delivery, nil
+}
@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// netlifyIssuer is the "iss" claim on every X-Webhook-Signature token
+const netlifyIssuer = "netlify"
+
+// NetlifySource handles Netlify deploy notification webhooks. Netlify posts the
+// deploy object itself with no event header, so every delivery is a deploy and
+// the notification (building, ready, error, locked) is read from its state.
+type NetlifySource struct {
+	Secret string // JWS secret token configured on the notification
+}
+
+// Name returns the provider name
+func (s *NetlifySource) Name() string {
+	return "netlify"
+}
+
+// Verify checks the X-Webhook-Signature JWS
+func (s *NetlifySource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	token := r.Header.Get("X-Webhook-Signature")
+	if token == "" {
+		return ErrMissingSignature
+	}
+	return verifyNetlifyToken(token, s.Secret, body)
+}
+
+// Parse treats every Netlify delivery as a deploy event
+func (s *NetlifySource) Parse(_ *http.Request, body []byte) (Delivery, error) {
+	var envelope struct {
+		ID        string `json:"id"`
+		UpdatedAt string `json:"updated_at"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse Netlify payload: %w", err)
+	}
+	if envelope.ID == "" {
+		return Delivery{}, fmt.Errorf("payload is not a Netlify deploy")
+	}
+
+	delivery := Delivery{EventType: "netlify.deploy"}
+	if ts, err := time.Parse(time.RFC3339, envelope.UpdatedAt); err == nil {
+		delivery.Timestamp = ts.UTC()
+	}
+	return delivery, nil
+}
+
+// verifyNetlifyToken validates the token signature, issuer and body hash claim
+func verifyNetlifyToken(token, secret string, body []byte) error {
+	claims, err := verifyHS256JWT(token, []byte(secret), time.Now())
+	if err != nil {
+		return err
+	}
+
+	if claims.Issuer != netlifyIssuer {
+		return fmt.Errorf("%w: unexpected issuer %q", ErrInvalidSignature, claims.Issuer)
+	}
+
+	sum := sha256.Sum256(body)
+	if claims.SHA256 != hex.EncodeToString(sum[:]) {
+		return fmt.Errorf("%w: body hash mismatch", ErrInvalidSignature)
+	}
+	return nil
+}
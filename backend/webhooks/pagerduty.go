@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// pagerDutyIncidentEvents are the V3 incident events with a dashboard representation.
+// Notes, responder requests and priority updates are ignored.
+var pagerDutyIncidentEvents = map[string]bool{
+	"incident.triggered":    true,
+	"incident.acknowledged": true,
+	"incident.escalated":    true,
+	"incident.resolved":     true,
+	"incident.reopened":     true,
+}
+
+// PagerDutySource handles PagerDuty V3 webhook deliveries
+type PagerDutySource struct {
+	Secret string // Signing secret of the PagerDuty webhook subscription
+}
+
+// Name returns the provider name
+func (s *PagerDutySource) Name() string {
+	return "pagerduty"
+}
+
+// Verify checks X-PagerDuty-Signature. The header holds one "v1=<hex>" entry
+// per active secret, separated by commas, so that deliveries keep verifying
+// while a secret is rotated.
+func (s *PagerDutySource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	header := r.Header.Get("X-PagerDuty-Signature")
+	if header == "" {
+		return ErrMissingSignature
+	}
+	for _, entry := range strings.Split(header, ",") {
+		signature, ok := strings.CutPrefix(strings.TrimSpace(entry), "v1=")
+		if !ok {
+			continue
+		}
+		err := VerifyHMACSHA256(s.Secret, body, signature)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrInvalidSignature) {
+			return err
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// Parse resolves the event type from the payload's "event.event_type" field
+func (s *PagerDutySource) Parse(_ *http.Request, body []byte) (Delivery, error) {
+	var envelope struct {
+		Event struct {
+			ID         string    `json:"id"`
+			EventType  string    `json:"event_type"`
+			OccurredAt time.Time `json:"occurred_at"`
+		} `json:"event"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse PagerDuty payload: %w", err)
+	}
+
+	event := envelope.Event
+	if event.EventType == "" {
+		return Delivery{}, fmt.Errorf("payload is not a PagerDuty V3 event")
+	}
+	// pagey.ping is sent when a subscription is created or tested
+	if !pagerDutyIncidentEvents[event.EventType] {
+		return Delivery{}, fmt.Errorf("%w: unsupported PagerDuty event %q", ErrIgnoredEvent, event.EventType)
+	}
+
+	return Delivery{
+		EventType: "pagerduty.incident",
+		Timestamp: event.OccurredAt,
+		ID:        event.ID,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// signQStashToken builds an Upstash-Signature token the way QStash does
+func signQStashToken(t *testing.T, key string, body []byte, claims map[string]interface{}) string {
+	t.Helper()
+
+	sum := sha256.Sum256(body)
+	payload := map[string]interface{}{
+		"iss":  "Upstash",
+		"sub":  "https://example.com/api/webhook",
+		"exp":  time.Now().Add(5 * time.Minute).Unix(),
+		"nbf":  time.Now().Unix(),
+		"iat":  time.Now().Unix(),
+		"body": base64.URLEncoding.EncodeToString(sum[:]),
+	}
+	for k, v := range claims {
+		payload[k] = v
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(payloadJSON)
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestQStashVerifier_Verify(t *testing.T) {
+	body := []byte(`{"type":"github.push","event":{}}`)
+	verifier := &QStashVerifier{CurrentSigningKey: "current", NextSigningKey: "next"}
+
+	tests := []struct {
+		name      string
+		token     string
+		expectErr bool
+	}{
+		{
+			name:  "signed with current key",
+			token: signQStashToken(t, "current", body, nil),
+		},
+		{
+			name:  "signed with next key",
+			token: signQStashToken(t, "next", body, nil),
+		},
+		{
+			name:      "signed with unknown key",
+			token:     signQStashToken(t, "other", body, nil),
+			expectErr: true,
+		},
+		{
+			name:      "body mismatch",
+			token:     signQStashToken(t, "current", []byte(`{"tampered":true}`), nil),
+			expectErr: true,
+		},
+		{
+			name:      "expired",
+			token:     signQStashToken(t, "current", body, map[string]interface{}{"exp": time.Now().Add(-time.Hour).Unix()}),
+			expectErr: true,
+		},
+		{
+			name:      "wrong issuer",
+			token:     signQStashToken(t, "current", body, map[string]interface{}{"iss": "Someone"}),
+			expectErr: true,
+		},
+		{
+			name:      "malformed",
+			token:     "not-a-jwt",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifier.Verify(tt.token, body)
+			if tt.expectErr {
+				if !errors.Is(err, ErrInvalidSignature) {
+					t.Errorf("expected ErrInvalidSignature, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestQStashVerifier_MissingSignature(t *testing.T) {
+	verifier := &QStashVerifier{CurrentSigningKey: "current"}
+	if err := verifier.Verify("", []byte("{}")); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RailwaySource handles native Railway webhook deliveries.
+// Railway does not sign its webhooks, so an optional shared token
+// can be appended to the webhook URL as ?token=... instead.
+type RailwaySource struct {
+	Token string
+}
+
+// Name returns the provider name
+func (s *RailwaySource) Name() string {
+	return "railway"
+}
+
+// Verify checks the token query parameter
+func (s *RailwaySource) Verify(r *http.Request, _ []byte) error {
+	if s.Token == "" {
+		return ErrNoSecret
+	}
+	return VerifyToken(s.Token, r.URL.Query().Get("token"))
+}
+
+// Parse treats every Railway delivery as a deployment event
+func (s *RailwaySource) Parse(_ *http.Request, body []byte) (Delivery, error) {
+	var envelope struct {
+		Type      string `json:"type"`
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse Railway payload: %w", err)
+	}
+
+	delivery := Delivery{EventType: "railway.deploy"}
+	if ts, err := time.Parse(time.RFC3339, envelope.Timestamp); err == nil {
+		delivery.Timestamp = ts.UTC()
+	}
+	return delivery, nil
+}
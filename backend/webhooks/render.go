@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// renderTimestampTolerance bounds how old a signed Render delivery may be, so a
+// captured request cannot be replayed later
+const renderTimestampTolerance = 5 * time.Minute
+
+// renderDeployEvents are the Render webhook types recorded as render.deploy.
+// Build phases are skipped: a failed build also ends its deploy.
+var renderDeployEvents = map[string]bool{
+	"deploy_started": true,
+	"deploy_ended":   true,
+}
+
+// RenderSource handles native Render webhook deliveries
+type RenderSource struct {
+	Secret string // Signing secret of the Render webhook ("whsec_...")
+}
+
+// Name returns the provider name
+func (s *RenderSource) Name() string {
+	return "render"
+}
+
+// Verify checks the webhook-signature header
+func (s *RenderSource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	return verifyRenderSignature(s.Secret, r.Header, body, time.Now())
+}
+
+// Parse resolves the event type from the payload's "type" field
+func (s *RenderSource) Parse(r *http.Request, body []byte) (Delivery, error) {
+	var envelope struct {
+		Type      string `json:"type"`
+		Timestamp string `json:"timestamp"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse Render payload: %w", err)
+	}
+
+	if !renderDeployEvents[envelope.Type] {
+		return Delivery{}, fmt.Errorf("%w: unsupported Render event %q", ErrIgnoredEvent, envelope.Type)
+	}
+
+	delivery := Delivery{
+		EventType: "render.deploy",
+		ID:        r.Header.Get("webhook-id"),
+	}
+	if ts, err := time.Parse(time.RFC3339, envelope.Timestamp); err == nil {
+		delivery.Timestamp = ts.UTC()
+	}
+	return delivery, nil
+}
+
+// verifyRenderSignature validates a Standard Webhooks signature: a base64
+// HMAC-SHA256 of "<webhook-id>.<webhook-timestamp>.<body>", keyed with the
+// base64 part of the "whsec_" secret. The header may list several
+// space-separated "v1,<signature>" entries while a secret is being rotated.
+func verifyRenderSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	id := header.Get("webhook-id")
+	timestamp := header.Get("webhook-timestamp")
+	signatures := header.Get("webhook-signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return ErrMissingSignature
+	}
+
+	secs, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp", ErrInvalidSignature)
+	}
+	sent := time.Unix(secs, 0)
+	if now.Sub(sent) > renderTimestampTolerance || sent.Sub(now) > renderTimestampTolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidSignature)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return fmt.Errorf("invalid Render webhook secret: %w", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, entry := range strings.Fields(signatures) {
+		version, signature, ok := strings.Cut(entry, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		received, err := base64.StdEncoding.DecodeString(signature)
+		if err == nil && hmac.Equal(expected, received) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,55 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// sentryResourceTypes maps Sentry-Hook-Resource values to registry event types
+var sentryResourceTypes = map[string]string{
+	"issue":       "sentry.issue",
+	"event_alert": "sentry.event_alert",
+}
+
+// SentrySource handles Sentry integration webhook deliveries
+type SentrySource struct {
+	Secret string // Client secret of the Sentry internal integration
+}
+
+// Name returns the provider name
+func (s *SentrySource) Name() string {
+	return "sentry"
+}
+
+// Verify checks Sentry-Hook-Signature
+func (s *SentrySource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	return VerifyHMACSHA256(s.Secret, body, r.Header.Get("Sentry-Hook-Signature"))
+}
+
+// Parse resolves the event type from the Sentry-Hook-Resource header
+func (s *SentrySource) Parse(r *http.Request, _ []byte) (Delivery, error) {
+	resource := r.Header.Get("Sentry-Hook-Resource")
+	if resource == "" {
+		return Delivery{}, fmt.Errorf("missing Sentry-Hook-Resource header")
+	}
+
+	// Installation and metric alert hooks have no dashboard representation
+	eventType, ok := sentryResourceTypes[resource]
+	if !ok {
+		return Delivery{}, fmt.Errorf("%w: unsupported Sentry resource %q", ErrIgnoredEvent, resource)
+	}
+
+	delivery := Delivery{
+		EventType: eventType,
+		ID:        r.Header.Get("Request-ID"),
+	}
+	if secs, err := strconv.ParseInt(r.Header.Get("Sentry-Hook-Timestamp"), 10, 64); err == nil && secs > 0 {
+		delivery.Timestamp = time.Unix(secs, 0).UTC()
+	}
+	return delivery, nil
+}
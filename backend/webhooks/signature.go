@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- Vercel signs webhooks with HMAC-SHA1
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"strings"
+)
+
+var (
+	// ErrNoSecret is returned when a source has no secret to verify requests against
+	ErrNoSecret = errors.New("webhook secret not configured")
+	// ErrMissingSignature is returned when a secret is configured but the request is unsigned
+	ErrMissingSignature = errors.New("missing webhook signature")
+	// ErrInvalidSignature is returned when the signature does not match the body
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// VerifyGitHubSignature validates an X-Hub-Signature-256 header ("sha256=<hex>")
+func VerifyGitHubSignature(secret string, body []byte, header string) error {
+	if header == "" {
+		return ErrMissingSignature
+	}
+	signature, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return verifyHMAC(sha256.New, secret, body, signature)
+}
+
+// VerifyVercelSignature validates an x-vercel-signature header (hex HMAC-SHA1 of the body)
+func VerifyVercelSignature(secret string, body []byte, header string) error {
+	if header == "" {
+		return ErrMissingSignature
+	}
+	return verifyHMAC(sha1.New, secret, body, header)
+}
+
+// VerifyHMACSHA256 validates a hex HMAC-SHA256 of the body, with or without a "sha256=" prefix
+func VerifyHMACSHA256(secret string, body []byte, header string) error {
+	if header == "" {
+		return ErrMissingSignature
+	}
+	return verifyHMAC(sha256.New, secret, body, strings.TrimPrefix(header, "sha256="))
+}
+
+// VerifyToken compares a shared token in constant time
+func VerifyToken(expected, received string) error {
+	if received == "" {
+		return ErrMissingSignature
+	}
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(received)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// verifyHMAC compares a hex-encoded HMAC of body against the expected digest
+func verifyHMAC(h func() hash.Hash, secret string, body []byte, signatureHex string) error {
+	received, err := hex.DecodeString(strings.TrimSpace(signatureHex))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(h, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), received) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
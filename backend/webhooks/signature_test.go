@@ -0,0 +1,91 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func signSHA256(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	body := []byte(`{"ref":"refs/heads/main"}`)
+	secret := "s3cret"
+
+	tests := []struct {
+		name        string
+		header      string
+		expectedErr error
+	}{
+		{
+			name:        "valid signature",
+			header:      "sha256=" + signSHA256(secret, body),
+			expectedErr: nil,
+		},
+		{
+			name:        "missing signature",
+			header:      "",
+			expectedErr: ErrMissingSignature,
+		},
+		{
+			name:        "wrong secret",
+			header:      "sha256=" + signSHA256("other", body),
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "missing prefix",
+			header:      signSHA256(secret, body),
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "not hex",
+			header:      "sha256=zzzz",
+			expectedErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyGitHubSignature(secret, body, tt.header)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyVercelSignature(t *testing.T) {
+	body := []byte(`{"type":"deployment.created"}`)
+	secret := "vercel-secret"
+
+	mac := hmac.New(sha1.New, []byte(secret))
+	mac.Write(body)
+	valid := hex.EncodeToString(mac.Sum(nil))
+
+	if err := VerifyVercelSignature(secret, body, valid); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+
+	if err := VerifyVercelSignature(secret, []byte(`{"type":"tampered"}`), valid); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for tampered body, got %v", err)
+	}
+}
+
+func TestVerifyToken(t *testing.T) {
+	if err := VerifyToken("abc", "abc"); err != nil {
+		t.Errorf("expected matching token to pass, got %v", err)
+	}
+	if err := VerifyToken("abc", "abd"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	if err := VerifyToken("abc", ""); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+}
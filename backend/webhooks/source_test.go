@@ -0,0 +1,494 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"heimdall-backend/transformers"
+)
+
+func TestGitHubSource_Parse(t *testing.T) {
+	tests := []struct {
+		name         string
+		event        string
+		expectedType string
+		expectIgnore bool
+		expectedErr  bool
+	}{
+		{name: "push", event: "push", expectedType: "github.push"},
+		{name: "pull request", event: "pull_request", expectedType: "github.pr"},
+		{name: "issues", event: "issues", expectedType: "github.issue"},
+		{name: "release", event: "release", expectedType: "github.release"},
+		{name: "workflow run", event: "workflow_run", expectedType: "github.workflow_run"},
+		{name: "workflow job", event: "workflow_job", expectedType: "github.workflow_job"},
+		{name: "check suite", event: "check_suite", expectedType: "github.check_suite"},
+		{name: "dependabot alert", event: "dependabot_alert", expectedType: "github.dependabot_alert"},
+		{name: "secret scanning alert", event: "secret_scanning_alert", expectedType: "github.secret_scanning_alert"},
+		{name: "pull request review", event: "pull_request_review", expectedType: "github.pull_request_review"},
+		{name: "issue comment", event: "issue_comment", expectedType: "github.issue_comment"},
+		{name: "discussion comment", event: "discussion_comment", expectedType: "github.discussion_comment"},
+		{name: "create", event: "create", expectedType: "github.create"},
+		{name: "star", event: "star", expectedType: "github.star"},
+		{name: "deployment status", event: "deployment_status", expectedType: "github.deployment_status"},
+		{name: "ping is ignored", event: "ping", expectIgnore: true},
+		{name: "unsupported event is ignored", event: "gollum", expectIgnore: true},
+		{name: "missing header", event: "", expectedErr: true},
+	}
+
+	source := &GitHubSource{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/webhook/github", http.NoBody)
+			if tt.event != "" {
+				req.Header.Set("X-GitHub-Event", tt.event)
+			}
+
+			delivery, err := source.Parse(req, nil)
+
+			if tt.expectIgnore {
+				if !errors.Is(err, ErrIgnoredEvent) {
+					t.Errorf("expected ErrIgnoredEvent, got %v", err)
+				}
+				return
+			}
+			if tt.expectedErr {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if delivery.EventType != tt.expectedType {
+				t.Errorf("expected event type %q, got %q", tt.expectedType, delivery.EventType)
+			}
+		})
+	}
+}
+
+func TestGitHubSource_VerifyRequiresSecret(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/github", http.NoBody)
+	if err := (&GitHubSource{}).Verify(req, []byte("{}")); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("expected ErrNoSecret without a secret, got %v", err)
+	}
+	if err := Unsigned(&GitHubSource{}).Verify(req, []byte("{}")); err != nil {
+		t.Errorf("expected an unsigned source to skip verification, got %v", err)
+	}
+	if err := (&GitHubSource{Secret: "x"}).Verify(req, []byte("{}")); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+}
+
+func TestSources_VerifyRequiresSecret(t *testing.T) {
+	sources := []Source{
+		&SentrySource{},
+		&GitLabSource{},
+		&NetlifySource{},
+		&RenderSource{},
+		&LinearSource{},
+		&JiraSource{},
+		&PagerDutySource{},
+		&DockerHubSource{},
+	}
+	for _, source := range sources {
+		req := httptest.NewRequest(http.MethodPost, "/api/webhook/"+source.Name(), http.NoBody)
+		if err := source.Verify(req, []byte("{}")); !errors.Is(err, ErrNoSecret) {
+			t.Errorf("%s: expected ErrNoSecret without a secret, got %v", source.Name(), err)
+		}
+	}
+}
+
+func TestVercelSource_Parse(t *testing.T) {
+	source := &VercelSource{}
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/vercel", http.NoBody)
+
+	delivery, err := source.Parse(req, []byte(`{"type":"deployment.succeeded","createdAt":1705314600000}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "vercel.deploy" {
+		t.Errorf("expected vercel.deploy, got %q", delivery.EventType)
+	}
+	expected := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	if !delivery.Timestamp.Equal(expected) {
+		t.Errorf("expected timestamp %v, got %v", expected, delivery.Timestamp)
+	}
+
+	if _, err := source.Parse(req, []byte(`{"type":"project.created"}`)); !errors.Is(err, ErrIgnoredEvent) {
+		t.Errorf("expected ErrIgnoredEvent for non-deployment event, got %v", err)
+	}
+}
+
+func TestRailwaySource_VerifyToken(t *testing.T) {
+	source := &RailwaySource{Token: "tok"}
+
+	valid := httptest.NewRequest(http.MethodPost, "/api/webhook/railway?token=tok", strings.NewReader("{}"))
+	if err := source.Verify(valid, nil); err != nil {
+		t.Errorf("expected valid token, got %v", err)
+	}
+
+	invalid := httptest.NewRequest(http.MethodPost, "/api/webhook/railway?token=nope", strings.NewReader("{}"))
+	if err := source.Verify(invalid, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestGitLabSource_ParseAndVerify(t *testing.T) {
+	source := &GitLabSource{Token: "glsecret"}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/gitlab", http.NoBody)
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Event-UUID", "uuid-1")
+
+	if err := source.Verify(req, nil); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature without token, got %v", err)
+	}
+	req.Header.Set("X-Gitlab-Token", "wrong")
+	if err := source.Verify(req, nil); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	req.Header.Set("X-Gitlab-Token", "glsecret")
+	if err := source.Verify(req, nil); err != nil {
+		t.Errorf("expected valid token, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "gitlab.mr" || delivery.ID != "uuid-1" {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+
+	req.Header.Set("X-Gitlab-Event", "Wiki Page Hook")
+	if _, err := source.Parse(req, nil); !errors.Is(err, ErrIgnoredEvent) {
+		t.Errorf("expected ErrIgnoredEvent, got %v", err)
+	}
+}
+
+func TestSentrySource_Parse(t *testing.T) {
+	source := &SentrySource{}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/sentry", http.NoBody)
+	req.Header.Set("Sentry-Hook-Resource", "issue")
+	req.Header.Set("Sentry-Hook-Timestamp", "1705314600")
+	req.Header.Set("Request-ID", "req-1")
+
+	delivery, err := source.Parse(req, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "sentry.issue" || delivery.ID != "req-1" {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+	expected := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	if !delivery.Timestamp.Equal(expected) {
+		t.Errorf("expected timestamp %v, got %v", expected, delivery.Timestamp)
+	}
+
+	req.Header.Set("Sentry-Hook-Resource", "installation")
+	if _, err := source.Parse(req, nil); !errors.Is(err, ErrIgnoredEvent) {
+		t.Errorf("expected ErrIgnoredEvent for installation hook, got %v", err)
+	}
+
+	if err := (&SentrySource{Secret: "x"}).Verify(req, []byte("{}")); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+}
+
+func TestNetlifySource_ParseAndVerify(t *testing.T) {
+	source := &NetlifySource{Secret: "jws-secret"}
+	body := []byte(`{"id":"d1","state":"ready","updated_at":"2024-01-15T10:30:00Z"}`)
+	sum := sha256.Sum256(body)
+	bodyHash := hex.EncodeToString(sum[:])
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/netlify", http.NoBody)
+	if err := source.Verify(req, body); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+	req.Header.Set("X-Webhook-Signature", signQStashToken(t, "jws-secret", body, map[string]interface{}{"iss": "netlify", "sha256": bodyHash}))
+	if err := source.Verify(req, body); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+	if err := source.Verify(req, []byte(`{"id":"d2"}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a tampered body, got %v", err)
+	}
+	req.Header.Set("X-Webhook-Signature", signQStashToken(t, "jws-secret", body, map[string]interface{}{"sha256": bodyHash}))
+	if err := source.Verify(req, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a foreign issuer, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "netlify.deploy" || !delivery.Timestamp.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+}
+
+func TestRenderSource_ParseAndVerify(t *testing.T) {
+	key := []byte("render-signing-key")
+	source := &RenderSource{Secret: "whsec_" + base64.StdEncoding.EncodeToString(key)}
+	body := []byte(`{"type":"deploy_ended","timestamp":"2024-01-15T10:30:00Z","data":{"id":"evj-1"}}`)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("msg_1." + timestamp + "."))
+	mac.Write(body)
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/render", http.NoBody)
+	req.Header.Set("webhook-id", "msg_1")
+	req.Header.Set("webhook-timestamp", timestamp)
+	if err := source.Verify(req, body); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+	req.Header.Set("webhook-signature", "v1,bm9wZQ== v1,"+signature)
+	if err := source.Verify(req, body); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+	if err := source.Verify(req, []byte(`{}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a tampered body, got %v", err)
+	}
+	req.Header.Set("webhook-timestamp", strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+	if err := source.Verify(req, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a stale timestamp, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "render.deploy" || delivery.ID != "msg_1" {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+
+	if _, err := source.Parse(req, []byte(`{"type":"build_started"}`)); !errors.Is(err, ErrIgnoredEvent) {
+		t.Errorf("expected ErrIgnoredEvent for build_started, got %v", err)
+	}
+}
+
+func TestLinearSource_ParseAndVerify(t *testing.T) {
+	source := &LinearSource{Secret: "linear-secret"}
+	now := time.Now().UnixMilli()
+	body := []byte(`{"action":"create","type":"Issue","webhookTimestamp":` + strconv.FormatInt(now, 10) + `}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/linear", http.NoBody)
+	req.Header.Set("Linear-Event", "Issue")
+	req.Header.Set("Linear-Delivery", "del-1")
+	if err := source.Verify(req, body); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+	req.Header.Set("Linear-Signature", signSHA256("linear-secret", body))
+	if err := source.Verify(req, body); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+
+	stale := []byte(`{"action":"create","type":"Issue","webhookTimestamp":` + strconv.FormatInt(now-time.Hour.Milliseconds(), 10) + `}`)
+	req.Header.Set("Linear-Signature", signSHA256("linear-secret", stale))
+	if err := source.Verify(req, stale); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a stale webhookTimestamp, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "linear.issue" || delivery.ID != "del-1" || delivery.Timestamp.UnixMilli() != now {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+
+	req.Header.Set("Linear-Event", "Reaction")
+	if _, err := source.Parse(req, body); !errors.Is(err, ErrIgnoredEvent) {
+		t.Errorf("expected ErrIgnoredEvent for Reaction, got %v", err)
+	}
+}
+
+func TestJiraSource_ParseAndVerify(t *testing.T) {
+	source := &JiraSource{Secret: "jira-secret"}
+	body := []byte(`{"webhookEvent":"jira:issue_updated","timestamp":1705314600000}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/jira", http.NoBody)
+	req.Header.Set("X-Atlassian-Webhook-Identifier", "wh-1")
+	if err := source.Verify(req, body); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+	req.Header.Set("X-Hub-Signature", "sha256="+signSHA256("jira-secret", body))
+	if err := source.Verify(req, body); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "jira.issue" || delivery.ID != "wh-1" {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+	if expected := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC); !delivery.Timestamp.Equal(expected) {
+		t.Errorf("expected timestamp %v, got %v", expected, delivery.Timestamp)
+	}
+
+	if _, err := source.Parse(req, []byte(`{"webhookEvent":"sprint_started"}`)); !errors.Is(err, ErrIgnoredEvent) {
+		t.Errorf("expected ErrIgnoredEvent for sprint_started, got %v", err)
+	}
+}
+
+func TestPagerDutySource_ParseAndVerify(t *testing.T) {
+	source := &PagerDutySource{Secret: "pd-secret"}
+	body := []byte(`{"event":{"id":"01EVT","event_type":"incident.triggered","occurred_at":"2024-01-15T10:30:00.000Z"}}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/pagerduty", http.NoBody)
+	if err := source.Verify(req, body); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature, got %v", err)
+	}
+	// During secret rotation PagerDuty sends one signature per secret
+	req.Header.Set("X-PagerDuty-Signature", "v1="+signSHA256("old-secret", body)+",v1="+signSHA256("pd-secret", body))
+	if err := source.Verify(req, body); err != nil {
+		t.Errorf("expected valid signature, got %v", err)
+	}
+	req.Header.Set("X-PagerDuty-Signature", "v1="+signSHA256("old-secret", body))
+	if err := source.Verify(req, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "pagerduty.incident" || delivery.ID != "01EVT" {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+	if expected := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC); !delivery.Timestamp.Equal(expected) {
+		t.Errorf("expected timestamp %v, got %v", expected, delivery.Timestamp)
+	}
+
+	// Reopens start a new open interval of the incident
+	reopened, err := source.Parse(req, []byte(`{"event":{"id":"01REO","event_type":"incident.reopened","occurred_at":"2024-01-15T11:00:00.000Z"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error for incident.reopened: %v", err)
+	}
+	if reopened.EventType != "pagerduty.incident" || reopened.ID != "01REO" {
+		t.Errorf("unexpected reopened delivery: %+v", reopened)
+	}
+
+	if _, err := source.Parse(req, []byte(`{"event":{"id":"01P","event_type":"pagey.ping"}}`)); !errors.Is(err, ErrIgnoredEvent) {
+		t.Errorf("expected ErrIgnoredEvent for pagey.ping, got %v", err)
+	}
+}
+
+func TestDockerHubSource_ParseAndVerify(t *testing.T) {
+	source := &DockerHubSource{Token: "hub-token"}
+	body := []byte(`{"push_data":{"pushed_at":1705314600,"pusher":"ci-bot","tag":"latest"},"repository":{"repo_name":"acme/api"}}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/dockerhub?token=wrong", http.NoBody)
+	if err := source.Verify(req, body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/api/webhook/dockerhub?token=hub-token", http.NoBody)
+	if err := source.Verify(req, body); err != nil {
+		t.Errorf("expected valid token, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delivery.EventType != "dockerhub.push" || !delivery.Timestamp.Equal(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected delivery: %+v", delivery)
+	}
+
+	if _, err := source.Parse(req, []byte(`{"repository":{}}`)); err == nil {
+		t.Error("expected error for a payload without push_data")
+	}
+}
+
+func TestCustomSource_ParseAndVerify(t *testing.T) {
+	t.Setenv("BACKUP_WEBHOOK_TOKEN", "s3cret")
+
+	source, err := NewCustomSource(transformers.MappingSource{
+		Name:       "nightly-backup",
+		Auth:       &transformers.MappingAuth{Scheme: transformers.MappingAuthToken, Header: "X-Backup-Token", SecretEnv: "BACKUP_WEBHOOK_TOKEN"},
+		DeliveryID: &transformers.MappingSelector{Path: "$.run_id"},
+		Timestamp:  &transformers.MappingSelector{Path: "$.finished_at"},
+		Rules: []transformers.MappingRule{
+			{Match: &transformers.MappingMatch{MappingSelector: transformers.MappingSelector{Header: "X-Backup-Event"}, Equals: "failed"}, EventType: "cron.failed", Title: "failed"},
+			{EventType: "cron.succeeded", Title: "ok"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewCustomSource: %v", err)
+	}
+
+	body := []byte(`{"run_id": 9007199254740993, "finished_at": 1709258400000}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/custom/nightly-backup", nil)
+	req.Header.Set("X-Backup-Event", "failed")
+
+	if err := source.Verify(req, body); !errors.Is(err, ErrMissingSignature) {
+		t.Errorf("expected ErrMissingSignature without token, got %v", err)
+	}
+	req.Header.Set("X-Backup-Token", "s3cret")
+	if err := source.Verify(req, body); err != nil {
+		t.Errorf("expected valid token to verify, got %v", err)
+	}
+
+	delivery, err := source.Parse(req, body)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if delivery.EventType != "cron.failed" {
+		t.Errorf("expected header match to select cron.failed, got %s", delivery.EventType)
+	}
+	if delivery.ID != "9007199254740993" {
+		t.Errorf("expected exact delivery ID, got %s", delivery.ID)
+	}
+	if !delivery.Timestamp.Equal(time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected millisecond timestamp to be parsed, got %v", delivery.Timestamp)
+	}
+
+	req.Header.Del("X-Backup-Event")
+	delivery, err = source.Parse(req, body)
+	if err != nil || delivery.EventType != "cron.succeeded" {
+		t.Errorf("expected fallback rule cron.succeeded, got %s (%v)", delivery.EventType, err)
+	}
+}
+
+func TestCustomSource_VerifyRequiresAuth(t *testing.T) {
+	source, err := NewCustomSource(transformers.MappingSource{
+		Name:  "cron",
+		Rules: []transformers.MappingRule{{EventType: "cron.run", Title: "x"}},
+	})
+	if err != nil {
+		t.Fatalf("NewCustomSource: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook/custom/cron", http.NoBody)
+	if err := source.Verify(req, []byte(`{}`)); !errors.Is(err, ErrNoSecret) {
+		t.Errorf("expected ErrNoSecret, got %v", err)
+	}
+	if err := Unsigned(source).Verify(req, []byte(`{}`)); err != nil {
+		t.Errorf("expected Unsigned to accept the delivery, got %v", err)
+	}
+}
+
+func TestNewCustomSource_RequiresSecret(t *testing.T) {
+	_, err := NewCustomSource(transformers.MappingSource{
+		Name:  "cron",
+		Auth:  &transformers.MappingAuth{Scheme: transformers.MappingAuthToken, Header: "X-Token", SecretEnv: "HEIMDALL_TEST_UNSET_SECRET"},
+		Rules: []transformers.MappingRule{{EventType: "cron.run", Title: "x"}},
+	})
+	if err == nil {
+		t.Error("expected an error when the secret environment variable is unset")
+	}
+}
@@ -0,0 +1,48 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VercelSource handles native Vercel webhook deliveries
+type VercelSource struct {
+	Secret string
+}
+
+// Name returns the provider name
+func (s *VercelSource) Name() string {
+	return "vercel"
+}
+
+// Verify checks x-vercel-signature
+func (s *VercelSource) Verify(r *http.Request, body []byte) error {
+	if s.Secret == "" {
+		return ErrNoSecret
+	}
+	return VerifyVercelSignature(s.Secret, body, r.Header.Get("x-vercel-signature"))
+}
+
+// Parse resolves the event type from the payload's "type" field
+func (s *VercelSource) Parse(_ *http.Request, body []byte) (Delivery, error) {
+	var envelope struct {
+		Type      string `json:"type"`
+		CreatedAt int64  `json:"createdAt"` // Unix milliseconds
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return Delivery{}, fmt.Errorf("failed to parse Vercel payload: %w", err)
+	}
+
+	if !strings.HasPrefix(envelope.Type, "deployment") {
+		return Delivery{}, fmt.Errorf("%w: unsupported Vercel event %q", ErrIgnoredEvent, envelope.Type)
+	}
+
+	delivery := Delivery{EventType: "vercel.deploy"}
+	if envelope.CreatedAt > 0 {
+		delivery.Timestamp = time.UnixMilli(envelope.CreatedAt).UTC()
+	}
+	return delivery, nil
+}
@@ -8,7 +8,9 @@ CREATE TABLE IF NOT EXISTS events (
     event_type VARCHAR(100) NOT NULL,
     title VARCHAR(500) NOT NULL,
     metadata JSONB,
-    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    source VARCHAR(50),
+    external_id VARCHAR(255)
 );
 
 -- Create an index on event_type for faster queries
@@ -20,8 +22,58 @@ CREATE INDEX IF NOT EXISTS idx_events_created_at ON events (created_at DESC);
 -- Create a composite index for common queries
 CREATE INDEX IF NOT EXISTS idx_events_type_created ON events (event_type, created_at DESC);
 
+-- Deduplicate redelivered webhooks by provider delivery identity
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_source_external_id ON events (source, external_id);
+
+-- Webhook deliveries that failed transformation, kept for replay
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id VARCHAR(255) PRIMARY KEY DEFAULT uuid_generate_v4()::text,
+    source VARCHAR(50) NOT NULL,
+    delivery_id VARCHAR(255),
+    event_type VARCHAR(100) NOT NULL,
+    payload TEXT NOT NULL,
+    headers JSONB,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dead_letters_source_delivery ON dead_letters (source, delivery_id);
+CREATE INDEX IF NOT EXISTS idx_dead_letters_created_at ON dead_letters (created_at DESC);
+
+-- Original webhook payloads, kept so transformers can be re-run over history
+CREATE TABLE IF NOT EXISTS event_payloads (
+    id VARCHAR(255) PRIMARY KEY DEFAULT uuid_generate_v4()::text,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS payload_id VARCHAR(255)
+    REFERENCES event_payloads (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_payload_id ON events (payload_id);
+CREATE INDEX IF NOT EXISTS idx_event_payloads_type_received ON event_payloads (event_type, received_at);
+
+-- Category assigned from the event type when the event is stored
+ALTER TABLE events ADD COLUMN IF NOT EXISTS category VARCHAR(50) NOT NULL DEFAULT 'development';
+ALTER TABLE events ADD COLUMN IF NOT EXISTS subcategory VARCHAR(50) NOT NULL DEFAULT 'other';
+
+CREATE INDEX IF NOT EXISTS idx_events_category_created ON events (category, created_at DESC);
+
+-- Commit SHA prefix lookups for pipeline tracing
+CREATE INDEX IF NOT EXISTS idx_events_commit_sha ON events ((metadata->>'commit_sha') text_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_events_merge_commit_sha ON events ((metadata->>'merge_commit_sha') text_pattern_ops);
+
+-- Pull request events by repo and number for cycle-time analytics
+CREATE INDEX IF NOT EXISTS idx_events_pull_request ON events ((metadata->>'repo'), (metadata->>'number'), created_at)
+    WHERE event_type IN ('github.pr', 'github.review', 'github.review_comment', 'github.comment');
+
 -- Insert some sample data for testing
-INSERT INTO events (event_type, title, metadata) VALUES 
-    ('github.push', 'Push to heimdall', '{"repo": "heimdall", "message": "Initial commit", "author": "roe"}'),
-    ('vercel.deploy', 'Deployment to production', '{"project": "heimdall", "status": "success", "url": "https://heimdall.vercel.app"}'),
-    ('github.push', 'Push to dashboard-ui', '{"repo": "dashboard-ui", "message": "Add real-time updates", "author": "roe"}');
\ No newline at end of file
+INSERT INTO events (event_type, title, metadata, category, subcategory) VALUES 
+    ('github.push', 'Push to heimdall', '{"repo": "heimdall", "message": "Initial commit", "author": "roe"}', 'development', 'commits'),
+    ('vercel.deploy', 'Deployment to production', '{"project": "heimdall", "status": "success", "url": "https://heimdall.vercel.app"}', 'deployments', 'deploys'),
+    ('github.push', 'Push to dashboard-ui', '{"repo": "dashboard-ui", "message": "Add real-time updates", "author": "roe"}', 'development', 'commits');
\ No newline at end of file
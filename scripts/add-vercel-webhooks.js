@@ -25,7 +25,15 @@ const { execSync } = require('child_process');
 // Configuration
 const VERCEL_API_BASE = 'https://api.vercel.com';
 const DEFAULT_WEBHOOK_URL = 'https://heimdall-ashen.vercel.app/api/webhook';
-const WEBHOOK_EVENTS = ['deployment.created', 'deployment.succeeded', 'deployment.error'];
+const WEBHOOK_EVENTS = [
+  'deployment.created',
+  'deployment.succeeded',
+  'deployment.ready',
+  'deployment.promoted',
+  'deployment.error',
+  'deployment.canceled',
+  'deployment.check-rerequested',
+];
 
 // Colors for console output
 const colors = {
@@ -24,7 +24,31 @@ const { promisify } = require('util');
 // Configuration
 const GITHUB_API_BASE = 'https://api.github.com';
 const DEFAULT_WEBHOOK_URL = 'https://heimdall-ashen.vercel.app/api/webhook';
-const WEBHOOK_EVENTS = ['push', 'create', 'delete', 'release'];
+const WEBHOOK_EVENTS = [
+  'push',
+  'create',
+  'delete',
+  'release',
+  'workflow_run',
+  'workflow_job',
+  'check_suite',
+  'dependabot_alert',
+  'code_scanning_alert',
+  'secret_scanning_alert',
+  'repository_vulnerability_alert',
+  'pull_request_review',
+  'pull_request_review_comment',
+  'issue_comment',
+  'discussion',
+  'discussion_comment',
+  'fork',
+  'star',
+  'watch',
+  'deployment',
+  'deployment_status',
+  'package',
+  'registry_package',
+];
 const DEFAULT_WEBHOOK_SECRET = 'heimdall-webhook-secret-2024';
 
 // Colors for console output
@@ -9,6 +9,35 @@ interface QStashPayload {
   event: any;
 }
 
+// X-GitHub-Event values and the backend transformer that handles each of them
+const GITHUB_EVENT_TYPES: Record<string, string> = {
+  push: 'github.push',
+  pull_request: 'github.pr',
+  issues: 'github.issue',
+  release: 'github.release',
+  workflow_run: 'github.workflow_run',
+  workflow_job: 'github.workflow_job',
+  check_suite: 'github.check_suite',
+  dependabot_alert: 'github.dependabot_alert',
+  code_scanning_alert: 'github.code_scanning_alert',
+  secret_scanning_alert: 'github.secret_scanning_alert',
+  repository_vulnerability_alert: 'github.repository_vulnerability_alert',
+  pull_request_review: 'github.pull_request_review',
+  pull_request_review_comment: 'github.pull_request_review_comment',
+  issue_comment: 'github.issue_comment',
+  discussion: 'github.discussion',
+  discussion_comment: 'github.discussion_comment',
+  create: 'github.create',
+  delete: 'github.delete',
+  fork: 'github.fork',
+  star: 'github.star',
+  watch: 'github.watch',
+  deployment: 'github.deployment',
+  deployment_status: 'github.deployment_status',
+  package: 'github.package',
+  registry_package: 'github.registry_package',
+};
+
 // Handle CORS preflight requests
 export async function OPTIONS(req: NextRequest) {
   return new NextResponse(null, {
@@ -107,24 +136,9 @@ export async function POST(req: NextRequest) {
       isVercelByStructure: payload?.type?.startsWith('deployment.') && !!payload?.payload,
     });
 
-    if (githubEvent === 'push') {
-      qstashPayload = {
-        type: 'github.push',
-        event: payload,
-      };
-    } else if (githubEvent === 'pull_request') {
-      qstashPayload = {
-        type: 'github.pr',
-        event: payload,
-      };
-    } else if (githubEvent === 'issues') {
-      qstashPayload = {
-        type: 'github.issue',
-        event: payload,
-      };
-    } else if (githubEvent === 'release') {
+    if (githubEvent && GITHUB_EVENT_TYPES[githubEvent]) {
       qstashPayload = {
-        type: 'github.release',
+        type: GITHUB_EVENT_TYPES[githubEvent],
         event: payload,
       };
     } else if (
@@ -5,7 +5,22 @@ import { Button } from '@/components/ui/button';
 import { Badge } from '@/components/ui/badge';
 import { useCategories, useCategoryOperations } from '@/contexts/CategoryContext';
 import { DEFAULT_SERVICES } from '@/types/categories';
-import { GitBranch, Zap, Train, Activity, Shield, Globe, Terminal, LucideIcon } from 'lucide-react';
+import {
+  GitBranch,
+  Gitlab,
+  Zap,
+  Train,
+  Activity,
+  Shield,
+  Globe,
+  Server,
+  Package,
+  ListChecks,
+  Ticket,
+  BellRing,
+  Terminal,
+  LucideIcon,
+} from 'lucide-react';
 import { cn } from '@/lib/utils';
 
 interface ServiceFilterProps {
@@ -16,11 +31,17 @@ interface ServiceFilterProps {
 // Icon mapping for services
 const serviceIcons: Record<string, LucideIcon> = {
   GitBranch,
+  Gitlab,
   Zap,
   Train,
   Activity,
   Shield,
   Globe,
+  Server,
+  Package,
+  ListChecks,
+  Ticket,
+  BellRing,
 };
 
 // Service to neon color mapping
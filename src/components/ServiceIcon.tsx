@@ -1,14 +1,36 @@
 'use client';
 
 import React from 'react';
-import { GitBranch, Zap, Train, Monitor, HelpCircle } from 'lucide-react';
+import {
+  GitBranch,
+  Gitlab,
+  Zap,
+  Train,
+  Globe,
+  Server,
+  Package,
+  ListChecks,
+  Ticket,
+  BellRing,
+  Bug,
+  Monitor,
+  HelpCircle,
+} from 'lucide-react';
 import { getServiceFromEventType, getServiceColorClasses, ServiceInfo } from '@/types/services';
 
 // Icon mapping for services (only includes icons for supported services)
 const SERVICE_ICONS = {
   GitBranch, // GitHub
+  Gitlab, // GitLab
   Zap, // Vercel
   Train, // Railway
+  Globe, // Netlify
+  Server, // Render
+  Package, // Container registries
+  ListChecks, // Linear
+  Ticket, // Jira
+  BellRing, // PagerDuty
+  Bug, // Sentry
   Monitor, // System
   HelpCircle, // Unknown/fallback
 } as const;
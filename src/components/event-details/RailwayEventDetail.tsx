@@ -22,7 +22,16 @@ interface RailwayEventDetailProps {
 interface RailwayMetadata {
   service_name?: string;
   deployment_url?: string;
-  status?: 'SUCCESS' | 'BUILDING' | 'FAILED' | 'CRASHED' | 'DEPLOYING';
+  status?:
+    | 'SUCCESS'
+    | 'BUILDING'
+    | 'FAILED'
+    | 'CRASHED'
+    | 'DEPLOYING'
+    | 'CANCELED'
+    | 'REMOVED'
+    | 'RESTARTED'
+    | 'SLEEPING';
   environment?: 'production' | 'staging' | 'development';
   project_name?: string;
   branch?: string;
@@ -50,6 +59,10 @@ export default function RailwayEventDetail({ event }: RailwayEventDetailProps) {
       case 'failed':
       case 'crashed':
         return <XCircle className="h-4 w-4 text-red-500" />;
+      case 'canceled':
+      case 'removed':
+      case 'sleeping':
+        return <Clock className="h-4 w-4 text-gray-500" />;
       default:
         return <Train className="h-4 w-4 text-purple-500" />;
     }
@@ -65,6 +78,10 @@ export default function RailwayEventDetail({ event }: RailwayEventDetailProps) {
       case 'failed':
       case 'crashed':
         return 'bg-red-50 text-red-700 border-red-200 dark:bg-red-950 dark:text-red-300';
+      case 'canceled':
+      case 'removed':
+      case 'sleeping':
+        return 'bg-gray-50 text-gray-700 border-gray-200 dark:bg-gray-950 dark:text-gray-300';
       default:
         return 'bg-purple-50 text-purple-700 border-purple-200 dark:bg-purple-950 dark:text-purple-300';
     }
@@ -21,7 +21,15 @@ interface VercelEventDetailProps {
 interface VercelMetadata {
   deployment_url?: string;
   project_name?: string;
-  status?: 'READY' | 'BUILDING' | 'ERROR' | 'CANCELED' | 'QUEUED';
+  status?:
+    | 'SUCCESS'
+    | 'PROMOTED'
+    | 'BUILDING'
+    | 'FAILED'
+    | 'CANCELED'
+    | 'READY'
+    | 'ERROR'
+    | 'QUEUED';
   environment?: 'production' | 'preview' | 'development';
   branch?: string;
   commit_sha?: string;
@@ -38,11 +46,14 @@ export default function VercelEventDetail({ event }: VercelEventDetailProps) {
 
   const getStatusIcon = (status?: string) => {
     switch (status?.toLowerCase()) {
+      case 'success':
+      case 'promoted':
       case 'ready':
         return <CheckCircle className="h-4 w-4 text-green-500" />;
       case 'building':
       case 'queued':
         return <Timer className="h-4 w-4 text-yellow-500" />;
+      case 'failed':
       case 'error':
         return <XCircle className="h-4 w-4 text-red-500" />;
       case 'canceled':
@@ -54,11 +65,14 @@ export default function VercelEventDetail({ event }: VercelEventDetailProps) {
 
   const getStatusColor = (status?: string) => {
     switch (status?.toLowerCase()) {
+      case 'success':
+      case 'promoted':
       case 'ready':
         return 'bg-green-50 text-green-700 border-green-200 dark:bg-green-950 dark:text-green-300';
       case 'building':
       case 'queued':
         return 'bg-yellow-50 text-yellow-700 border-yellow-200 dark:bg-yellow-950 dark:text-yellow-300';
+      case 'failed':
       case 'error':
         return 'bg-red-50 text-red-700 border-red-200 dark:bg-red-950 dark:text-red-300';
       case 'canceled':
@@ -12,7 +12,6 @@ import {
   EventStatus,
   DEFAULT_CATEGORIES,
   DEFAULT_SERVICES,
-  classifyEvent,
   extractService,
   extractEventStatus,
   extractRepository,
@@ -84,9 +83,8 @@ export function CategoryProvider({ children }: { children: React.ReactNode }) {
   // Helper function to get category for an event - O(1) Map lookup
   const getEventCategory = useCallback(
     (event: DashboardEvent): EventCategory => {
-      // Use backend category if available, otherwise classify from event_type
-      const categoryId = event.category || classifyEvent(event.event_type);
-      return categoryMap.get(categoryId) || categories[0];
+      // Categories come from the backend rules; unknown ones fall back to the first category
+      return (event.category && categoryMap.get(event.category)) || categories[0];
     },
     [categoryMap, categories]
   );
@@ -167,8 +165,7 @@ export function CategoryProvider({ children }: { children: React.ReactNode }) {
       return events.filter((event) => {
         // Category filter - O(1) with Map lookup
         if (filter.selectedCategory && filter.selectedCategory !== 'all') {
-          const categoryId = event.category || classifyEvent(event.event_type);
-          if (categoryId !== filter.selectedCategory) return false;
+          if (event.category !== filter.selectedCategory) return false;
         }
 
         // Service filter - uses cached extractService
@@ -1,65 +1,12 @@
 import {
-  classifyEvent,
   getCategoryById,
   extractService,
   getServiceById,
   getCategoryColorClasses,
   DEFAULT_CATEGORIES,
   DEFAULT_SERVICES,
-  EVENT_TYPE_CATEGORY_MAP,
 } from './categories';
 
-describe('classifyEvent', () => {
-  describe('direct mapping', () => {
-    it.each(Object.entries(EVENT_TYPE_CATEGORY_MAP))(
-      'should classify %s as %s',
-      (eventType, expectedCategory) => {
-        expect(classifyEvent(eventType)).toBe(expectedCategory);
-      }
-    );
-  });
-
-  describe('fallback pattern matching', () => {
-    it('should classify unknown github events as development', () => {
-      expect(classifyEvent('github.unknown')).toBe('development');
-      expect(classifyEvent('github.workflow')).toBe('development');
-    });
-
-    it('should classify unknown vercel events as deployments', () => {
-      expect(classifyEvent('vercel.unknown')).toBe('deployments');
-      expect(classifyEvent('vercel.build')).toBe('deployments');
-    });
-
-    it('should classify unknown railway events as deployments', () => {
-      expect(classifyEvent('railway.unknown')).toBe('deployments');
-      expect(classifyEvent('railway.build')).toBe('deployments');
-    });
-
-    it('should classify unknown error events as issues', () => {
-      expect(classifyEvent('error.unknown')).toBe('issues');
-      expect(classifyEvent('error.runtime')).toBe('issues');
-    });
-
-    it('should classify unknown security events as security', () => {
-      expect(classifyEvent('security.unknown')).toBe('security');
-      expect(classifyEvent('security.breach')).toBe('security');
-    });
-
-    it('should classify unknown monitoring events as infrastructure', () => {
-      expect(classifyEvent('monitoring.unknown')).toBe('infrastructure');
-      expect(classifyEvent('monitoring.cpu')).toBe('infrastructure');
-    });
-  });
-
-  describe('default fallback', () => {
-    it('should default to development for completely unknown types', () => {
-      expect(classifyEvent('unknown.event')).toBe('development');
-      expect(classifyEvent('custom.type')).toBe('development');
-      expect(classifyEvent('')).toBe('development');
-    });
-  });
-});
-
 describe('getCategoryById', () => {
   it('should return category for valid ID', () => {
     const category = getCategoryById('development');
@@ -13,8 +13,8 @@ export interface EventCategory {
 export interface DashboardEvent {
   id: string;
   event_type: string;
-  category?: string; // Optional - will be computed from event_type if not present
-  subcategory?: string; // Optional subcategory
+  category?: string; // Assigned by the backend from its category rules when stored
+  subcategory?: string; // Backend subcategory (commits, ci, incidents, ...)
   title: string;
   metadata: GenericMetadata;
   created_at: string;
@@ -74,7 +74,7 @@ export const DEFAULT_CATEGORIES: EventCategory[] = [
   {
     id: 'deployments',
     name: 'Deployments',
-    description: 'Application deployments and builds',
+    description: 'Application deployments, builds and CI runs',
     icon: 'Rocket',
     color: 'green',
     priority: 2,
@@ -105,40 +105,6 @@ export const DEFAULT_CATEGORIES: EventCategory[] = [
   },
 ];
 
-// Event type to category mapping (frontend classification)
-export const EVENT_TYPE_CATEGORY_MAP: Record<string, string> = {
-  'github.push': 'development',
-  'github.pr': 'development',
-  'github.issue': 'issues',
-  'github.release': 'development',
-  'vercel.deploy': 'deployments',
-  'railway.deploy': 'deployments',
-  'error.system': 'issues',
-  'error.build': 'issues',
-  'security.vulnerability': 'security',
-  'security.audit': 'security',
-  'monitoring.alert': 'infrastructure',
-  'monitoring.performance': 'infrastructure',
-};
-
-// Helper function to classify event based on event_type
-export function classifyEvent(eventType: string): string {
-  // Check direct mapping first
-  if (EVENT_TYPE_CATEGORY_MAP[eventType]) {
-    return EVENT_TYPE_CATEGORY_MAP[eventType];
-  }
-
-  // Fallback pattern matching
-  if (eventType.startsWith('github.')) return 'development';
-  if (eventType.startsWith('vercel.') || eventType.startsWith('railway.')) return 'deployments';
-  if (eventType.startsWith('error.')) return 'issues';
-  if (eventType.startsWith('security.')) return 'security';
-  if (eventType.startsWith('monitoring.')) return 'infrastructure';
-
-  // Default fallback
-  return 'development';
-}
-
 // Helper function to get category by ID
 export function getCategoryById(categoryId: string): EventCategory | undefined {
   return DEFAULT_CATEGORIES.find((cat) => cat.id === categoryId);
@@ -154,6 +120,14 @@ export const DEFAULT_SERVICES: ServiceType[] = [
     color: 'slate',
     pattern: '^github\\.',
   },
+  {
+    id: 'gitlab',
+    name: 'GitLab',
+    description: 'GitLab pushes, merge requests and pipelines',
+    icon: 'Gitlab',
+    color: 'orange',
+    pattern: '^gitlab\\.',
+  },
   {
     id: 'vercel',
     name: 'Vercel',
@@ -170,6 +144,54 @@ export const DEFAULT_SERVICES: ServiceType[] = [
     color: 'violet',
     pattern: '^railway\\.',
   },
+  {
+    id: 'netlify',
+    name: 'Netlify',
+    description: 'Static site and frontend deployments',
+    icon: 'Globe',
+    color: 'green',
+    pattern: '^netlify\\.',
+  },
+  {
+    id: 'render',
+    name: 'Render',
+    description: 'Web service and backend deployments',
+    icon: 'Server',
+    color: 'blue',
+    pattern: '^render\\.',
+  },
+  {
+    id: 'artifact',
+    name: 'Artifacts',
+    description: 'Container images and packages published to registries',
+    icon: 'Package',
+    color: 'purple',
+    pattern: '^artifact\\.',
+  },
+  {
+    id: 'linear',
+    name: 'Linear',
+    description: 'Issue and comment activity from Linear',
+    icon: 'ListChecks',
+    color: 'violet',
+    pattern: '^issues\\.linear',
+  },
+  {
+    id: 'jira',
+    name: 'Jira',
+    description: 'Issue and workflow activity from Jira',
+    icon: 'Ticket',
+    color: 'blue',
+    pattern: '^issues\\.jira',
+  },
+  {
+    id: 'pagerduty',
+    name: 'PagerDuty',
+    description: 'Incidents and on-call pages',
+    icon: 'BellRing',
+    color: 'green',
+    pattern: '^incident\\.',
+  },
   {
     id: 'monitoring',
     name: 'Monitoring',
@@ -335,8 +357,8 @@ export function extractEventStatus(event: DashboardEvent): EventStatus | null {
   // Check state field (used by some providers)
   if (metadata.state) {
     const state = String(metadata.state).toLowerCase();
-    if (state === 'success' || state === 'ready') return 'success';
-    if (state === 'failure' || state === 'error') return 'failure';
+    if (state === 'success' || state === 'ready' || state === 'up') return 'success';
+    if (state === 'failure' || state === 'error' || state === 'down') return 'failure';
     if (state === 'pending' || state === 'building') return 'pending';
   }
 
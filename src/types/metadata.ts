@@ -76,10 +76,13 @@ export type GitHubReleaseMetadata = z.infer<typeof GitHubReleaseMetadataSchema>;
 // ============================================================================
 
 export const VercelDeploymentStatusSchema = z.enum([
-  'READY',
+  'SUCCESS',
+  'PROMOTED',
   'BUILDING',
-  'ERROR',
+  'FAILED',
   'CANCELED',
+  'READY',
+  'ERROR',
   'QUEUED',
 ]);
 export type VercelDeploymentStatus = z.infer<typeof VercelDeploymentStatusSchema>;
@@ -99,6 +102,9 @@ export const VercelDeployMetadataSchema = z.object({
   target: z.string().optional(),
   branch: z.string().optional(),
   commit_sha: z.string().optional(),
+  commit_url: z.string().optional(),
+  commit_message: z.string().optional(),
+  repo: z.string().optional(),
   author: z.string().optional(),
   build_time: z.number().optional(),
   created_at: z.string().optional(),
@@ -120,6 +126,10 @@ export const RailwayDeploymentStatusSchema = z.enum([
   'FAILED',
   'CRASHED',
   'DEPLOYING',
+  'CANCELED',
+  'REMOVED',
+  'RESTARTED',
+  'SLEEPING',
 ]);
 export type RailwayDeploymentStatus = z.infer<typeof RailwayDeploymentStatusSchema>;
 
@@ -140,6 +150,8 @@ export const RailwayDeployMetadataSchema = z.object({
   logs_url: z.string().optional(),
   branch: z.string().optional(),
   commit_sha: z.string().optional(),
+  commit_message: z.string().optional(),
+  repo: z.string().optional(),
   author: z.string().optional(),
   build_time: z.number().optional(),
   memory_limit: z.number().optional(),
@@ -147,6 +159,7 @@ export const RailwayDeployMetadataSchema = z.object({
   creator_name: z.string().optional(),
   creator_id: z.string().optional(),
   event_type: z.string().optional(),
+  railway_status: z.string().optional(),
   timestamp: z.string().optional(),
 });
 export type RailwayDeployMetadata = z.infer<typeof RailwayDeployMetadataSchema>;
@@ -18,6 +18,13 @@ export const SERVICES: ServiceInfo[] = [
     color: 'gray',
     description: 'Git repository and collaboration platform',
   },
+  {
+    id: 'gitlab',
+    name: 'GitLab',
+    icon: 'Gitlab',
+    color: 'orange',
+    description: 'Git repository and CI/CD platform',
+  },
   {
     id: 'vercel',
     name: 'Vercel',
@@ -32,6 +39,55 @@ export const SERVICES: ServiceInfo[] = [
     color: 'purple',
     description: 'Infrastructure deployment platform',
   },
+  {
+    id: 'netlify',
+    name: 'Netlify',
+    icon: 'Globe',
+    color: 'teal',
+    description: 'Frontend deployment and hosting platform',
+  },
+  {
+    id: 'render',
+    name: 'Render',
+    icon: 'Server',
+    color: 'indigo',
+    description: 'Cloud application hosting platform',
+  },
+  {
+    id: 'artifact',
+    name: 'Artifacts',
+    icon: 'Package',
+    color: 'blue',
+    description: 'Container images and packages pushed to Docker Hub and GHCR',
+  },
+  {
+    id: 'linear',
+    name: 'Linear',
+    icon: 'ListChecks',
+    color: 'indigo',
+    description: 'Issue tracking and project planning',
+  },
+  {
+    id: 'jira',
+    name: 'Jira',
+    icon: 'Ticket',
+    color: 'blue',
+    description: 'Issue tracking and project planning',
+  },
+  {
+    id: 'pagerduty',
+    name: 'PagerDuty',
+    icon: 'BellRing',
+    color: 'green',
+    description: 'Incident response and on-call paging',
+  },
+  {
+    id: 'sentry',
+    name: 'Sentry',
+    icon: 'Bug',
+    color: 'red',
+    description: 'Error tracking and alerting',
+  },
   {
     id: 'system',
     name: 'System',
@@ -56,6 +112,28 @@ export const EVENT_TYPE_SERVICE_MAP: Record<string, string> = {
   'github.pr': 'github',
   'github.issue': 'github',
   'github.release': 'github',
+  'github.ci': 'github',
+  'github.ci_job': 'github',
+  'github.review': 'github',
+  'github.review_comment': 'github',
+  'github.comment': 'github',
+  'github.discussion': 'github',
+  'github.discussion_comment': 'github',
+  'github.branch': 'github',
+  'github.tag': 'github',
+  'github.community': 'github',
+  'github.deploy': 'github',
+  'security.dependabot': 'github',
+  'security.code_scanning': 'github',
+  'security.secret_scanning': 'github',
+  'security.vulnerability': 'github',
+
+  // GitLab events
+  'gitlab.push': 'gitlab',
+  'gitlab.mr': 'gitlab',
+  'gitlab.tag': 'gitlab',
+  'gitlab.release': 'gitlab',
+  'gitlab.ci': 'gitlab',
 
   // Vercel events
   'vercel.deploy': 'vercel',
@@ -67,12 +145,39 @@ export const EVENT_TYPE_SERVICE_MAP: Record<string, string> = {
   'railway.build': 'railway',
   'railway.error': 'railway',
 
+  // Netlify events
+  'netlify.deploy': 'netlify',
+
+  // Render events
+  'render.deploy': 'render',
+
+  // Registry events
+  'artifact.image': 'artifact',
+  'artifact.package': 'artifact',
+
+  // Issue tracker events
+  'issues.linear': 'linear',
+  'issues.linear_comment': 'linear',
+  'issues.jira': 'jira',
+
+  // Incident events
+  'incident.triggered': 'pagerduty',
+  'incident.acknowledged': 'pagerduty',
+  'incident.escalated': 'pagerduty',
+  'incident.resolved': 'pagerduty',
+  'incident.reopened': 'pagerduty',
+
+  // Sentry events
+  'error.issue': 'sentry',
+  'error.alert': 'sentry',
+
   // System events
   'system.error': 'system',
   'system.alert': 'system',
   'system.monitor': 'system',
   'error.system': 'system',
   'monitoring.alert': 'system',
+  'monitoring.check': 'system',
 };
 
 // Helper function to get service info from event type